@@ -0,0 +1,280 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultConcurrency = 4
+	gitignoreFileName  = ".gitignore"
+	gitDirName         = ".git"
+)
+
+type fileEntry struct {
+	path string
+	rel  string
+	info os.FileInfo
+}
+
+// loadDir loads files under root recursively, pattern is an optional glob pattern relative to root
+// in addition to Include. Documents are returned in the lexical order of relative paths.
+func (f *FileLoader) loadDir(ctx context.Context, root string, pattern string) ([]*schema.Document, error) {
+	files, err := f.walkDir(root, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := f.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	results := make([][]*schema.Document, len(files))
+	errs := make([]error, len(files))
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return
+			}
+
+			entry := files[i]
+			results[i], errs[i] = f.loadFile(ctx, entry.path, map[string]any{
+				MetaKeyRelPath: entry.rel,
+				MetaKeyModTime: entry.info.ModTime(),
+				MetaKeySize:    entry.info.Size(),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	var docs []*schema.Document
+	for i := range files {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		docs = append(docs, results[i]...)
+	}
+
+	return docs, nil
+}
+
+// walkDir lists files to load under root.
+func (f *FileLoader) walkDir(root string, pattern string) ([]*fileEntry, error) {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("read dir from path, error while resolving path: %w, path= %s", err, root)
+	}
+	realRoot, err = filepath.Abs(realRoot)
+	if err != nil {
+		return nil, fmt.Errorf("read dir from path, error while resolving path: %w, path= %s", err, root)
+	}
+
+	w := &dirWalker{
+		config:   &f.FileLoaderConfig,
+		pattern:  pattern,
+		realRoot: realRoot,
+		visited:  map[string]bool{realRoot: true},
+	}
+	if err = w.walk(root, "", nil); err != nil {
+		return nil, err
+	}
+
+	return w.files, nil
+}
+
+type dirWalker struct {
+	config   *FileLoaderConfig
+	pattern  string
+	realRoot string
+	visited  map[string]bool
+
+	files []*fileEntry
+}
+
+func (w *dirWalker) walk(dir, rel string, rules []ignoreRule) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read dir from path failed with err: %w, path= %s", err, dir)
+	}
+
+	if w.config.RespectGitignore {
+		content, err := os.ReadFile(filepath.Join(dir, gitignoreFileName))
+		if err == nil {
+			// copy to avoid sharing the underlying array between sibling directories
+			rules = append(rules[:len(rules):len(rules)], parseGitignore(rel, content)...)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("read gitignore failed with err: %w, path= %s", err, dir)
+		}
+	}
+
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		r := path.Join(rel, entry.Name())
+
+		info, err := os.Lstat(p)
+		if err != nil {
+			return fmt.Errorf("read dir from path, error while checking file stat: %w, path= %s", err, p)
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			info, err = w.resolveSymlink(p)
+			if err != nil {
+				return err
+			}
+			if info == nil {
+				continue
+			}
+		}
+
+		if info.IsDir() {
+			if !w.shouldEnterDir(p, r, rules) {
+				continue
+			}
+			if err = w.walk(p, r, rules); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !info.Mode().IsRegular() || !w.shouldLoadFile(r, info, rules) {
+			continue
+		}
+
+		w.files = append(w.files, &fileEntry{path: p, rel: r, info: info})
+	}
+
+	return nil
+}
+
+// resolveSymlink returns the stat of link target, or nil if the link should be skipped.
+func (w *dirWalker) resolveSymlink(p string) (os.FileInfo, error) {
+	if w.config.SymlinkPolicy == SymlinkSkip {
+		return nil, nil
+	}
+
+	target, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		// broken link
+		return nil, nil
+	}
+
+	if w.config.SymlinkPolicy == SymlinkFollowWithinRoot {
+		abs, err := filepath.Abs(target)
+		if err != nil {
+			return nil, nil
+		}
+		if abs != w.realRoot && !strings.HasPrefix(abs, w.realRoot+string(filepath.Separator)) {
+			return nil, nil
+		}
+	}
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, fmt.Errorf("read dir from path, error while checking file stat: %w, path= %s", err, p)
+	}
+
+	return info, nil
+}
+
+func (w *dirWalker) shouldEnterDir(p, rel string, rules []ignoreRule) bool {
+	if w.config.RespectGitignore && path.Base(rel) == gitDirName {
+		return false
+	}
+	if matchAny(w.config.Exclude, rel) {
+		return false
+	}
+	if isIgnored(rules, rel, true) {
+		return false
+	}
+	if w.pattern != "" && !matchGlobPrefix(w.pattern, rel) {
+		return false
+	}
+
+	// avoid cycles of symbolic links
+	realPath, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return false
+	}
+	if realPath, err = filepath.Abs(realPath); err != nil {
+		return false
+	}
+	if w.visited[realPath] {
+		return false
+	}
+	w.visited[realPath] = true
+
+	return true
+}
+
+func (w *dirWalker) shouldLoadFile(rel string, info os.FileInfo, rules []ignoreRule) bool {
+	if w.pattern != "" && !matchGlob(w.pattern, rel) {
+		return false
+	}
+	if len(w.config.Include) > 0 && !matchAny(w.config.Include, rel) {
+		return false
+	}
+	if matchAny(w.config.Exclude, rel) {
+		return false
+	}
+	if isIgnored(rules, rel, false) {
+		return false
+	}
+	if w.config.MaxFileSize > 0 && info.Size() > w.config.MaxFileSize {
+		return false
+	}
+
+	return true
+}
+
+// splitGlob splits a glob URI into the directory without glob meta characters and the pattern relative to it.
+func splitGlob(uri string) (root string, pattern string) {
+	segs := strings.Split(filepath.ToSlash(uri), "/")
+	for i, seg := range segs {
+		if hasGlobMeta(seg) {
+			root = strings.Join(segs[:i], "/")
+			if root == "" && i > 0 {
+				root = "/"
+			}
+			if root == "" {
+				root = "."
+			}
+			return filepath.FromSlash(root), strings.Join(segs[i:], "/")
+		}
+	}
+
+	return uri, ""
+}
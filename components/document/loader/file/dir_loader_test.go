@@ -0,0 +1,158 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		assert.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+}
+
+func loadRelPaths(t *testing.T, config *FileLoaderConfig, uri string) []string {
+	ctx := context.Background()
+	loader, err := NewFileLoader(ctx, config)
+	assert.NoError(t, err)
+
+	docs, err := loader.Load(ctx, document.Source{URI: uri})
+	assert.NoError(t, err)
+
+	paths := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		paths = append(paths, doc.MetaData[MetaKeyRelPath].(string))
+	}
+	return paths
+}
+
+func TestFileLoader_LoadDir(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a.md":               "a",
+		"b.txt":              "b",
+		"docs/c.md":          "c",
+		"docs/large.md":      "this file is larger than the limit",
+		"docs/deep/d.md":     "d",
+		"build/out.md":       "out",
+		".git/config":        "config",
+		".gitignore":         "build/\n*.log\n!keep.log\n",
+		"docs/.gitignore":    "/deep\n",
+		"docs/e.log":         "e",
+		"docs/keep.log":      "keep",
+		"vendor/lib/f.md":    "f",
+		"vendor/lib/f.go":    "package f",
+		"docs/deep/g.md.bak": "g",
+	})
+
+	t.Run("all files", func(t *testing.T) {
+		paths := loadRelPaths(t, &FileLoaderConfig{}, root)
+		assert.Equal(t, []string{
+			".git/config", ".gitignore", "a.md", "b.txt", "build/out.md",
+			"docs/.gitignore", "docs/c.md", "docs/deep/d.md", "docs/deep/g.md.bak", "docs/e.log", "docs/keep.log",
+			"docs/large.md", "vendor/lib/f.go", "vendor/lib/f.md",
+		}, paths)
+	})
+
+	t.Run("include exclude and gitignore", func(t *testing.T) {
+		paths := loadRelPaths(t, &FileLoaderConfig{
+			Include:          []string{"*.md", "*.log"},
+			Exclude:          []string{"vendor"},
+			RespectGitignore: true,
+			MaxFileSize:      10,
+			Concurrency:      2,
+		}, root)
+		assert.Equal(t, []string{"a.md", "docs/c.md", "docs/keep.log"}, paths)
+	})
+
+	t.Run("glob uri", func(t *testing.T) {
+		paths := loadRelPaths(t, &FileLoaderConfig{}, filepath.Join(root, "docs", "**", "*.md"))
+		assert.Equal(t, []string{"c.md", "deep/d.md", "large.md"}, paths)
+
+		paths = loadRelPaths(t, &FileLoaderConfig{}, filepath.Join(root, "*", "*.md"))
+		assert.Equal(t, []string{"build/out.md", "docs/c.md", "docs/large.md"}, paths)
+	})
+
+	t.Run("metadata and id", func(t *testing.T) {
+		ctx := context.Background()
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{UseNameAsID: true, Include: []string{"docs/c.md"}})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "docs/c.md", docs[0].ID)
+		assert.Equal(t, "c", docs[0].Content)
+		assert.Equal(t, "c.md", docs[0].MetaData[MetaKeyFileName])
+		assert.Equal(t, ".md", docs[0].MetaData[MetaKeyExtension])
+		assert.Equal(t, filepath.Join(root, "docs", "c.md"), docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, int64(1), docs[0].MetaData[MetaKeySize])
+		assert.IsType(t, time.Time{}, docs[0].MetaData[MetaKeyModTime])
+	})
+}
+
+func TestFileLoader_LoadDirSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink requires privilege on windows")
+	}
+
+	base := t.TempDir()
+	root := filepath.Join(base, "root")
+	writeFiles(t, base, map[string]string{
+		"root/a.md":    "a",
+		"outside/b.md": "b",
+	})
+	assert.NoError(t, os.Symlink(filepath.Join(base, "outside"), filepath.Join(root, "outside")))
+	assert.NoError(t, os.Symlink(filepath.Join(root, "a.md"), filepath.Join(root, "link.md")))
+	// cycle
+	assert.NoError(t, os.Symlink(root, filepath.Join(root, "self")))
+
+	paths := loadRelPaths(t, &FileLoaderConfig{}, root)
+	assert.Equal(t, []string{"a.md"}, paths)
+
+	paths = loadRelPaths(t, &FileLoaderConfig{SymlinkPolicy: SymlinkFollow}, root)
+	assert.Equal(t, []string{"a.md", "link.md", "outside/b.md"}, paths)
+
+	paths = loadRelPaths(t, &FileLoaderConfig{SymlinkPolicy: SymlinkFollowWithinRoot}, root)
+	assert.Equal(t, []string{"a.md", "link.md"}, paths)
+}
+
+func TestSplitGlob(t *testing.T) {
+	root, pattern := splitGlob("docs/**/*.md")
+	assert.Equal(t, "docs", root)
+	assert.Equal(t, "**/*.md", pattern)
+
+	root, pattern = splitGlob("*.md")
+	assert.Equal(t, ".", root)
+	assert.Equal(t, "*.md", pattern)
+
+	root, pattern = splitGlob("/*.md")
+	assert.Equal(t, string(filepath.Separator), root)
+	assert.Equal(t, "*.md", pattern)
+}
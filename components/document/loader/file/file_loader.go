@@ -34,11 +34,47 @@ const (
 	MetaKeyFileName  = "_file_name"
 	MetaKeyExtension = "_extension"
 	MetaKeySource    = "_source"
+
+	// metadata of files loaded from a directory
+	MetaKeyRelPath = "_rel_path" // value: string, slash separated path relative to the directory
+	MetaKeyModTime = "_mod_time" // value: time.Time
+	MetaKeySize    = "_size"     // value: int64, in bytes
+)
+
+// SymlinkPolicy specifies how symbolic links are handled when loading a directory.
+type SymlinkPolicy uint8
+
+const (
+	// SymlinkSkip skips symbolic links.
+	SymlinkSkip SymlinkPolicy = iota
+	// SymlinkFollow follows symbolic links, directories already visited are skipped to avoid cycles.
+	SymlinkFollow
+	// SymlinkFollowWithinRoot follows symbolic links only if the target is inside the loading directory.
+	SymlinkFollowWithinRoot
 )
 
 type FileLoaderConfig struct {
+	// UseNameAsID use file name as document id, or the relative path when loading a directory.
 	UseNameAsID bool
 	Parser      parser.Parser
+
+	// The following configs take effect when the source URI is a directory or a glob pattern like "docs/**/*.md".
+
+	// Include only load files matching any of the glob patterns, all files are loaded if empty.
+	// Patterns without "/" match the file name at any depth, e.g. "*.md",
+	// others match the slash separated path relative to the directory, e.g. "docs/**/*.md".
+	Include []string
+	// Exclude skip files and directories matching any of the glob patterns, in the same syntax as Include.
+	Exclude []string
+	// RespectGitignore skip files ignored by .gitignore files in the directory tree, and the .git directory.
+	RespectGitignore bool
+	// MaxFileSize skip files larger than it in bytes, no limit if zero.
+	MaxFileSize int64
+	// SymlinkPolicy SymlinkSkip by default.
+	SymlinkPolicy SymlinkPolicy
+	// Concurrency max number of files parsed in parallel.
+	// Default is 4.
+	Concurrency int
 }
 
 // FileLoader loads a local file and use its content directly as Document's content.
@@ -78,32 +114,61 @@ func (f *FileLoader) Load(ctx context.Context, src document.Source, opts ...docu
 		Source: src,
 	})
 
-	file, err := openFile(src.URI)
+	if f.Parser == nil {
+		return nil, errors.New("no parser specified")
+	}
+
+	if info, statErr := os.Stat(src.URI); statErr == nil && info.IsDir() {
+		docs, err = f.loadDir(ctx, src.URI, "")
+	} else if statErr != nil && hasGlobMeta(src.URI) {
+		root, pattern := splitGlob(src.URI)
+		docs, err = f.loadDir(ctx, root, pattern)
+	} else {
+		docs, err = f.loadFile(ctx, src.URI, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// loadFile parses a single file, extra is added to the metadata and name of the file is replaced by
+// MetaKeyRelPath in extra if UseNameAsID.
+func (f *FileLoader) loadFile(ctx context.Context, path string, extra map[string]any) ([]*schema.Document, error) {
+	file, err := openFile(path)
 	if err != nil {
 		return nil, err
 	}
 
 	defer file.Close()
 
-	name := filepath.Base(src.URI)
-	ext := filepath.Ext(src.URI)
+	name := filepath.Base(path)
+	ext := filepath.Ext(path)
 
 	meta := map[string]any{
 		MetaKeyExtension: ext,
 		MetaKeyFileName:  name,
-		MetaKeySource:    src.URI,
+		MetaKeySource:    path,
 	}
-
-	if f.Parser == nil {
-		return nil, errors.New("no parser specified")
+	for k, v := range extra {
+		meta[k] = v
 	}
 
-	docs, err = f.Parser.Parse(ctx, file, parser.WithURI(src.URI), parser.WithExtraMeta(meta))
+	docs, err := f.Parser.Parse(ctx, file, parser.WithURI(path), parser.WithExtraMeta(meta))
 	if err != nil {
-		return nil, fmt.Errorf("file parse err of [%s]: %w", src.URI, err)
+		return nil, fmt.Errorf("file parse err of [%s]: %w", path, err)
 	}
 
 	if f.UseNameAsID {
+		if rel, ok := extra[MetaKeyRelPath].(string); ok {
+			name = rel
+		}
 		if len(docs) == 1 {
 			docs[0].ID = name
 		} else {
@@ -113,11 +178,6 @@ func (f *FileLoader) Load(ctx context.Context, src document.Source, opts ...docu
 		}
 	}
 
-	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
-		Source: src,
-		Docs:   docs,
-	})
-
 	return docs, nil
 }
 
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"path"
+	"strings"
+)
+
+// matchGlob reports whether the slash separated name matches pattern.
+// Besides the syntax of path.Match, a "**" segment matches zero or more path segments.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, segs []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(segs); i++ {
+				if matchSegments(rest, segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], segs[0]); err != nil || !ok {
+			return false
+		}
+		pattern, segs = pattern[1:], segs[1:]
+	}
+
+	return len(segs) == 0
+}
+
+// matchGlobPrefix reports whether files under dir may match pattern, which is used to prune directories.
+func matchGlobPrefix(pattern, dir string) bool {
+	if dir == "" {
+		return true
+	}
+
+	pat := strings.Split(pattern, "/")
+	for _, seg := range strings.Split(dir, "/") {
+		if len(pat) == 0 {
+			return false
+		}
+		if pat[0] == "**" {
+			return true
+		}
+		if ok, err := path.Match(pat[0], seg); err != nil || !ok {
+			return false
+		}
+		pat = pat[1:]
+	}
+
+	return len(pat) > 0
+}
+
+// matchFilter matches a path relative to the loading directory with Include / Exclude patterns.
+// Patterns without "/" match the base name at any depth, others match the whole relative path.
+func matchFilter(pattern, rel string) bool {
+	if !strings.Contains(pattern, "/") {
+		return matchGlob(pattern, path.Base(rel))
+	}
+
+	return matchGlob(strings.TrimPrefix(pattern, "/"), rel)
+}
+
+func matchAny(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		if matchFilter(p, rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasGlobMeta(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// ignoreRule is a rule of .gitignore file.
+// see: https://git-scm.com/docs/gitignore#_pattern_format
+type ignoreRule struct {
+	// base is the directory of the .gitignore file, relative to the loading directory.
+	base     string
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+func parseGitignore(base string, content []byte) []ignoreRule {
+	var rules []ignoreRule
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func (r *ignoreRule) match(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+
+	p := rel
+	if r.base != "" {
+		if !strings.HasPrefix(rel, r.base+"/") {
+			return false
+		}
+		p = rel[len(r.base)+1:]
+	}
+
+	if r.anchored {
+		return matchGlob(r.pattern, p)
+	}
+
+	return matchGlob(r.pattern, path.Base(p))
+}
+
+// isIgnored the last matching rule decides whether the path is ignored.
+func isIgnored(rules []ignoreRule, rel string, isDir bool) bool {
+	ignored := false
+	for i := range rules {
+		if rules[i].match(rel, isDir) {
+			ignored = !rules[i].negate
+		}
+	}
+
+	return ignored
+}
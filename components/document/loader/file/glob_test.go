@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.md", "a.md", true},
+		{"*.md", "docs/a.md", false},
+		{"docs/*.md", "docs/a.md", true},
+		{"**/*.md", "a.md", true},
+		{"**/*.md", "docs/deep/a.md", true},
+		{"docs/**", "docs/deep/a.md", true},
+		{"docs/**/a.md", "docs/a.md", true},
+		{"docs/**/a.md", "other/a.md", false},
+		{"[ab].md", "c.md", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, matchGlob(c.pattern, c.name), c.pattern+" "+c.name)
+	}
+}
+
+func TestMatchGlobPrefix(t *testing.T) {
+	assert.True(t, matchGlobPrefix("docs/*.md", ""))
+	assert.True(t, matchGlobPrefix("docs/*.md", "docs"))
+	assert.False(t, matchGlobPrefix("docs/*.md", "other"))
+	assert.False(t, matchGlobPrefix("docs/*.md", "docs/deep"))
+	assert.True(t, matchGlobPrefix("docs/**/*.md", "docs/deep/deeper"))
+}
+
+func TestMatchFilter(t *testing.T) {
+	assert.True(t, matchFilter("*.md", "docs/deep/a.md"))
+	assert.True(t, matchFilter("vendor", "vendor"))
+	assert.True(t, matchFilter("/docs/*.md", "docs/a.md"))
+	assert.False(t, matchFilter("docs/*.md", "x/docs/a.md"))
+}
+
+func TestGitignore(t *testing.T) {
+	rules := parseGitignore("", []byte("# comment\n\n*.log\n!keep.log\nbuild/\n/root.txt\ndocs/**/tmp\n"))
+	rules = append(rules, parseGitignore("sub", []byte("local.txt\n"))...)
+
+	cases := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"a.log", false, true},
+		{"deep/a.log", false, true},
+		{"keep.log", false, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"deep/build", true, true},
+		{"root.txt", false, true},
+		{"deep/root.txt", false, false},
+		{"docs/a/b/tmp", true, true},
+		{"sub/local.txt", false, true},
+		{"local.txt", false, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, isIgnored(rules, c.rel, c.isDir), c.rel)
+	}
+}
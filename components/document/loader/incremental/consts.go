@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+const typ = "IncrementalLoader"
+
+const defaultBatchSize = 100
+
+// Metadata keys recorded by loaders, which are used to identify and fingerprint sources.
+const (
+	// MetaKeySource source of the document, recorded by file, s3 and url loaders and the url crawler.
+	MetaKeySource = "_source"
+	// MetaKeyETag recorded by s3 and url loaders and the url crawler.
+	MetaKeyETag = "_etag"
+	// MetaKeyModTime recorded by file loader when loading a directory.
+	MetaKeyModTime = "_mod_time"
+	// MetaKeyLastModified recorded by s3 and url loaders and the url crawler.
+	MetaKeyLastModified = "_last_modified"
+	// MetaKeySize recorded by file and s3 loaders.
+	MetaKeySize = "_size"
+	// MetaKeyNotModified marks the placeholder of a source not modified since the last load, recorded by
+	// the url crawler with EmitNotModified. Sources with only placeholders are kept unchanged.
+	MetaKeyNotModified = "_not_modified"
+)
+
+const (
+	// MetaKeySourceKey source key of the document resolved by Config.SourceKey, value: string
+	MetaKeySourceKey = "_sync_source_key"
+	// MetaKeyFingerprint fingerprint of the source, value: string
+	MetaKeyFingerprint = "_sync_fingerprint"
+)
+
+func GetType() string {
+	return typ
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/incremental"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with a real loader, e.g. file.NewFileLoader loading a directory
+	var fileLoader document.Loader = &staticLoader{docs: []*schema.Document{
+		{ID: "a.md", Content: "hello", MetaData: map[string]any{incremental.MetaKeySource: "docs/a.md"}},
+		{ID: "b.md", Content: "world", MetaData: map[string]any{incremental.MetaKeySource: "docs/b.md"}},
+	}}
+
+	manifest, err := incremental.NewFileManifest("./sync_manifest.json")
+	if err != nil {
+		log.Fatalf("NewFileManifest failed, err=%v", err)
+	}
+
+	loader, err := incremental.NewLoader(ctx, &incremental.Config{
+		Loader:    fileLoader,
+		Manifest:  manifest,
+		BatchSize: 50,
+	})
+	if err != nil {
+		log.Fatalf("NewLoader failed, err=%v", err)
+	}
+
+	// the manifest is checkpointed after each batch is handled successfully,
+	// run it again to resume after failures
+	err = loader.Sync(ctx, document.Source{URI: "./docs"}, func(ctx context.Context, changes *incremental.ChangeSet) error {
+		// replace with indexer.Store and deleting from the storage
+		log.Printf("upsert %d documents, delete %v", len(changes.Upserts), changes.Deleted)
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Sync failed, err=%v", err)
+	}
+}
+
+type staticLoader struct {
+	docs []*schema.Document
+}
+
+func (s *staticLoader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	return s.docs, nil
+}
@@ -0,0 +1,334 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+var _ document.Loader = (*Loader)(nil)
+
+type Config struct {
+	// Loader loads documents of a source URI, e.g. a directory, s3 prefix or url, required.
+	Loader document.Loader
+	// Manifest persists the state of synced sources, required.
+	Manifest Manifest
+
+	// SourceKey identifies the source of a loaded document, documents of the same source are synced together.
+	// Default is DefaultSourceKey.
+	SourceKey func(doc *schema.Document, src document.Source) string
+	// Fingerprint returns a value which changes when the source changes, docs are all documents of the source.
+	// Default is DefaultFingerprint.
+	Fingerprint func(docs []*schema.Document) string
+
+	// BatchSize number of changed sources passed to the handler of Sync at a time, the manifest is
+	// checkpointed after each batch is handled.
+	// Default is 100.
+	BatchSize int
+}
+
+// ChangeSet changes of sources since last sync.
+type ChangeSet struct {
+	// Upserts documents of new and changed sources.
+	Upserts []*schema.Document
+	// Deleted ids of documents that no longer exist, from deleted sources or previous versions of changed sources.
+	Deleted []string
+	// DeletedSources keys of deleted sources.
+	DeletedSources []string
+}
+
+// Loader loads only new and changed documents by comparing sources with a manifest.
+type Loader struct {
+	config *Config
+}
+
+// NewLoader creates an incremental loader.
+func NewLoader(_ context.Context, config *Config) (*Loader, error) {
+	if config == nil {
+		return nil, errors.New("[NewLoader] config is nil")
+	}
+	if config.Loader == nil {
+		return nil, errors.New("[NewLoader] loader not provided")
+	}
+	if config.Manifest == nil {
+		return nil, errors.New("[NewLoader] manifest not provided")
+	}
+
+	conf := *config
+	if conf.SourceKey == nil {
+		conf.SourceKey = DefaultSourceKey
+	}
+	if conf.Fingerprint == nil {
+		conf.Fingerprint = DefaultFingerprint
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+
+	return &Loader{config: &conf}, nil
+}
+
+// DefaultSourceKey uses MetaKeySource of the document, or the URI of the source if absent.
+func DefaultSourceKey(doc *schema.Document, src document.Source) string {
+	if s, ok := doc.MetaData[MetaKeySource].(string); ok && s != "" {
+		return s
+	}
+
+	return src.URI
+}
+
+// DefaultFingerprint prefers ETag, then modification time and size recorded by loaders,
+// and falls back to sha256 of the contents of all documents.
+func DefaultFingerprint(docs []*schema.Document) string {
+	if len(docs) > 0 {
+		meta := docs[0].MetaData
+		if etag, ok := meta[MetaKeyETag].(string); ok && etag != "" {
+			return "etag:" + etag
+		}
+
+		for _, key := range []string{MetaKeyModTime, MetaKeyLastModified} {
+			if t, ok := meta[key].(time.Time); ok && !t.IsZero() {
+				return fmt.Sprintf("mtime:%s,size:%v", t.UTC().Format(time.RFC3339Nano), meta[MetaKeySize])
+			}
+		}
+	}
+
+	h := sha256.New()
+	for _, doc := range docs {
+		_, _ = fmt.Fprintf(h, "%d:", len(doc.Content))
+		_, _ = h.Write([]byte(doc.Content))
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Load returns documents of new and changed sources, and commits the manifest before returning.
+// Use WithChangeSet to receive deleted document ids, or Sync to commit only after the changes are applied.
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+
+	o := document.GetLoaderImplSpecificOptions(&options{}, opts...)
+
+	all := &ChangeSet{}
+	err = l.Sync(ctx, src, func(_ context.Context, changes *ChangeSet) error {
+		all.Upserts = append(all.Upserts, changes.Upserts...)
+		all.Deleted = append(all.Deleted, changes.Deleted...)
+		all.DeletedSources = append(all.DeletedSources, changes.DeletedSources...)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.changeSet != nil {
+		*o.changeSet = *all
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   all.Upserts,
+	})
+
+	return all.Upserts, nil
+}
+
+type source struct {
+	key         string
+	fingerprint string
+	docs        []*schema.Document
+	// notModified is true if the loader reported the source as not modified without loading it.
+	notModified bool
+}
+
+// Sync loads src and passes changes to handler in batches. The manifest is checkpointed after each
+// successful handler call, so a sync interrupted by errors or restarts can be resumed by calling Sync again,
+// sources committed before are unchanged then. Sources reported as not modified by the loader are neither
+// upserted nor deleted. Deletions are passed to handler in the last call.
+func (l *Loader) Sync(ctx context.Context, src document.Source, handler func(ctx context.Context, changes *ChangeSet) error,
+	opts ...document.LoaderOption) error {
+
+	docs, err := l.config.Loader.Load(ctx, src, opts...)
+	if err != nil {
+		return fmt.Errorf("[IncrementalLoader] load source fail: %w", err)
+	}
+
+	prev, err := l.config.Manifest.Get(ctx, src.URI)
+	if err != nil {
+		return fmt.Errorf("[IncrementalLoader] get manifest fail: %w", err)
+	}
+
+	sources := l.groupBySource(docs, src)
+	seen := make(map[string]bool, len(sources))
+
+	var changed []*source
+	for _, s := range sources {
+		seen[s.key] = true
+		if s.notModified {
+			continue
+		}
+		if e, ok := prev[s.key]; ok && e.Fingerprint == s.fingerprint {
+			continue
+		}
+		changed = append(changed, s)
+	}
+
+	for start := 0; start < len(changed); start += l.config.BatchSize {
+		end := start + l.config.BatchSize
+		if end > len(changed) {
+			end = len(changed)
+		}
+
+		changes := &ChangeSet{}
+		entries := make([]*Entry, 0, end-start)
+		for _, s := range changed[start:end] {
+			ids := make([]string, 0, len(s.docs))
+			for _, doc := range s.docs {
+				ids = append(ids, doc.ID)
+			}
+
+			changes.Upserts = append(changes.Upserts, s.docs...)
+			if e, ok := prev[s.key]; ok {
+				changes.Deleted = append(changes.Deleted, subtract(e.DocIDs, ids)...)
+			}
+			entries = append(entries, &Entry{
+				SourceKey:   s.key,
+				Fingerprint: s.fingerprint,
+				DocIDs:      ids,
+				SyncedAt:    time.Now(),
+			})
+		}
+
+		if err = handler(ctx, changes); err != nil {
+			return fmt.Errorf("[IncrementalLoader] handle changes fail: %w", err)
+		}
+		if err = l.config.Manifest.Put(ctx, src.URI, entries); err != nil {
+			return fmt.Errorf("[IncrementalLoader] checkpoint manifest fail: %w", err)
+		}
+	}
+
+	var deletedKeys []string
+	for key := range prev {
+		if !seen[key] {
+			deletedKeys = append(deletedKeys, key)
+		}
+	}
+	if len(deletedKeys) == 0 {
+		return nil
+	}
+	sort.Strings(deletedKeys)
+
+	changes := &ChangeSet{DeletedSources: deletedKeys}
+	for _, key := range deletedKeys {
+		changes.Deleted = append(changes.Deleted, prev[key].DocIDs...)
+	}
+	if err = handler(ctx, changes); err != nil {
+		return fmt.Errorf("[IncrementalLoader] handle changes fail: %w", err)
+	}
+	if err = l.config.Manifest.Delete(ctx, src.URI, deletedKeys); err != nil {
+		return fmt.Errorf("[IncrementalLoader] checkpoint manifest fail: %w", err)
+	}
+
+	return nil
+}
+
+// groupBySource groups documents by source key in the order of first appearance, documents without id
+// are assigned "<source key>_<index>" so that they can be deleted later. Sources whose documents are all
+// marked with MetaKeyNotModified are flagged as not modified.
+func (l *Loader) groupBySource(docs []*schema.Document, src document.Source) []*source {
+	var sources []*source
+	byKey := make(map[string]*source)
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+
+		key := l.config.SourceKey(doc, src)
+		s, ok := byKey[key]
+		if !ok {
+			s = &source{key: key}
+			byKey[key] = s
+			sources = append(sources, s)
+		}
+		s.docs = append(s.docs, doc)
+	}
+
+	for _, s := range sources {
+		s.notModified = true
+		for _, doc := range s.docs {
+			if nm, _ := doc.MetaData[MetaKeyNotModified].(bool); !nm {
+				s.notModified = false
+				break
+			}
+		}
+
+		s.fingerprint = l.config.Fingerprint(s.docs)
+		for i, doc := range s.docs {
+			if doc.ID == "" {
+				doc.ID = fmt.Sprintf("%s_%d", s.key, i)
+			}
+			if doc.MetaData == nil {
+				doc.MetaData = make(map[string]any)
+			}
+			doc.MetaData[MetaKeySourceKey] = s.key
+			doc.MetaData[MetaKeyFingerprint] = s.fingerprint
+		}
+	}
+
+	return sources
+}
+
+func subtract(a, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, s := range b {
+		set[s] = true
+	}
+
+	var ret []string
+	for _, s := range a {
+		if !set[s] {
+			ret = append(ret, s)
+		}
+	}
+
+	return ret
+}
+
+func (l *Loader) GetType() string {
+	return typ
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
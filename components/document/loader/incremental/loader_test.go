@@ -0,0 +1,309 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+type mockLoader struct {
+	docs func() []*schema.Document
+	err  error
+}
+
+func (m *mockLoader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.docs(), nil
+}
+
+func fileDoc(id, source, content string, modTime time.Time) *schema.Document {
+	return &schema.Document{
+		ID:      id,
+		Content: content,
+		MetaData: map[string]any{
+			MetaKeySource:  source,
+			MetaKeyModTime: modTime,
+			MetaKeySize:    int64(len(content)),
+		},
+	}
+}
+
+func TestNewLoader(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewLoader(ctx, nil)
+	assert.Error(t, err)
+	_, err = NewLoader(ctx, &Config{Manifest: NewMemoryManifest()})
+	assert.Error(t, err)
+	_, err = NewLoader(ctx, &Config{Loader: &mockLoader{}})
+	assert.Error(t, err)
+
+	l, err := NewLoader(ctx, &Config{Loader: &mockLoader{}, Manifest: NewMemoryManifest()})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultBatchSize, l.config.BatchSize)
+	assert.Equal(t, typ, l.GetType())
+}
+
+func TestLoader_Load(t *testing.T) {
+	ctx := context.Background()
+	t1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	var current []*schema.Document
+	inner := &mockLoader{docs: func() []*schema.Document {
+		// return fresh documents every time, as real loaders do
+		ret := make([]*schema.Document, 0, len(current))
+		for _, doc := range current {
+			ret = append(ret, &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: deepCopyMap(doc.MetaData)})
+		}
+		return ret
+	}}
+
+	l, err := NewLoader(ctx, &Config{Loader: inner, Manifest: NewMemoryManifest()})
+	assert.NoError(t, err)
+
+	src := document.Source{URI: "./docs"}
+
+	// first sync, everything is new
+	current = []*schema.Document{
+		fileDoc("a", "docs/a.md", "a", t1),
+		fileDoc("b_0", "docs/b.md", "b0", t1),
+		fileDoc("b_1", "docs/b.md", "b1", t1),
+		fileDoc("c", "docs/c.md", "c", t1),
+	}
+	changes := &ChangeSet{}
+	docs, err := l.Load(ctx, src, WithChangeSet(changes))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 4)
+	assert.Empty(t, changes.Deleted)
+	assert.Equal(t, "docs/b.md", docs[1].MetaData[MetaKeySourceKey])
+	assert.NotEmpty(t, docs[1].MetaData[MetaKeyFingerprint])
+
+	// nothing changed
+	docs, err = l.Load(ctx, src, WithChangeSet(changes))
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+	assert.Empty(t, changes.Deleted)
+
+	// b changed and has less documents, c deleted, d added
+	current = []*schema.Document{
+		fileDoc("a", "docs/a.md", "a", t1),
+		fileDoc("b_0", "docs/b.md", "b0 changed", t2),
+		fileDoc("d", "docs/d.md", "d", t2),
+	}
+	docs, err = l.Load(ctx, src, WithChangeSet(changes))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, "b_0", docs[0].ID)
+	assert.Equal(t, "d", docs[1].ID)
+	assert.Equal(t, []string{"b_1", "c"}, changes.Deleted)
+	assert.Equal(t, []string{"docs/c.md"}, changes.DeletedSources)
+
+	// another scope is independent
+	docs, err = l.Load(ctx, document.Source{URI: "./other"})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+}
+
+func TestLoader_SyncResume(t *testing.T) {
+	ctx := context.Background()
+
+	inner := &mockLoader{docs: func() []*schema.Document {
+		return []*schema.Document{
+			{Content: "a", MetaData: map[string]any{MetaKeySource: "a"}},
+			{Content: "b", MetaData: map[string]any{MetaKeySource: "b"}},
+			{Content: "c", MetaData: map[string]any{MetaKeySource: "c"}},
+		}
+	}}
+
+	l, err := NewLoader(ctx, &Config{Loader: inner, Manifest: NewMemoryManifest(), BatchSize: 2})
+	assert.NoError(t, err)
+
+	src := document.Source{URI: "src"}
+
+	// handler fails at the second batch
+	var handled []string
+	err = l.Sync(ctx, src, func(ctx context.Context, changes *ChangeSet) error {
+		if len(handled) > 0 {
+			return errors.New("mock err")
+		}
+		for _, doc := range changes.Upserts {
+			handled = append(handled, doc.ID)
+		}
+		return nil
+	})
+	assert.ErrorContains(t, err, "mock err")
+	assert.Equal(t, []string{"a_0", "b_0"}, handled)
+
+	// resume from the checkpoint
+	handled = nil
+	err = l.Sync(ctx, src, func(ctx context.Context, changes *ChangeSet) error {
+		for _, doc := range changes.Upserts {
+			handled = append(handled, doc.ID)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c_0"}, handled)
+
+	inner.err = errors.New("load err")
+	err = l.Sync(ctx, src, func(ctx context.Context, changes *ChangeSet) error { return nil })
+	assert.ErrorContains(t, err, "load err")
+}
+
+// siteCrawler crawls a site in the way of the url crawler with a StateStore and EmitNotModified:
+// every page is a document with its url as source, and pages whose ETag is unchanged since the last crawl
+// are placeholders marked with MetaKeyNotModified.
+type siteCrawler struct {
+	mu    sync.Mutex
+	pages map[string]string // url -> content
+	etags map[string]string // url -> etag, pages without etag are always downloaded
+	seen  map[string]string // url -> etag of the last crawl
+	hits  map[string]int    // url -> number of downloads
+}
+
+func (c *siteCrawler) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	urls := make([]string, 0, len(c.pages))
+	for u := range c.pages {
+		urls = append(urls, u)
+	}
+	sort.Strings(urls)
+
+	docs := make([]*schema.Document, 0, len(urls))
+	for _, u := range urls {
+		etag, hasETag := c.etags[u]
+		meta := map[string]any{MetaKeySource: u}
+		if hasETag {
+			meta[MetaKeyETag] = etag
+		}
+		if hasETag && c.seen[u] == etag {
+			meta[MetaKeyNotModified] = true
+			docs = append(docs, &schema.Document{MetaData: meta})
+			continue
+		}
+		c.seen[u] = etag
+		c.hits[u]++
+		docs = append(docs, &schema.Document{Content: c.pages[u], MetaData: meta})
+	}
+	return docs, nil
+}
+
+func (c *siteCrawler) update(f func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f()
+}
+
+func TestLoader_Crawler(t *testing.T) {
+	ctx := context.Background()
+
+	const site = "http://example.com"
+	crawler := &siteCrawler{
+		pages: map[string]string{site + "/": "/a /b", site + "/a": "a", site + "/b": "b"},
+		etags: map[string]string{site + "/a": `"a1"`, site + "/b": `"b1"`},
+		seen:  make(map[string]string),
+		hits:  make(map[string]int),
+	}
+	l, err := NewLoader(ctx, &Config{Loader: crawler, Manifest: NewMemoryManifest()})
+	assert.NoError(t, err)
+
+	src := document.Source{URI: site + "/"}
+	run := func() *ChangeSet {
+		all := &ChangeSet{}
+		err := l.Sync(ctx, src, func(_ context.Context, changes *ChangeSet) error {
+			all.Upserts = append(all.Upserts, changes.Upserts...)
+			all.Deleted = append(all.Deleted, changes.Deleted...)
+			all.DeletedSources = append(all.DeletedSources, changes.DeletedSources...)
+			return nil
+		})
+		assert.NoError(t, err)
+		return all
+	}
+
+	// every page is a source of its own
+	changes := run()
+	assert.Len(t, changes.Upserts, 3)
+	assert.Equal(t, site+"/a", changes.Upserts[1].MetaData[MetaKeySourceKey])
+	assert.Equal(t, `etag:"a1"`, changes.Upserts[1].MetaData[MetaKeyFingerprint])
+
+	// only b is downloaded and upserted, a is not modified and kept
+	crawler.update(func() {
+		crawler.pages[site+"/b"], crawler.etags[site+"/b"] = "b changed", `"b2"`
+	})
+	changes = run()
+	assert.Len(t, changes.Upserts, 1)
+	assert.Equal(t, "b changed", changes.Upserts[0].Content)
+	assert.Empty(t, changes.Deleted)
+	crawler.update(func() {
+		assert.Equal(t, 1, crawler.hits[site+"/a"])
+	})
+
+	// nothing changed
+	changes = run()
+	assert.Empty(t, changes.Upserts)
+	assert.Empty(t, changes.Deleted)
+
+	// b is removed from the site, only the index linking to it changes
+	crawler.update(func() {
+		delete(crawler.pages, site+"/b")
+		crawler.pages[site+"/"] = "/a"
+	})
+	changes = run()
+	assert.Len(t, changes.Upserts, 1)
+	assert.Equal(t, site+"/", changes.Upserts[0].MetaData[MetaKeySourceKey])
+	assert.Equal(t, []string{site + "/b_0"}, changes.Deleted)
+	assert.Equal(t, []string{site + "/b"}, changes.DeletedSources)
+}
+
+func TestDefaultFingerprint(t *testing.T) {
+	t1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "etag:abc", DefaultFingerprint([]*schema.Document{{MetaData: map[string]any{MetaKeyETag: "abc", MetaKeyModTime: t1}}}))
+	assert.Equal(t, "mtime:2025-01-01T00:00:00Z,size:3",
+		DefaultFingerprint([]*schema.Document{{MetaData: map[string]any{MetaKeyLastModified: t1, MetaKeySize: int64(3)}}}))
+
+	h1 := DefaultFingerprint([]*schema.Document{{Content: "ab"}, {Content: "c"}})
+	h2 := DefaultFingerprint([]*schema.Document{{Content: "a"}, {Content: "bc"}})
+	assert.NotEqual(t, h1, h2)
+	assert.Equal(t, h1, DefaultFingerprint([]*schema.Document{{Content: "ab"}, {Content: "c"}}))
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry records the state of a source when it was last synced.
+type Entry struct {
+	// SourceKey identifies the source, e.g. file path, s3 uri or url.
+	SourceKey string `json:"source_key"`
+	// Fingerprint changes when the source changes, e.g. ETag, mtime and size, or content hash.
+	Fingerprint string `json:"fingerprint"`
+	// DocIDs ids of documents loaded from the source.
+	DocIDs []string `json:"doc_ids"`
+	// SyncedAt time when the entry is committed.
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// Manifest persists entries of sources, grouped by scope, which is the URI passed to Loader.
+// Sources which were loaded in the scope before but are absent now are considered deleted.
+type Manifest interface {
+	// Get returns entries of the scope by source key.
+	Get(ctx context.Context, scope string) (map[string]*Entry, error)
+	// Put upserts entries of the scope.
+	Put(ctx context.Context, scope string, entries []*Entry) error
+	// Delete removes entries of the scope by source keys.
+	Delete(ctx context.Context, scope string, sourceKeys []string) error
+}
+
+// MemoryManifest a Manifest in memory, which is lost after restart.
+type MemoryManifest struct {
+	mu     sync.RWMutex
+	scopes map[string]map[string]*Entry
+}
+
+func NewMemoryManifest() *MemoryManifest {
+	return &MemoryManifest{scopes: make(map[string]map[string]*Entry)}
+}
+
+func (m *MemoryManifest) Get(_ context.Context, scope string) (map[string]*Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ret := make(map[string]*Entry, len(m.scopes[scope]))
+	for k, e := range m.scopes[scope] {
+		cp := *e
+		ret[k] = &cp
+	}
+
+	return ret, nil
+}
+
+func (m *MemoryManifest) Put(_ context.Context, scope string, entries []*Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.put(scope, entries)
+	return nil
+}
+
+func (m *MemoryManifest) put(scope string, entries []*Entry) {
+	s, ok := m.scopes[scope]
+	if !ok {
+		s = make(map[string]*Entry, len(entries))
+		m.scopes[scope] = s
+	}
+	for _, e := range entries {
+		cp := *e
+		s[e.SourceKey] = &cp
+	}
+}
+
+func (m *MemoryManifest) Delete(_ context.Context, scope string, sourceKeys []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.delete(scope, sourceKeys)
+	return nil
+}
+
+func (m *MemoryManifest) delete(scope string, sourceKeys []string) {
+	for _, k := range sourceKeys {
+		delete(m.scopes[scope], k)
+	}
+	if len(m.scopes[scope]) == 0 {
+		delete(m.scopes, scope)
+	}
+}
+
+// FileManifest a Manifest persisted as a json file, which is rewritten atomically on every change.
+type FileManifest struct {
+	path string
+	mem  *MemoryManifest
+}
+
+// NewFileManifest loads the manifest from path, an empty manifest is created if the file does not exist.
+func NewFileManifest(path string) (*FileManifest, error) {
+	m := &FileManifest{path: path, mem: NewMemoryManifest()}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("[NewFileManifest] read manifest failed: %w", err)
+	}
+
+	if err = json.Unmarshal(b, &m.mem.scopes); err != nil {
+		return nil, fmt.Errorf("[NewFileManifest] unmarshal manifest failed: %w", err)
+	}
+	if m.mem.scopes == nil {
+		m.mem.scopes = make(map[string]map[string]*Entry)
+	}
+
+	return m, nil
+}
+
+func (f *FileManifest) Get(ctx context.Context, scope string) (map[string]*Entry, error) {
+	return f.mem.Get(ctx, scope)
+}
+
+func (f *FileManifest) Put(_ context.Context, scope string, entries []*Entry) error {
+	f.mem.mu.Lock()
+	defer f.mem.mu.Unlock()
+
+	f.mem.put(scope, entries)
+	return f.save()
+}
+
+func (f *FileManifest) Delete(_ context.Context, scope string, sourceKeys []string) error {
+	f.mem.mu.Lock()
+	defer f.mem.mu.Unlock()
+
+	f.mem.delete(scope, sourceKeys)
+	return f.save()
+}
+
+// save writes to a temp file and renames it, so the manifest is never half written.
+func (f *FileManifest) save() error {
+	b, err := json.Marshal(f.mem.scopes)
+	if err != nil {
+		return fmt.Errorf("[FileManifest] marshal manifest failed: %w", err)
+	}
+
+	dir := filepath.Dir(f.path)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("[FileManifest] create dir failed: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(f.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("[FileManifest] create temp file failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("[FileManifest] write temp file failed: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("[FileManifest] close temp file failed: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("[FileManifest] rename temp file failed: %w", err)
+	}
+
+	return nil
+}
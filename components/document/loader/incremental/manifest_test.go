@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package incremental
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileManifest(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state", "manifest.json")
+
+	m, err := NewFileManifest(path)
+	assert.NoError(t, err)
+
+	entries, err := m.Get(ctx, "scope")
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, m.Put(ctx, "scope", []*Entry{
+		{SourceKey: "a", Fingerprint: "fa", DocIDs: []string{"a_0"}},
+		{SourceKey: "b", Fingerprint: "fb", DocIDs: []string{"b_0", "b_1"}},
+	}))
+	assert.NoError(t, m.Delete(ctx, "scope", []string{"a"}))
+
+	// reload from file
+	m, err = NewFileManifest(path)
+	assert.NoError(t, err)
+
+	entries, err = m.Get(ctx, "scope")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "fb", entries["b"].Fingerprint)
+	assert.Equal(t, []string{"b_0", "b_1"}, entries["b"].DocIDs)
+
+	// modifying returned entries does not affect the manifest
+	entries["b"].Fingerprint = "changed"
+	entries, _ = m.Get(ctx, "scope")
+	assert.Equal(t, "fb", entries["b"].Fingerprint)
+
+	// no temp files left
+	files, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0o644))
+	_, err = NewFileManifest(path)
+	assert.Error(t, err)
+}
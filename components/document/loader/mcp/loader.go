@@ -0,0 +1,267 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	MetaKeySource   = "_source"
+	MetaKeyMIMEType = "_mime_type"
+)
+
+// methodResourceUpdated is the notification sent by MCP server when a subscribed resource changes.
+const methodResourceUpdated = "notifications/resources/updated"
+
+type LoaderConfig struct {
+	// Cli is the MCP (Model Control Protocol) client, ref: https://github.com/mark3labs/mcp-go
+	// Notice: should Initialize with server before use
+	// Required
+	Cli client.MCPClient
+	// Parser parses the text and blob contents of resources, the resource URI is passed by parser.WithURI.
+	// Default: parser.ExtParser by the extension of URI, falling back to parser.TextParser.
+	Parser parser.Parser
+}
+
+// Loader reads MCP resources by resources/read, the source URI is the resource URI.
+// Each content of the resource is parsed into documents, whose ID is the content URI if not set by Parser.
+type Loader struct {
+	config *LoaderConfig
+}
+
+// NewLoader creates a new MCP resource Loader.
+func NewLoader(ctx context.Context, config *LoaderConfig) (*Loader, error) {
+	if config == nil || config.Cli == nil {
+		return nil, errors.New("[NewLoader] mcp client not provided")
+	}
+
+	conf := *config
+	if conf.Parser == nil {
+		p, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{
+			FallbackParser: parser.TextParser{},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[NewLoader] new parser fail: %w", err)
+		}
+		conf.Parser = p
+	}
+
+	return &Loader{config: &conf}, nil
+}
+
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+
+	docs, err = l.read(ctx, src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+func (l *Loader) read(ctx context.Context, uri string) ([]*schema.Document, error) {
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = uri
+	result, err := l.config.Cli.ReadResource(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("read mcp resource fail: %w, uri: %s", err, uri)
+	}
+
+	var docs []*schema.Document
+	for _, content := range result.Contents {
+		var (
+			reader     io.Reader
+			contentURI string
+			mimeType   string
+		)
+		switch c := content.(type) {
+		case mcp.TextResourceContents:
+			reader, contentURI, mimeType = strings.NewReader(c.Text), c.URI, c.MIMEType
+		case mcp.BlobResourceContents:
+			data, err := base64.StdEncoding.DecodeString(c.Blob)
+			if err != nil {
+				return nil, fmt.Errorf("decode mcp resource blob fail: %w, uri: %s", err, c.URI)
+			}
+			reader, contentURI, mimeType = bytes.NewReader(data), c.URI, c.MIMEType
+		default:
+			return nil, fmt.Errorf("unknown mcp resource content type: %T, uri: %s", content, uri)
+		}
+		if contentURI == "" {
+			contentURI = uri
+		}
+
+		meta := map[string]any{
+			MetaKeySource: contentURI,
+		}
+		if mimeType != "" {
+			meta[MetaKeyMIMEType] = mimeType
+		}
+		parsed, err := l.config.Parser.Parse(ctx, reader, parser.WithURI(contentURI), parser.WithExtraMeta(meta))
+		if err != nil {
+			return nil, fmt.Errorf("parse mcp resource fail: %w, uri: %s", err, contentURI)
+		}
+		for idx, doc := range parsed {
+			if doc.ID != "" {
+				continue
+			}
+			if len(parsed) == 1 {
+				doc.ID = contentURI
+			} else {
+				doc.ID = fmt.Sprintf("%s_%d", contentURI, idx)
+			}
+		}
+		docs = append(docs, parsed...)
+	}
+
+	return docs, nil
+}
+
+// Watch subscribes to the resources of uris, and re-loads a resource every time the server sends
+// notifications/resources/updated of it, calling onUpdate with the documents or the error of loading.
+// Notifications of the same resource arriving while loading are coalesced. onUpdate is called sequentially.
+// A panic in loading or in onUpdate is reported to onUpdate as an error, and watching goes on.
+//
+// Watching stops and the resources are unsubscribed when ctx is done. Since a notification handler can't be removed
+// from client.MCPClient, call Watch once per client.
+func (l *Loader) Watch(ctx context.Context, uris []string, onUpdate func(uri string, docs []*schema.Document, err error)) error {
+	if onUpdate == nil {
+		return errors.New("on update callback not provided")
+	}
+
+	watched := make(map[string]bool, len(uris))
+	for _, uri := range uris {
+		watched[uri] = true
+	}
+
+	var (
+		mu      sync.Mutex
+		pending = make(map[string]bool)
+		updated = make(chan struct{}, 1)
+	)
+	l.config.Cli.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != methodResourceUpdated {
+			return
+		}
+		uri, _ := notification.Params.AdditionalFields["uri"].(string)
+		if !watched[uri] {
+			return
+		}
+		mu.Lock()
+		pending[uri] = true
+		mu.Unlock()
+		select {
+		case updated <- struct{}{}:
+		default:
+		}
+	})
+
+	for i, uri := range uris {
+		req := mcp.SubscribeRequest{}
+		req.Params.URI = uri
+		if err := l.config.Cli.Subscribe(ctx, req); err != nil {
+			l.unsubscribe(uris[:i])
+			return fmt.Errorf("subscribe mcp resource fail: %w, uri: %s", err, uri)
+		}
+	}
+
+	go func() {
+		defer l.unsubscribe(uris)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-updated:
+				mu.Lock()
+				batch := make([]string, 0, len(pending))
+				for uri := range pending {
+					batch = append(batch, uri)
+				}
+				pending = make(map[string]bool)
+				mu.Unlock()
+
+				sort.Strings(batch)
+				for _, uri := range batch {
+					l.reload(ctx, uri, onUpdate)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reload re-loads the resource of uri and calls onUpdate, recovering a panic so that the watching loop goes on.
+func (l *Loader) reload(ctx context.Context, uri string, onUpdate func(uri string, docs []*schema.Document, err error)) {
+	defer func() {
+		if e := recover(); e != nil {
+			// onUpdate may panic again when reporting the panic.
+			defer func() { _ = recover() }()
+			onUpdate(uri, nil, fmt.Errorf("panic in watching mcp resource: %v", e))
+		}
+	}()
+
+	docs, err := l.read(ctx, uri)
+	onUpdate(uri, docs, err)
+}
+
+func (l *Loader) unsubscribe(uris []string) {
+	ctx := context.Background()
+	for _, uri := range uris {
+		req := mcp.UnsubscribeRequest{}
+		req.Params.URI = uri
+		_ = l.config.Cli.Unsubscribe(ctx, req)
+	}
+}
+
+func (l *Loader) GetType() string {
+	return "MCPLoader"
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader(t *testing.T) {
+	ctx := context.Background()
+	cli := &mockMCPClient{version: "v1"}
+
+	_, err := NewLoader(ctx, nil)
+	assert.Error(t, err)
+
+	l, err := NewLoader(ctx, &LoaderConfig{Cli: cli})
+	assert.NoError(t, err)
+
+	docs, err := l.Load(ctx, document.Source{URI: "file:///notes.txt"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*schema.Document{{
+		ID:       "file:///notes.txt",
+		Content:  "notes v1",
+		MetaData: map[string]any{MetaKeySource: "file:///notes.txt", MetaKeyMIMEType: "text/plain"},
+	}}, docs)
+
+	docs, err = l.Load(ctx, document.Source{URI: "file:///dir"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(docs))
+	assert.Equal(t, "file:///dir/a.txt", docs[0].ID)
+	assert.Equal(t, "a", docs[0].Content)
+	assert.Equal(t, "file:///dir/b.bin", docs[1].ID)
+	assert.Equal(t, "blob", docs[1].Content)
+	assert.Equal(t, "application/octet-stream", docs[1].MetaData[MetaKeyMIMEType])
+
+	_, err = l.Load(ctx, document.Source{URI: "file:///missing"})
+	assert.Error(t, err)
+}
+
+func TestLoaderWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cli := &mockMCPClient{version: "v1"}
+	l, err := NewLoader(ctx, &LoaderConfig{Cli: cli})
+	assert.NoError(t, err)
+
+	assert.Error(t, l.Watch(ctx, []string{"file:///notes.txt"}, nil))
+
+	updates := make(chan []*schema.Document, 1)
+	err = l.Watch(ctx, []string{"file:///notes.txt"}, func(uri string, docs []*schema.Document, err error) {
+		assert.NoError(t, err)
+		assert.Equal(t, "file:///notes.txt", uri)
+		updates <- docs
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"file:///notes.txt"}, cli.subscribed())
+
+	cli.mu.Lock()
+	cli.version = "v2"
+	cli.mu.Unlock()
+	cli.notify("file:///other")
+	cli.notify("file:///notes.txt")
+
+	select {
+	case docs := <-updates:
+		assert.Equal(t, "notes v2", docs[0].Content)
+	case <-time.After(time.Second):
+		t.Fatal("resource is not reloaded")
+	}
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		return len(cli.subscribed()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLoaderWatchRecover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cli := &mockMCPClient{version: "v1"}
+	l, err := NewLoader(ctx, &LoaderConfig{Cli: cli})
+	assert.NoError(t, err)
+
+	errs := make(chan error, 3)
+	calls := 0
+	err = l.Watch(ctx, []string{"file:///notes.txt"}, func(uri string, docs []*schema.Document, err error) {
+		calls++
+		errs <- err
+		if calls <= 2 {
+			// the first panic is reported to onUpdate, which panics again
+			panic("on update")
+		}
+	})
+	assert.NoError(t, err)
+
+	cli.notify("file:///notes.txt")
+	for _, want := range []string{"", "panic in watching mcp resource: on update"} {
+		select {
+		case err = <-errs:
+			if want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("resource is not reloaded")
+		}
+	}
+
+	// watching goes on after the panics
+	cli.notify("file:///notes.txt")
+	select {
+	case err = <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("resource is not reloaded after the panic")
+	}
+	assert.Equal(t, []string{"file:///notes.txt"}, cli.subscribed())
+}
+
+type mockMCPClient struct {
+	mu      sync.Mutex
+	version string
+	subs    map[string]bool
+	handler func(notification mcp.JSONRPCNotification)
+}
+
+func (m *mockMCPClient) notify(uri string) {
+	notification := mcp.JSONRPCNotification{}
+	notification.Method = methodResourceUpdated
+	notification.Params.AdditionalFields = map[string]interface{}{"uri": uri}
+	m.handler(notification)
+}
+
+func (m *mockMCPClient) subscribed() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var ret []string
+	for uri := range m.subs {
+		ret = append(ret, uri)
+	}
+	return ret
+}
+
+func (m *mockMCPClient) Initialize(ctx context.Context, request mcp.InitializeRequest) (*mcp.InitializeResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) Ping(ctx context.Context) error {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) ListResources(ctx context.Context, request mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) ListResourceTemplates(ctx context.Context, request mcp.ListResourceTemplatesRequest) (*mcp.ListResourceTemplatesResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) ReadResource(ctx context.Context, request mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch request.Params.URI {
+	case "file:///notes.txt":
+		return &mcp.ReadResourceResult{Contents: []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: "file:///notes.txt", MIMEType: "text/plain", Text: "notes " + m.version},
+		}}, nil
+	case "file:///dir":
+		return &mcp.ReadResourceResult{Contents: []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: "file:///dir/a.txt", Text: "a"},
+			mcp.BlobResourceContents{URI: "file:///dir/b.bin", MIMEType: "application/octet-stream", Blob: base64.StdEncoding.EncodeToString([]byte("blob"))},
+		}}, nil
+	default:
+		return nil, errors.New("resource not found")
+	}
+}
+
+func (m *mockMCPClient) Subscribe(ctx context.Context, request mcp.SubscribeRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.subs == nil {
+		m.subs = make(map[string]bool)
+	}
+	m.subs[request.Params.URI] = true
+	return nil
+}
+
+func (m *mockMCPClient) Unsubscribe(ctx context.Context, request mcp.UnsubscribeRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.subs, request.Params.URI)
+	return nil
+}
+
+func (m *mockMCPClient) ListPrompts(ctx context.Context, request mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) GetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) ListTools(ctx context.Context, request mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) CallTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) SetLevel(ctx context.Context, request mcp.SetLevelRequest) error {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) Complete(ctx context.Context, request mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) Close() error {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) OnNotification(handler func(notification mcp.JSONRPCNotification)) {
+	m.handler = handler
+}
@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"mime"
 	"strings"
+	"sync"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/document"
@@ -34,23 +36,54 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
+const (
+	MetaKeySource       = "_source"        // value: string, s3://bucket/key
+	MetaKeyBucket       = "_bucket"        // value: string
+	MetaKeyObjectKey    = "_object_key"    // value: string
+	MetaKeyETag         = "_etag"          // value: string, without quotes
+	MetaKeyLastModified = "_last_modified" // value: time.Time
+	MetaKeyContentType  = "_content_type"  // value: string
+	MetaKeySize         = "_size"          // value: int64, in bytes
+	MetaKeyUserMetadata = "_user_metadata" // value: map[string]string, x-amz-meta-* headers with lower case keys
+)
+
+const defaultConcurrency = 8
+
 // LoaderConfig is the configuration for s3 loader.
 type LoaderConfig struct {
 	Region       *string // the region of the AWS bucket
 	AWSAccessKey *string
 	AWSSecretKey *string
 
+	// Endpoint custom endpoint for S3 compatible storages, e.g. http://localhost:9000 for MinIO,
+	// https://<account_id>.r2.cloudflarestorage.com for Cloudflare R2, https://oss-cn-hangzhou.aliyuncs.com for Aliyun OSS.
+	Endpoint *string
+	// UsePathStyle use path-style addressing (endpoint/bucket/key) instead of virtual-hosted-style, which is required by MinIO.
+	UsePathStyle bool
+
 	UseObjectKeyAsID bool // whether to use object key as document ID
 
 	Parser parser.Parser // the parser to parse the s3 object stream into documents, default to parser.TextParser, which directly converts []byte to string
+	// ContentTypeParsers selects parser by the content type of object, e.g. "text/html" or "text/*".
+	// Parser is used if no parser matches.
+	ContentTypeParsers map[string]parser.Parser
+
+	// Concurrency max number of objects fetched in parallel when loading with prefix (uri ends with "/").
+	// Default is 8.
+	Concurrency int
+	// MaxKeys page size of listing objects, decided by the server if zero (1000 for AWS S3).
+	MaxKeys int32
 }
 
 type loader struct {
 	client *s3.Client
 
-	parser parser.Parser
+	parser             parser.Parser
+	contentTypeParsers map[string]parser.Parser
 
 	useObjectKeyAsID bool
+	concurrency      int
+	maxKeys          int32
 }
 
 // NewS3Loader creates a new s3 loader.
@@ -87,17 +120,35 @@ func NewS3Loader(ctx context.Context, conf *LoaderConfig) (document.Loader, erro
 		return nil, fmt.Errorf("new s3 loader, load config err: %w", err)
 	}
 
-	client := s3.NewFromConfig(sdkConfig)
+	client := s3.NewFromConfig(sdkConfig, func(o *s3.Options) {
+		if conf.Endpoint != nil {
+			o.BaseEndpoint = conf.Endpoint
+		}
+		o.UsePathStyle = conf.UsePathStyle
+	})
 
 	p := conf.Parser
 	if p == nil {
 		p = &parser.TextParser{}
 	}
 
+	contentTypeParsers := make(map[string]parser.Parser, len(conf.ContentTypeParsers))
+	for contentType, ctp := range conf.ContentTypeParsers {
+		contentTypeParsers[strings.ToLower(contentType)] = ctp
+	}
+
+	concurrency := conf.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
 	return &loader{
-		client:           client,
-		parser:           p,
-		useObjectKeyAsID: conf.UseObjectKeyAsID,
+		client:             client,
+		parser:             p,
+		contentTypeParsers: contentTypeParsers,
+		useObjectKeyAsID:   conf.UseObjectKeyAsID,
+		concurrency:        concurrency,
+		maxKeys:            conf.MaxKeys,
 	}, nil
 }
 
@@ -120,13 +171,25 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 		return nil, err
 	}
 
-	// prefix is not supported now
 	if isPrefix {
-		err = errors.New("s3 loader does not support batch load with prefix for now")
+		docs, err = l.loadPrefix(ctx, bucket, key)
+	} else {
+		docs, err = l.loadObject(ctx, bucket, key)
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	// get object from s3
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// loadObject gets a single object and parses it as stream.
+func (l *loader) loadObject(ctx context.Context, bucket, key string) ([]*schema.Document, error) {
 	resp, err := l.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
@@ -134,19 +197,39 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 	if err != nil {
 		var noKey *types.NoSuchKey
 		if errors.As(err, &noKey) {
-			err = fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
-			return nil, err
+			return nil, fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
 		}
 
-		err = fmt.Errorf("s3 loader get object err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader get object err: %w", err)
 	}
 	defer resp.Body.Close()
 
-	docs, err = l.parser.Parse(ctx, resp.Body, parser.WithURI(src.URI))
+	uri := fmt.Sprintf("s3://%s/%s", bucket, key)
+	meta := map[string]any{
+		MetaKeySource:    uri,
+		MetaKeyBucket:    bucket,
+		MetaKeyObjectKey: key,
+	}
+	if resp.ETag != nil {
+		meta[MetaKeyETag] = strings.Trim(*resp.ETag, `"`)
+	}
+	if resp.LastModified != nil {
+		meta[MetaKeyLastModified] = *resp.LastModified
+	}
+	if resp.ContentType != nil {
+		meta[MetaKeyContentType] = *resp.ContentType
+	}
+	if resp.ContentLength != nil {
+		meta[MetaKeySize] = *resp.ContentLength
+	}
+	if len(resp.Metadata) > 0 {
+		meta[MetaKeyUserMetadata] = resp.Metadata
+	}
+
+	p := l.selectParser(aws.ToString(resp.ContentType))
+	docs, err := p.Parse(ctx, resp.Body, parser.WithURI(uri), parser.WithExtraMeta(meta))
 	if err != nil {
-		err = fmt.Errorf("s3 loader parse err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader parse err of [%s]: %w", uri, err)
 	}
 
 	if l.useObjectKeyAsID {
@@ -155,14 +238,121 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 		}
 	}
 
-	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
-		Source: src,
-		Docs:   docs,
-	})
+	return docs, nil
+}
+
+// loadPrefix lists all objects under prefix page by page, objects are fetched concurrently while listing.
+// Documents are returned in the listing order, the first error aborts loading.
+func (l *loader) loadPrefix(ctx context.Context, bucket, prefix string) ([]*schema.Document, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+	if l.maxKeys > 0 {
+		input.MaxKeys = aws.Int32(l.maxKeys)
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		results  []*[]*schema.Document
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, l.concurrency)
+	)
+
+	setErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(l.client, input)
+list:
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			setErr(fmt.Errorf("s3 loader list objects err, bucket= %s, prefix= %s: %w", bucket, prefix, err))
+			break
+		}
+
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if key == "" || strings.HasSuffix(key, "/") { // folder placeholder
+				continue
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				setErr(ctx.Err())
+				break list
+			}
+
+			slot := new([]*schema.Document)
+			results = append(results, slot)
+
+			wg.Add(1)
+			go func(key string) {
+				defer func() {
+					if e := recover(); e != nil {
+						setErr(fmt.Errorf("s3 loader panic while loading key= %s: %v", key, e))
+					}
+					<-sem
+					wg.Done()
+				}()
+
+				docs, err := l.loadObject(ctx, bucket, key)
+				if err != nil {
+					setErr(err)
+					return
+				}
+				*slot = docs
+			}(key)
+		}
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var docs []*schema.Document
+	for _, slot := range results {
+		docs = append(docs, *slot...)
+	}
 
 	return docs, nil
 }
 
+// selectParser selects parser by media type of the content type, then by "type/*".
+func (l *loader) selectParser(contentType string) parser.Parser {
+	if len(l.contentTypeParsers) == 0 || contentType == "" {
+		return l.parser
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	if p, ok := l.contentTypeParsers[mediaType]; ok {
+		return p
+	}
+	if i := strings.Index(mediaType, "/"); i > 0 {
+		if p, ok := l.contentTypeParsers[mediaType[:i]+"/*"]; ok {
+			return p
+		}
+	}
+
+	return l.parser
+}
+
 func uriToBucketAndKey(uri string) (bucket string, key string, isPrefix bool, err error) {
 	const (
 		uriPrefix = `s3://`
@@ -186,7 +376,7 @@ func uriToBucketAndKey(uri string) (bucket string, key string, isPrefix bool, er
 	bucket = bucketAndKey[:bucketEnd]
 	key = bucketAndKey[bucketEnd+1:]
 
-	if strings.HasSuffix(key, separator) {
+	if key == "" || strings.HasSuffix(key, separator) {
 		return bucket, key, true, nil
 	}
 
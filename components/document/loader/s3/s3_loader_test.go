@@ -18,9 +18,17 @@ package s3
 
 import (
 	"context"
+	"encoding/xml"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
@@ -29,6 +37,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/bytedance/mockey"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document/parser"
 )
 
 func TestNewS3Loader(t *testing.T) {
@@ -95,12 +105,6 @@ func TestLoader_Load(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "incomplete")
 
-		_, err = s3Loader.Load(ctx, document.Source{
-			URI: "s3://bucket/prefix/",
-		})
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "does not support batch load with prefix")
-
 		mockey.PatchConvey("get object returns no such key", func() {
 			mockey.Mock((*s3.Client).GetObject).Return(nil, &types.NoSuchKey{}).Build()
 
@@ -140,3 +144,202 @@ func TestLoader_Load(t *testing.T) {
 		assert.Equal(t, "key.txt", result[0].ID)
 	})
 }
+
+type fakeObject struct {
+	content      string
+	contentType  string
+	lastModified time.Time
+	userMeta     map[string]string
+}
+
+// fakeS3 is a minimal S3 compatible stand-in supporting path-style ListObjectsV2 and GetObject.
+type fakeS3 struct {
+	bucket  string
+	objects map[string]*fakeObject
+
+	mu        sync.Mutex
+	listCalls int
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name `xml:"ListBucketResult"`
+	Name                  string
+	Prefix                string
+	KeyCount              int
+	MaxKeys               int
+	IsTruncated           bool
+	NextContinuationToken string `xml:",omitempty"`
+	Contents              []listContent
+}
+
+type listContent struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	if bucket != f.bucket {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<Error><Code>NoSuchBucket</Code><Message>bucket not found</Message></Error>`))
+		return
+	}
+
+	if key == "" && r.URL.Query().Get("list-type") == "2" {
+		f.list(w, r)
+		return
+	}
+
+	obj, ok := f.objects[key]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>key not found</Message></Error>`))
+		return
+	}
+
+	w.Header().Set("ETag", `"etag-`+key+`"`)
+	w.Header().Set("Content-Type", obj.contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(obj.content)))
+	w.Header().Set("Last-Modified", obj.lastModified.UTC().Format(http.TimeFormat))
+	for k, v := range obj.userMeta {
+		w.Header().Set("x-amz-meta-"+k, v)
+	}
+	_, _ = w.Write([]byte(obj.content))
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.listCalls++
+	f.mu.Unlock()
+
+	query := r.URL.Query()
+	prefix := query.Get("prefix")
+	maxKeys, err := strconv.Atoi(query.Get("max-keys"))
+	if err != nil || maxKeys <= 0 {
+		maxKeys = 1000
+	}
+
+	var keys []string
+	for k := range f.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	start := 0
+	if token := query.Get("continuation-token"); token != "" {
+		start, _ = strconv.Atoi(token)
+	}
+	end := start + maxKeys
+	if end > len(keys) {
+		end = len(keys)
+	}
+
+	result := listBucketResult{
+		Name:     f.bucket,
+		Prefix:   prefix,
+		KeyCount: end - start,
+		MaxKeys:  maxKeys,
+	}
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = strconv.Itoa(end)
+	}
+	for _, k := range keys[start:end] {
+		result.Contents = append(result.Contents, listContent{
+			Key:          k,
+			LastModified: f.objects[k].lastModified.UTC().Format(time.RFC3339),
+			ETag:         `"etag-` + k + `"`,
+			Size:         len(f.objects[k].content),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+type upperParser struct{}
+
+func (u *upperParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	o := parser.GetCommonOptions(&parser.Options{}, opts...)
+	return []*schema.Document{{Content: strings.ToUpper(string(b)), MetaData: o.ExtraMeta}}, nil
+}
+
+func TestLoader_LoadPrefix(t *testing.T) {
+	ctx := context.Background()
+	modTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	fake := &fakeS3{
+		bucket: "bucket",
+		objects: map[string]*fakeObject{
+			"docs/":         {contentType: "application/x-directory", lastModified: modTime},
+			"docs/a.txt":    {content: "a", contentType: "text/plain", lastModified: modTime, userMeta: map[string]string{"author": "eino"}},
+			"docs/b.html":   {content: "b", contentType: "text/html; charset=utf-8", lastModified: modTime},
+			"docs/c/d.txt":  {content: "d", contentType: "text/plain", lastModified: modTime},
+			"docs/e.json":   {content: "e", contentType: "application/json", lastModified: modTime},
+			"other/f.txt":   {content: "f", contentType: "text/plain", lastModified: modTime},
+			"docs/g.txt":    {content: "g", contentType: "text/plain", lastModified: modTime},
+			"docs/h/i.html": {content: "i", contentType: "text/html", lastModified: modTime},
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	l, err := NewS3Loader(ctx, &LoaderConfig{
+		Region:           aws.String("us-east-1"),
+		AWSAccessKey:     aws.String("ak"),
+		AWSSecretKey:     aws.String("sk"),
+		Endpoint:         aws.String(server.URL),
+		UsePathStyle:     true,
+		UseObjectKeyAsID: true,
+		ContentTypeParsers: map[string]parser.Parser{
+			"TEXT/HTML": &upperParser{},
+		},
+		Concurrency: 2,
+		MaxKeys:     2,
+	})
+	assert.NoError(t, err)
+
+	docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, fake.listCalls)
+
+	var ids, contents []string
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+		contents = append(contents, doc.Content)
+	}
+	assert.Equal(t, []string{"docs/a.txt", "docs/b.html", "docs/c/d.txt", "docs/e.json", "docs/g.txt", "docs/h/i.html"}, ids)
+	assert.Equal(t, []string{"a", "B", "d", "e", "g", "I"}, contents)
+
+	meta := docs[0].MetaData
+	assert.Equal(t, "s3://bucket/docs/a.txt", meta[MetaKeySource])
+	assert.Equal(t, "bucket", meta[MetaKeyBucket])
+	assert.Equal(t, "docs/a.txt", meta[MetaKeyObjectKey])
+	assert.Equal(t, "etag-docs/a.txt", meta[MetaKeyETag])
+	assert.Equal(t, modTime, meta[MetaKeyLastModified].(time.Time).UTC())
+	assert.Equal(t, "text/plain", meta[MetaKeyContentType])
+	assert.Equal(t, int64(1), meta[MetaKeySize])
+	assert.Equal(t, map[string]string{"author": "eino"}, meta[MetaKeyUserMetadata])
+
+	docs, err = l.Load(ctx, document.Source{URI: "s3://bucket/docs/a.txt"})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, "a", docs[0].Content)
+
+	_, err = l.Load(ctx, document.Source{URI: "s3://bucket/docs/missing.txt"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	_, err = l.Load(ctx, document.Source{URI: "s3://missing/docs/"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "list objects err")
+}
@@ -0,0 +1,790 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/url/robots"
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyURL is the final url of the page after redirects.
+	MetaKeyURL = "_url"
+	// MetaKeyCanonical is the canonical url declared by the page, if any.
+	MetaKeyCanonical = "_canonical"
+	// MetaKeyDepth is the number of links followed from a seed to reach the page.
+	MetaKeyDepth = "_depth"
+	// MetaKeyNotModified marks the placeholder document of a page not modified since the last crawl,
+	// see CrawlerConfig.EmitNotModified. value: bool
+	MetaKeyNotModified = "_not_modified"
+)
+
+const (
+	defaultUserAgent          = "eino-crawler"
+	defaultCrawlerConcurrency = 4
+	defaultMaxBodySize        = 10 << 20
+	maxSitemapDepth           = 3
+)
+
+var _ document.Loader = (*Crawler)(nil)
+
+// CrawlerConfig is the config for url Crawler.
+type CrawlerConfig struct {
+	// Seeds are the urls to start crawling from.
+	// The uri of the source passed to Load is used as a seed as well.
+	Seeds []string
+
+	// optional, default: parser/html.
+	Parser parser.Parser
+	// optional, default http.DefaultClient.
+	Client *http.Client
+	// UserAgent is sent with every request and used to select the robots.txt group.
+	// Default is "eino-crawler".
+	UserAgent string
+
+	// SameDomain if true, only links to the hosts of the seeds are followed.
+	SameDomain bool
+	// PathPrefix if set, only links whose path starts with it are followed.
+	PathPrefix string
+	// MaxDepth is the max number of links followed from a seed, zero means no limit.
+	MaxDepth int
+	// MaxPages is the max number of pages fetched, zero means no limit.
+	MaxPages int
+	// Concurrency is the max number of pages fetched at the same time.
+	// Default is 4.
+	Concurrency int
+	// MaxBodySize is the max number of bytes read from a page or sitemap.
+	// Pages larger than it are skipped, sitemaps larger than it are ignored.
+	// robots.txt is read up to the first 500KB instead, as RFC 9309 requires crawlers to parse at least that.
+	// Default is 10MB.
+	MaxBodySize int64
+	// RequestInterval is the min interval between two requests to the same host.
+	// The Crawl-delay of robots.txt is used instead if it is longer and RespectRobots is true.
+	RequestInterval time.Duration
+
+	// RespectRobots if true, robots.txt, robots meta tags and rel="nofollow" links are honored.
+	RespectRobots bool
+	// UseSitemap if true, urls listed in the sitemaps of the seed hosts are crawled as seeds.
+	// Sitemaps declared in robots.txt are used when RespectRobots is true, "/sitemap.xml" otherwise.
+	UseSitemap bool
+
+	// StateStore keeps the validators of fetched pages, enables conditional requests with
+	// If-None-Match and If-Modified-Since when set. Pages not modified since the last crawl
+	// are not returned, but their links are still followed.
+	// optional.
+	StateStore StateStore
+	// EmitNotModified if true, a page not modified since the last crawl is returned as a document
+	// without content, which carries the page metadata and MetaKeyNotModified, so that an incremental
+	// loader can tell unchanged pages from deleted ones.
+	EmitNotModified bool
+}
+
+// PageState is what the crawler remembers about a page between crawls.
+type PageState struct {
+	ETag         string   `json:"etag,omitempty"`
+	LastModified string   `json:"last_modified,omitempty"`
+	Links        []string `json:"links,omitempty"`
+}
+
+// StateStore persists PageState by normalized page url.
+type StateStore interface {
+	Get(ctx context.Context, url string) (*PageState, error)
+	Put(ctx context.Context, url string, state *PageState) error
+}
+
+// MemoryStateStore is a StateStore kept in memory.
+type MemoryStateStore struct {
+	mu     sync.RWMutex
+	states map[string]*PageState
+}
+
+// NewMemoryStateStore creates an empty MemoryStateStore.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: make(map[string]*PageState)}
+}
+
+func (m *MemoryStateStore) Get(_ context.Context, url string) (*PageState, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.states[url], nil
+}
+
+func (m *MemoryStateStore) Put(_ context.Context, url string, state *PageState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.states[url] = state
+	return nil
+}
+
+// NewCrawler creates a new crawler that follows links from the seed urls.
+func NewCrawler(ctx context.Context, conf *CrawlerConfig) (*Crawler, error) {
+	if conf == nil {
+		conf = &CrawlerConfig{}
+	}
+
+	c := *conf
+	if c.Parser == nil {
+		p, err := html.NewParser(ctx, &html.Config{
+			Selector: &html.BodySelector,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create default HTML parser: %w", err)
+		}
+
+		c.Parser = p
+	}
+	if c.Client == nil {
+		c.Client = http.DefaultClient
+	}
+	if c.UserAgent == "" {
+		c.UserAgent = defaultUserAgent
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultCrawlerConcurrency
+	}
+	if c.MaxBodySize <= 0 {
+		c.MaxBodySize = defaultMaxBodySize
+	}
+
+	return &Crawler{
+		conf:    &c,
+		limiter: &hostLimiter{next: make(map[string]time.Time)},
+	}, nil
+}
+
+// Crawler is a loader that crawls a site from seed urls, every page is parsed by the configured parser.
+// Pages are fetched breadth first, so the returned documents are ordered by depth.
+type Crawler struct {
+	conf    *CrawlerConfig
+	limiter *hostLimiter
+}
+
+type crawlTask struct {
+	url   *neturl.URL
+	depth int
+}
+
+type pageResult struct {
+	finalURL *neturl.URL
+	// keys are the normalized urls identifying the page, the canonical one first.
+	keys  []string
+	docs  []*schema.Document
+	links []string
+	// state is stored when the crawl succeeds, nil if the page is not cacheable.
+	state *PageState
+}
+
+// Load crawls from src.URI and CrawlerConfig.Seeds.
+// Responses with a non 2xx status and pages disallowed by robots.txt are skipped,
+// request and parse errors abort the crawl.
+func (c *Crawler) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+
+	s, err := c.newSession(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err = s.run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+func (c *Crawler) GetType() string {
+	return "URLCrawler"
+}
+
+func (c *Crawler) IsCallbacksEnabled() bool {
+	return true
+}
+
+// crawlSession holds the state of a single Load.
+type crawlSession struct {
+	*Crawler
+
+	seeds []*neturl.URL
+	hosts map[string]struct{}
+	// seen are the urls already queued, emitted are the pages already returned.
+	seen    map[string]struct{}
+	emitted map[string]struct{}
+
+	robotsMu sync.Mutex
+	robots   map[string]*robotsEntry
+}
+
+type robotsEntry struct {
+	once  sync.Once
+	rules *robots.Rules
+}
+
+func (c *Crawler) newSession(ctx context.Context, src document.Source) (*crawlSession, error) {
+	raw := make([]string, 0, len(c.conf.Seeds)+1)
+	raw = append(raw, c.conf.Seeds...)
+	if src.URI != "" {
+		raw = append(raw, src.URI)
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("no seed url provided")
+	}
+
+	s := &crawlSession{
+		Crawler: c,
+		hosts:   make(map[string]struct{}),
+		seen:    make(map[string]struct{}),
+		emitted: make(map[string]struct{}),
+		robots:  make(map[string]*robotsEntry),
+	}
+	for _, r := range raw {
+		u, err := neturl.Parse(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid seed url [%s]: %w", r, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("invalid seed url [%s]: unsupported scheme", r)
+		}
+		s.seeds = append(s.seeds, u)
+		s.hosts[strings.ToLower(u.Hostname())] = struct{}{}
+	}
+
+	return s, nil
+}
+
+func (s *crawlSession) run(ctx context.Context) ([]*schema.Document, error) {
+	var level []*crawlTask
+	for _, u := range s.seeds {
+		level = s.enqueue(level, u, 0, false)
+	}
+	if s.conf.UseSitemap {
+		for _, u := range s.sitemapURLs(ctx) {
+			level = s.enqueue(level, u, 0, true)
+		}
+	}
+
+	var (
+		docs    []*schema.Document
+		states  = make(map[string]*PageState)
+		fetched int
+	)
+	for len(level) > 0 {
+		if s.conf.MaxPages > 0 {
+			remaining := s.conf.MaxPages - fetched
+			if remaining <= 0 {
+				break
+			}
+			if len(level) > remaining {
+				level = level[:remaining]
+			}
+		}
+
+		results, err := s.fetchAll(ctx, level)
+		if err != nil {
+			return nil, err
+		}
+		fetched += len(level)
+
+		var next []*crawlTask
+		for i, res := range results {
+			if res == nil {
+				continue
+			}
+			t := level[i]
+
+			for _, key := range res.keys {
+				s.seen[key] = struct{}{}
+			}
+			if res.state != nil {
+				states[res.keys[len(res.keys)-1]] = res.state
+			}
+			if len(res.docs) > 0 && !s.markEmitted(res.keys) {
+				docs = append(docs, res.docs...)
+			}
+
+			if s.conf.MaxDepth > 0 && t.depth >= s.conf.MaxDepth {
+				continue
+			}
+			for _, link := range res.links {
+				u, err := neturl.Parse(link)
+				if err != nil {
+					continue
+				}
+				next = s.enqueue(next, u, t.depth+1, true)
+			}
+		}
+		level = next
+	}
+
+	if s.conf.StateStore != nil {
+		for key, state := range states {
+			if err := s.conf.StateStore.Put(ctx, key, state); err != nil {
+				return nil, fmt.Errorf("failed to save state of [%s]: %w", key, err)
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// enqueue appends u to tasks if it has not been seen, scope is checked for links and sitemap urls but not for seeds.
+func (s *crawlSession) enqueue(tasks []*crawlTask, u *neturl.URL, depth int, checkScope bool) []*crawlTask {
+	if checkScope && !s.inScope(u) {
+		return tasks
+	}
+	key := normalizeURL(u)
+	if _, ok := s.seen[key]; ok {
+		return tasks
+	}
+	s.seen[key] = struct{}{}
+
+	return append(tasks, &crawlTask{url: u, depth: depth})
+}
+
+// markEmitted records the keys of a page and reports whether one of them was returned before.
+func (s *crawlSession) markEmitted(keys []string) bool {
+	dup := false
+	for _, key := range keys {
+		if _, ok := s.emitted[key]; ok {
+			dup = true
+		}
+	}
+	for _, key := range keys {
+		s.emitted[key] = struct{}{}
+	}
+
+	return dup
+}
+
+func (s *crawlSession) inScope(u *neturl.URL) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	if s.conf.SameDomain {
+		if _, ok := s.hosts[strings.ToLower(u.Hostname())]; !ok {
+			return false
+		}
+	}
+	if s.conf.PathPrefix != "" && !strings.HasPrefix(u.Path, s.conf.PathPrefix) {
+		return false
+	}
+
+	return true
+}
+
+// fetchAll fetches the tasks of one level with bounded concurrency, results keep the order of tasks.
+func (s *crawlSession) fetchAll(ctx context.Context, tasks []*crawlTask) ([]*pageResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*pageResult, len(tasks))
+	errs := make([]error, len(tasks))
+	sem := make(chan struct{}, s.conf.Concurrency)
+	wg := sync.WaitGroup{}
+	for i := range tasks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				if e := recover(); e != nil {
+					errs[i] = fmt.Errorf("panic: %v", e)
+					cancel()
+				}
+			}()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = s.fetchPage(ctx, tasks[i])
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// report the root cause rather than the cancellation it triggered.
+	var firstErr error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return results, nil
+}
+
+// fetchPage returns nil if the page is skipped.
+func (s *crawlSession) fetchPage(ctx context.Context, t *crawlTask) (*pageResult, error) {
+	interval := s.conf.RequestInterval
+	if s.conf.RespectRobots {
+		rules := s.robotsFor(ctx, t.url)
+		if !rules.Allowed(robots.Path(t.url)) {
+			return nil, nil
+		}
+		if rules.CrawlDelay() > interval {
+			interval = rules.CrawlDelay()
+		}
+	}
+
+	key := normalizeURL(t.url)
+	var prev *PageState
+	if s.conf.StateStore != nil {
+		var err error
+		prev, err = s.conf.StateStore.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state of [%s]: %w", key, err)
+		}
+	}
+
+	if err := s.limiter.wait(ctx, t.url.Host, interval); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", s.conf.UserAgent)
+	if prev != nil {
+		if prev.ETag != "" {
+			req.Header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
+	}
+
+	resp, err := s.conf.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load content from uri [%s]: %w", t.url, err)
+	}
+	defer resp.Body.Close()
+
+	finalURL := t.url
+	if resp.Request != nil && resp.Request.URL != nil {
+		finalURL = resp.Request.URL
+	}
+
+	if resp.StatusCode == http.StatusNotModified && prev != nil {
+		res := &pageResult{
+			finalURL: finalURL,
+			keys:     []string{key},
+			links:    prev.Links,
+			state:    prev,
+		}
+		if s.conf.EmitNotModified {
+			doc := &schema.Document{MetaData: map[string]any{
+				MetaKeyURL:         finalURL.String(),
+				MetaKeyDepth:       t.depth,
+				MetaKeyNotModified: true,
+			}}
+			setSourceMeta(doc, finalURL.String(), prev.ETag, prev.LastModified)
+			res.docs = []*schema.Document{doc}
+		}
+		return res, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil
+	}
+
+	finalKey := normalizeURL(finalURL)
+	// links redirected out of scope are skipped, seeds are always crawled.
+	if finalKey != key && t.depth > 0 && !s.inScope(finalURL) {
+		return nil, nil
+	}
+
+	body, err := readBody(resp.Body, s.conf.MaxBodySize)
+	if errors.Is(err, errBodyTooLarge) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content from uri [%s]: %w", finalURL, err)
+	}
+
+	res := &pageResult{finalURL: finalURL}
+
+	var pl *pageLinks
+	if isHTML(resp.Header.Get("Content-Type"), body) {
+		pl = extractLinks(finalURL, body, s.conf.RespectRobots)
+		if !s.conf.RespectRobots {
+			pl.noIndex, pl.noFollow = false, false
+		}
+		if !pl.noFollow {
+			res.links = pl.links
+		}
+	}
+
+	if pl != nil && pl.canonical != "" {
+		if cu, err := neturl.Parse(pl.canonical); err == nil {
+			res.keys = append(res.keys, normalizeURL(cu))
+		}
+	}
+	if finalKey != key {
+		res.keys = append(res.keys, finalKey)
+	}
+	res.keys = append(res.keys, key)
+
+	if resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "" {
+		res.state = &PageState{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Links:        res.links,
+		}
+	}
+
+	if pl != nil && pl.noIndex {
+		return res, nil
+	}
+
+	docs, err := s.conf.Parser.Parse(ctx, bytes.NewReader(body), parser.WithURI(finalURL.String()))
+	if err != nil {
+		return nil, fmt.Errorf("parse content of uri [%s] err: %w", finalURL, err)
+	}
+	for _, doc := range docs {
+		setSourceMeta(doc, finalURL.String(), resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+		doc.MetaData[MetaKeyURL] = finalURL.String()
+		doc.MetaData[MetaKeyDepth] = t.depth
+		if pl != nil && pl.canonical != "" {
+			doc.MetaData[MetaKeyCanonical] = pl.canonical
+		}
+	}
+	res.docs = docs
+
+	return res, nil
+}
+
+// robotsFor fetches robots.txt once per host.
+func (s *crawlSession) robotsFor(ctx context.Context, u *neturl.URL) *robots.Rules {
+	origin := u.Scheme + "://" + u.Host
+
+	s.robotsMu.Lock()
+	e, ok := s.robots[origin]
+	if !ok {
+		e = &robotsEntry{}
+		s.robots[origin] = e
+	}
+	s.robotsMu.Unlock()
+
+	e.once.Do(func() {
+		e.rules = s.fetchRobots(ctx, origin)
+	})
+
+	return e.rules
+}
+
+// fetchRobots gets the robots.txt of origin, ref: RFC 9309: a missing robots.txt (4xx) allows everything,
+// while an unreachable one (5xx or network errors) disallows everything. Only the first robots.MaxSize bytes are parsed.
+func (s *crawlSession) fetchRobots(ctx context.Context, origin string) *robots.Rules {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return robots.DisallowAll()
+	}
+	req.Header.Set("User-Agent", s.conf.UserAgent)
+
+	resp, err := s.conf.Client.Do(req)
+	if err != nil {
+		return robots.DisallowAll()
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return robots.Parse(io.LimitReader(resp.Body, robots.MaxSize), s.conf.UserAgent)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return nil
+	default:
+		return robots.DisallowAll()
+	}
+}
+
+// sitemapURLs collects page urls from the sitemaps of the seed hosts, failures are ignored.
+func (s *crawlSession) sitemapURLs(ctx context.Context) []*neturl.URL {
+	var (
+		queue   []string
+		visited = make(map[string]struct{})
+		origins = make(map[string]struct{})
+	)
+	for _, seed := range s.seeds {
+		origin := seed.Scheme + "://" + seed.Host
+		if _, ok := origins[origin]; ok {
+			continue
+		}
+		origins[origin] = struct{}{}
+
+		var declared []string
+		if s.conf.RespectRobots {
+			declared = s.robotsFor(ctx, seed).Sitemaps()
+		}
+		if len(declared) == 0 {
+			declared = []string{origin + "/sitemap.xml"}
+		}
+		queue = append(queue, declared...)
+	}
+
+	var ret []*neturl.URL
+	for depth := 0; depth < maxSitemapDepth && len(queue) > 0; depth++ {
+		var nested []string
+		for _, sm := range queue {
+			if _, ok := visited[sm]; ok {
+				continue
+			}
+			visited[sm] = struct{}{}
+
+			body, err := s.get(ctx, sm)
+			if err != nil {
+				continue
+			}
+			urls, sitemaps, err := parseSitemap(bytes.NewReader(body))
+			if err != nil {
+				continue
+			}
+			for _, raw := range urls {
+				if u, err := neturl.Parse(raw); err == nil {
+					ret = append(ret, u)
+				}
+			}
+			nested = append(nested, sitemaps...)
+		}
+		queue = nested
+	}
+
+	return ret
+}
+
+// get fetches an auxiliary resource such as robots.txt or a sitemap.
+func (s *crawlSession) get(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", s.conf.UserAgent)
+
+	resp, err := s.conf.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return readBody(resp.Body, s.conf.MaxBodySize)
+}
+
+var errBodyTooLarge = errors.New("body too large")
+
+// readBody reads at most limit bytes, errBodyTooLarge is returned if r has more.
+func readBody(r io.Reader, limit int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, errBodyTooLarge
+	}
+
+	return body, nil
+}
+
+func isHTML(contentType string, body []byte) bool {
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	return strings.Contains(strings.ToLower(contentType), "html")
+}
+
+// hostLimiter spaces out requests to the same host.
+type hostLimiter struct {
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func (l *hostLimiter) wait(ctx context.Context, host string, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[host] = at.Add(interval)
+	l.mu.Unlock()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
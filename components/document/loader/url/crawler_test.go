@@ -0,0 +1,379 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+type testSite struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	hits         map[string]int
+	robotsStatus int
+}
+
+func newTestSite() *testSite {
+	site := &testSite{hits: make(map[string]int)}
+
+	page := func(body string) string {
+		return "<html><head>" + body + "</head></html>"
+	}
+	pages := map[string]string{
+		"/": page(`<a href="/docs/a">a</a><a href="/docs/b#top">b</a><a href="/private/x">x</a>
+			<a href="/other">other</a><a href="http://example.invalid/">ext</a><a href="mailto:a@b.c">mail</a>`),
+		"/docs/a":      page(`<link rel="canonical" href="/docs/a"><a href="c">c</a>`),
+		"/docs/b":      page(`<link rel="canonical" href="/docs/a"><a href="/docs/a">a</a>`),
+		"/docs/orphan": page(`orphan`),
+		"/other":       page(`<meta name="robots" content="noindex"><a href="/docs/hidden">hidden</a>`),
+		"/docs/hidden": page(`hidden`),
+		"/private/x":   page(`private`),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		if status := site.status(); status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = fmt.Fprintf(w, "User-agent: *\nDisallow: /private\nSitemap: http://%s/sitemap.xml\n", r.Host)
+	})
+	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `<?xml version="1.0"?><urlset><url><loc>http://%s/docs/orphan</loc></url></urlset>`, r.Host)
+	})
+	mux.HandleFunc("/docs/c", func(w http.ResponseWriter, r *http.Request) {
+		site.hit(r.URL.Path)
+		if r.Header.Get("If-None-Match") == `"c1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"c1"`)
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = io.WriteString(w, page(`<a href="/docs/d">d</a>`))
+	})
+	mux.HandleFunc("/docs/d", func(w http.ResponseWriter, r *http.Request) {
+		site.hit(r.URL.Path)
+		_, _ = io.WriteString(w, "plain text page")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		site.hit(r.URL.Path)
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = io.WriteString(w, body)
+	})
+
+	site.Server = httptest.NewServer(mux)
+	return site
+}
+
+func (s *testSite) hit(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hits[path]++
+}
+
+func (s *testSite) setRobotsStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.robotsStatus = status
+}
+
+func (s *testSite) status() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.robotsStatus
+}
+
+func (s *testSite) hitCount(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits[path]
+}
+
+func urlParser() *MockParser {
+	return &MockParser{
+		mock: func(reader io.Reader) ([]*schema.Document, error) {
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, err
+			}
+			return []*schema.Document{{Content: string(data)}}, nil
+		},
+	}
+}
+
+func paths(docs []*schema.Document) []string {
+	ret := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		u, _ := neturl.Parse(doc.MetaData[MetaKeyURL].(string))
+		ret = append(ret, u.Path)
+	}
+	return ret
+}
+
+func TestCrawler(t *testing.T) {
+	ctx := callbacks.InitCallbacks(context.Background(), &callbacks.RunInfo{})
+
+	t.Run("crawl site", func(t *testing.T) {
+		site := newTestSite()
+		defer site.Close()
+
+		c, err := NewCrawler(ctx, &CrawlerConfig{
+			Parser:        urlParser(),
+			SameDomain:    true,
+			RespectRobots: true,
+			UseSitemap:    true,
+		})
+		assert.Nil(t, err)
+
+		docs, err := c.Load(ctx, document.Source{URI: site.URL + "/"})
+		assert.Nil(t, err)
+		// /docs/b shares the canonical url of /docs/a, /other is noindex but its links are followed.
+		assert.Equal(t, []string{"/", "/docs/orphan", "/docs/a", "/docs/c", "/docs/hidden", "/docs/d"}, paths(docs))
+		assert.Equal(t, 0, site.hitCount("/private/x"))
+		assert.Equal(t, 1, site.hitCount("/docs/b"))
+		assert.Equal(t, 1, site.hitCount("/docs/a"))
+
+		assert.Equal(t, 0, docs[0].MetaData[MetaKeyDepth])
+		assert.Equal(t, site.URL+"/docs/a", docs[2].MetaData[MetaKeyCanonical])
+		assert.Equal(t, 1, docs[2].MetaData[MetaKeyDepth])
+		assert.Equal(t, 2, docs[3].MetaData[MetaKeyDepth])
+		assert.Equal(t, "plain text page", docs[5].Content)
+	})
+
+	t.Run("scope and limits", func(t *testing.T) {
+		site := newTestSite()
+		defer site.Close()
+
+		c, err := NewCrawler(ctx, &CrawlerConfig{
+			Seeds:      []string{site.URL},
+			Parser:     urlParser(),
+			PathPrefix: "/docs/",
+			MaxDepth:   1,
+		})
+		assert.Nil(t, err)
+
+		docs, err := c.Load(ctx, document.Source{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"", "/docs/a"}, paths(docs))
+		assert.Equal(t, 0, site.hitCount("/docs/c"))
+		assert.Equal(t, 0, site.hitCount("/other"))
+
+		c, err = NewCrawler(ctx, &CrawlerConfig{
+			Parser:   urlParser(),
+			MaxPages: 2,
+		})
+		assert.Nil(t, err)
+
+		docs, err = c.Load(ctx, document.Source{URI: site.URL + "/"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/", "/docs/a"}, paths(docs))
+	})
+
+	t.Run("conditional requests", func(t *testing.T) {
+		site := newTestSite()
+		defer site.Close()
+
+		store := NewMemoryStateStore()
+		c, err := NewCrawler(ctx, &CrawlerConfig{
+			Seeds:      []string{site.URL + "/docs/c"},
+			Parser:     urlParser(),
+			StateStore: store,
+		})
+		assert.Nil(t, err)
+
+		docs, err := c.Load(ctx, document.Source{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/docs/c", "/docs/d"}, paths(docs))
+		assert.Equal(t, site.URL+"/docs/c", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, `"c1"`, docs[0].MetaData[MetaKeyETag])
+		assert.Nil(t, docs[1].MetaData[MetaKeyETag])
+
+		state, err := store.Get(ctx, site.URL+"/docs/c")
+		assert.Nil(t, err)
+		assert.Equal(t, `"c1"`, state.ETag)
+		assert.Equal(t, []string{site.URL + "/docs/d"}, state.Links)
+
+		// not modified, /docs/d is still reached through the stored links.
+		docs, err = c.Load(ctx, document.Source{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/docs/d"}, paths(docs))
+		assert.Equal(t, 2, site.hitCount("/docs/c"))
+		assert.Equal(t, 2, site.hitCount("/docs/d"))
+
+		// placeholders of not modified pages.
+		c, err = NewCrawler(ctx, &CrawlerConfig{
+			Seeds:           []string{site.URL + "/docs/c"},
+			Parser:          urlParser(),
+			StateStore:      store,
+			EmitNotModified: true,
+		})
+		assert.Nil(t, err)
+
+		docs, err = c.Load(ctx, document.Source{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/docs/c", "/docs/d"}, paths(docs))
+		assert.Equal(t, "", docs[0].Content)
+		assert.Equal(t, true, docs[0].MetaData[MetaKeyNotModified])
+		assert.Equal(t, site.URL+"/docs/c", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, `"c1"`, docs[0].MetaData[MetaKeyETag])
+		assert.Nil(t, docs[1].MetaData[MetaKeyNotModified])
+	})
+
+	t.Run("max body size", func(t *testing.T) {
+		site := newTestSite()
+		defer site.Close()
+
+		c, err := NewCrawler(ctx, &CrawlerConfig{
+			Seeds:         []string{site.URL + "/docs/c", site.URL + "/docs/d", site.URL + "/private/x"},
+			Parser:        urlParser(),
+			MaxBodySize:   int64(len("plain text page")),
+			RespectRobots: true,
+		})
+		assert.Nil(t, err)
+
+		// /docs/c is larger than the limit and skipped, robots.txt is larger than the limit too but still honoured.
+		docs, err := c.Load(ctx, document.Source{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/docs/d"}, paths(docs))
+		assert.Equal(t, 1, site.hitCount("/docs/c"))
+		assert.Equal(t, 0, site.hitCount("/private/x"))
+	})
+
+	t.Run("robots.txt status", func(t *testing.T) {
+		site := newTestSite()
+		defer site.Close()
+
+		c, err := NewCrawler(ctx, &CrawlerConfig{
+			Seeds:         []string{site.URL + "/private/x"},
+			Parser:        urlParser(),
+			MaxDepth:      1,
+			RespectRobots: true,
+		})
+		assert.Nil(t, err)
+
+		// a missing robots.txt allows everything.
+		site.setRobotsStatus(http.StatusNotFound)
+		docs, err := c.Load(ctx, document.Source{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/private/x"}, paths(docs))
+
+		// an unreachable robots.txt disallows everything.
+		site.setRobotsStatus(http.StatusServiceUnavailable)
+		docs, err = c.Load(ctx, document.Source{})
+		assert.Nil(t, err)
+		assert.Empty(t, docs)
+		assert.Equal(t, 1, site.hitCount("/private/x"))
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		site := newTestSite()
+		defer site.Close()
+
+		c, err := NewCrawler(ctx, &CrawlerConfig{
+			Seeds:           []string{site.URL + "/docs/a", site.URL + "/docs/b", site.URL + "/docs/orphan"},
+			Parser:          urlParser(),
+			MaxDepth:        1,
+			MaxPages:        3,
+			RequestInterval: 50 * time.Millisecond,
+		})
+		assert.Nil(t, err)
+
+		start := time.Now()
+		_, err = c.Load(ctx, document.Source{})
+		assert.Nil(t, err)
+		assert.True(t, time.Since(start) >= 100*time.Millisecond)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c, err := NewCrawler(ctx, &CrawlerConfig{Parser: urlParser()})
+		assert.Nil(t, err)
+
+		_, err = c.Load(ctx, document.Source{})
+		assert.NotNil(t, err)
+
+		_, err = c.Load(ctx, document.Source{URI: "ftp://127.0.0.1/"})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestParseSitemap(t *testing.T) {
+	urls, sitemaps, err := parseSitemap(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc> http://example.com/a.xml </loc></sitemap>
+</sitemapindex>`))
+	assert.Nil(t, err)
+	assert.Empty(t, urls)
+	assert.Equal(t, []string{"http://example.com/a.xml"}, sitemaps)
+
+	urls, _, err = parseSitemap(strings.NewReader(`<urlset><url><loc>http://example.com/b</loc></url><url><loc>http://example.com/a</loc></url></urlset>`))
+	assert.Nil(t, err)
+	sort.Strings(urls)
+	assert.Equal(t, []string{"http://example.com/a", "http://example.com/b"}, urls)
+
+	_, _, err = parseSitemap(strings.NewReader(`not xml`))
+	assert.NotNil(t, err)
+}
+
+func TestExtractLinks(t *testing.T) {
+	base, _ := neturl.Parse("http://example.com/docs/index.html")
+	body := []byte(`<html><head>
+<base href="/root/">
+<link rel="canonical" href="http://Example.com:80/docs/">
+<meta name="robots" content="nofollow">
+</head><body>
+<a href="a.html">a</a>
+<a href="/b#frag">b</a>
+<a rel="nofollow" href="c.html">c</a>
+<a href="javascript:void(0)">js</a>
+</body></html>`)
+
+	pl := extractLinks(base, body, true)
+	assert.Equal(t, []string{"http://example.com/root/a.html", "http://example.com/b#frag"}, pl.links)
+	assert.Equal(t, "http://Example.com:80/docs/", pl.canonical)
+	assert.True(t, pl.noFollow)
+	assert.False(t, pl.noIndex)
+
+	pl = extractLinks(base, body, false)
+	assert.Equal(t, 3, len(pl.links))
+
+	cu, _ := neturl.Parse(pl.canonical)
+	assert.Equal(t, "http://example.com/docs/", normalizeURL(cu))
+	u, _ := neturl.Parse("https://Example.com:443?q=1#x")
+	assert.Equal(t, "https://example.com/?q=1", normalizeURL(u))
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/url"
+	"github.com/cloudwego/eino/components/document"
+)
+
+func main() {
+	staticDir := "../testdata"
+	// server
+	fileServer := http.FileServer(http.Dir(staticDir))
+	http.Handle("/", fileServer)
+
+	addr := "127.0.0.1:18002"
+
+	go func() { // nolint: byted_goroutine_recover
+		fmt.Println("Serving directory on http://127.0.0.1:18002")
+		if err := http.ListenAndServe(addr, nil); err != nil {
+			fmt.Println("Server failed to start:", err)
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	ctx := context.Background()
+	crawler, err := url.NewCrawler(ctx, &url.CrawlerConfig{
+		SameDomain:      true,
+		MaxDepth:        2,
+		MaxPages:        50,
+		RequestInterval: 100 * time.Millisecond,
+		RespectRobots:   true,
+		UseSitemap:      true,
+		StateStore:      url.NewMemoryStateStore(),
+	})
+	if err != nil {
+		log.Fatalf("NewCrawler failed, err=%v", err)
+	}
+
+	docs, err := crawler.Load(ctx, document.Source{
+		URI: fmt.Sprintf("http://%s/", addr),
+	})
+	if err != nil {
+		log.Fatalf("Load failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("url=%v, depth=%v, content=%q\n", doc.MetaData[url.MetaKeyURL], doc.MetaData[url.MetaKeyDepth], doc.Content)
+	}
+}
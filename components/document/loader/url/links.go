@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bytes"
+	neturl "net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// pageLinks is what the crawler needs from an html page besides its content.
+type pageLinks struct {
+	links     []string
+	canonical string
+	noIndex   bool
+	noFollow  bool
+}
+
+// extractLinks collects absolute http(s) links, the canonical url and the robots meta directives of an html page.
+// Anchors with rel="nofollow" are skipped when respectNoFollow is true.
+func extractLinks(base *neturl.URL, body []byte, respectNoFollow bool) *pageLinks {
+	ret := &pageLinks{}
+	var hrefs []string
+
+	z := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		tok := z.Token()
+		switch tok.DataAtom {
+		case atom.Base:
+			if href := attr(tok, "href"); href != "" {
+				if u, err := base.Parse(href); err == nil {
+					base = u
+				}
+			}
+		case atom.A, atom.Area:
+			href := attr(tok, "href")
+			if href == "" {
+				continue
+			}
+			if respectNoFollow && hasToken(attr(tok, "rel"), "nofollow") {
+				continue
+			}
+			hrefs = append(hrefs, href)
+		case atom.Link:
+			if hasToken(attr(tok, "rel"), "canonical") {
+				ret.canonical = attr(tok, "href")
+			}
+		case atom.Meta:
+			if strings.EqualFold(attr(tok, "name"), "robots") {
+				content := strings.ToLower(attr(tok, "content"))
+				ret.noIndex = ret.noIndex || strings.Contains(content, "noindex") || strings.Contains(content, "none")
+				ret.noFollow = ret.noFollow || strings.Contains(content, "nofollow") || strings.Contains(content, "none")
+			}
+		}
+	}
+
+	// resolve after the whole page is read, <base> applies to every link in the document.
+	for _, href := range hrefs {
+		if u := resolveLink(base, href); u != "" {
+			ret.links = append(ret.links, u)
+		}
+	}
+	if ret.canonical != "" {
+		ret.canonical = resolveLink(base, ret.canonical)
+	}
+
+	return ret
+}
+
+func resolveLink(base *neturl.URL, href string) string {
+	u, err := base.Parse(strings.TrimSpace(href))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+
+	return u.String()
+}
+
+func attr(tok html.Token, key string) string {
+	for _, a := range tok.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+func hasToken(s, token string) bool {
+	for _, f := range strings.Fields(strings.ToLower(s)) {
+		if f == token {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeURL is used as the de-duplication key: scheme and host are lower-cased,
+// default ports and fragments are dropped and an empty path becomes "/".
+func normalizeURL(u *neturl.URL) string {
+	n := *u
+	n.Scheme = strings.ToLower(n.Scheme)
+	n.Host = strings.ToLower(n.Host)
+	if port := n.Port(); (n.Scheme == "http" && port == "80") || (n.Scheme == "https" && port == "443") {
+		n.Host = strings.TrimSuffix(n.Host, ":"+port)
+	}
+	if n.Path == "" {
+		n.Path = "/"
+		n.RawPath = ""
+	}
+	n.Fragment = ""
+	n.RawFragment = ""
+	n.User = nil
+
+	return n.String()
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package robots parses robots.txt and matches paths against the rules for a user agent, ref: RFC 9309.
+package robots
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSize is the size of robots.txt that crawlers are required to parse, ref: RFC 9309.
+const MaxSize = 500 << 10
+
+// Rules is the group of a robots.txt that applies to a user agent.
+type Rules struct {
+	rules      []*rule
+	crawlDelay time.Duration
+	sitemaps   []string
+}
+
+type rule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+type group struct {
+	agents     []string
+	rules      []*rule
+	crawlDelay time.Duration
+}
+
+// Parse parses a robots.txt and keeps the group matching userAgent, falling back to the "*" group.
+func Parse(r io.Reader, userAgent string) *Rules {
+	var (
+		groups   []*group
+		cur      *group
+		inRules  bool
+		sitemaps []string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if cur == nil || inRules {
+				cur = &group{}
+				groups = append(groups, cur)
+				inRules = false
+			}
+			cur.agents = append(cur.agents, strings.ToLower(value))
+		case "allow", "disallow":
+			if cur == nil {
+				continue
+			}
+			inRules = true
+			if value == "" {
+				continue
+			}
+			cur.rules = append(cur.rules, &rule{
+				allow:   key == "allow",
+				pattern: value,
+				re:      compilePattern(value),
+			})
+		case "crawl-delay":
+			if cur == nil {
+				continue
+			}
+			inRules = true
+			if sec, err := strconv.ParseFloat(value, 64); err == nil && sec > 0 {
+				cur.crawlDelay = time.Duration(sec * float64(time.Second))
+			}
+		case "sitemap":
+			if value != "" {
+				sitemaps = append(sitemaps, value)
+			}
+		}
+	}
+
+	ret := &Rules{sitemaps: sitemaps}
+	if g := selectGroup(groups, userAgent); g != nil {
+		ret.rules = g.rules
+		ret.crawlDelay = g.crawlDelay
+	}
+
+	return ret
+}
+
+// DisallowAll returns the rules disallowing everything, e.g. when the robots.txt is unreachable.
+func DisallowAll() *Rules {
+	return &Rules{rules: []*rule{{allow: false, pattern: "/", re: compilePattern("/")}}}
+}
+
+// selectGroup picks the group with the longest agent token contained in userAgent, or the "*" group.
+func selectGroup(groups []*group, userAgent string) *group {
+	ua := strings.ToLower(userAgent)
+
+	var (
+		best     *group
+		bestLen  int
+		wildcard *group
+	)
+	for _, g := range groups {
+		for _, agent := range g.agents {
+			if agent == "*" {
+				if wildcard == nil {
+					wildcard = g
+				}
+				continue
+			}
+			if agent != "" && strings.Contains(ua, agent) && len(agent) > bestLen {
+				best, bestLen = g, len(agent)
+			}
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	return wildcard
+}
+
+// compilePattern supports the "*" wildcard and the "$" end anchor.
+func compilePattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+
+	return regexp.MustCompile(expr)
+}
+
+// Allowed reports whether path (with query, see Path) may be fetched, the longest matching rule wins and allow wins ties.
+// A nil Rules allows everything, e.g. when the site has no robots.txt.
+func (r *Rules) Allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+
+	allow, matchLen := true, -1
+	for _, ru := range r.rules {
+		if !ru.re.MatchString(path) {
+			continue
+		}
+		l := len(ru.pattern)
+		if l > matchLen || (l == matchLen && ru.allow) {
+			allow, matchLen = ru.allow, l
+		}
+	}
+
+	return allow
+}
+
+// CrawlDelay returns the Crawl-delay of the group, zero if not set.
+func (r *Rules) CrawlDelay() time.Duration {
+	if r == nil {
+		return 0
+	}
+	return r.crawlDelay
+}
+
+// Sitemaps returns the sitemap urls declared in the robots.txt, which apply to all user agents.
+func (r *Rules) Sitemaps() []string {
+	if r == nil {
+		return nil
+	}
+	return r.sitemaps
+}
+
+// Path returns the escaped path with query of u, to match against the rules.
+func Path(u *url.URL) string {
+	p := u.EscapedPath()
+	if p == "" {
+		p = "/"
+	}
+	if u.RawQuery != "" {
+		p += "?" + u.RawQuery
+	}
+
+	return p
+}
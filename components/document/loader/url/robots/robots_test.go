@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package robots
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	robots := `
+# comment
+User-agent: *
+Disallow: /
+
+User-agent: other-bot
+User-agent: eino-crawler
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+Crawl-delay: 1.5
+
+Sitemap: http://example.com/sitemap.xml
+`
+	rules := Parse(strings.NewReader(robots), "Mozilla/5.0 (compatible; eino-crawler/1.0)")
+	assert.Equal(t, 1500*time.Millisecond, rules.CrawlDelay())
+	assert.Equal(t, []string{"http://example.com/sitemap.xml"}, rules.Sitemaps())
+	assert.True(t, rules.Allowed("/"))
+	assert.False(t, rules.Allowed("/private/a"))
+	assert.True(t, rules.Allowed("/private/public/a"))
+	assert.False(t, rules.Allowed("/docs/a.pdf"))
+	assert.True(t, rules.Allowed("/docs/a.pdf?x=1"))
+
+	rules = Parse(strings.NewReader(robots), "unknown")
+	assert.False(t, rules.Allowed("/"))
+	assert.Equal(t, time.Duration(0), rules.CrawlDelay())
+
+	var nilRules *Rules
+	assert.True(t, nilRules.Allowed("/"))
+	assert.Equal(t, time.Duration(0), nilRules.CrawlDelay())
+	assert.Nil(t, nilRules.Sitemaps())
+}
+
+func TestParseAnchored(t *testing.T) {
+	robots := `
+User-agent: eino
+User-agent: other
+Disallow: /tmp
+Allow: /tmp/public$
+Disallow: /*.pdf$
+
+User-agent: *
+Disallow: /
+`
+	rules := Parse(strings.NewReader(robots), "eino (https://github.com/cloudwego/eino)")
+	assert.True(t, rules.Allowed("/"))
+	assert.False(t, rules.Allowed("/tmp/a"))
+	assert.True(t, rules.Allowed("/tmp/public"))
+	assert.False(t, rules.Allowed("/tmp/public/a"))
+	assert.False(t, rules.Allowed("/docs/a.pdf"))
+	assert.True(t, rules.Allowed("/docs/a.pdf?download=1"))
+
+	rules = Parse(strings.NewReader(robots), "crawler")
+	assert.False(t, rules.Allowed("/"))
+}
+
+func TestPath(t *testing.T) {
+	u, _ := url.Parse("http://example.com")
+	assert.Equal(t, "/", Path(u))
+	u, _ = url.Parse("http://example.com/a%20b?x=1")
+	assert.Equal(t, "/a%20b?x=1", Path(u))
+}
+
+func TestDisallowAll(t *testing.T) {
+	rules := DisallowAll()
+	assert.False(t, rules.Allowed("/"))
+	assert.False(t, rules.Allowed("/a?b=c"))
+	assert.Equal(t, time.Duration(0), rules.CrawlDelay())
+}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/cloudwego/eino-ext/components/document/parser/html"
@@ -32,6 +31,16 @@ import (
 
 var _ document.Loader = (*Loader)(nil)
 
+// Metadata keys recorded by the Loader and the Crawler, which let incremental loaders identify and fingerprint pages.
+const (
+	// MetaKeySource is the url of the page, value: string.
+	MetaKeySource = "_source"
+	// MetaKeyETag is the ETag response header, value: string.
+	MetaKeyETag = "_etag"
+	// MetaKeyLastModified is the Last-Modified response header, value: time.Time.
+	MetaKeyLastModified = "_last_modified"
+)
+
 // LoaderConfig is the config for url Loader.
 type LoaderConfig struct {
 	// optional, default: parser/html.
@@ -92,21 +101,24 @@ func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document
 		Source: src,
 	})
 
-	var readerCloser io.ReadCloser
-	readerCloser, err = l.load(ctx, src)
+	var resp *http.Response
+	resp, err = l.load(ctx, src)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load content from uri [%s]: %w", src.URI, err)
 	}
-	defer readerCloser.Close()
+	defer resp.Body.Close()
 
 	if l.conf.Parser == nil {
 		return nil, errors.New("parser is nil")
 	}
 
-	docs, err = l.conf.Parser.Parse(ctx, readerCloser, parser.WithURI(src.URI))
+	docs, err = l.conf.Parser.Parse(ctx, resp.Body, parser.WithURI(src.URI))
 	if err != nil {
 		return nil, fmt.Errorf("parse content of uri [%s] err: %w", src.URI, err)
 	}
+	for _, doc := range docs {
+		setSourceMeta(doc, src.URI, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+	}
 
 	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
 		Source: src,
@@ -116,7 +128,7 @@ func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document
 	return docs, nil
 }
 
-func (l *Loader) load(ctx context.Context, src document.Source) (io.ReadCloser, error) {
+func (l *Loader) load(ctx context.Context, src document.Source) (*http.Response, error) {
 	req, err := l.conf.RequestBuilder(ctx, src)
 	if err != nil {
 		return nil, err
@@ -127,7 +139,21 @@ func (l *Loader) load(ctx context.Context, src document.Source) (io.ReadCloser,
 		return nil, err
 	}
 
-	return resp.Body, nil
+	return resp, nil
+}
+
+// setSourceMeta records the url and the validators of the response the document is parsed from.
+func setSourceMeta(doc *schema.Document, source, etag, lastModified string) {
+	if doc.MetaData == nil {
+		doc.MetaData = make(map[string]any)
+	}
+	doc.MetaData[MetaKeySource] = source
+	if etag != "" {
+		doc.MetaData[MetaKeyETag] = etag
+	}
+	if t, err := http.ParseTime(lastModified); err == nil {
+		doc.MetaData[MetaKeyLastModified] = t
+	}
 }
 
 func (l *Loader) GetType() string {
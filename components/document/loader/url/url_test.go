@@ -96,6 +96,9 @@ func TestLoad(t *testing.T) {
 
 		assert.Equal(t, 1, len(docs))
 		assert.Equal(t, "# Title\nhello world", docs[0].Content)
+		// custom parsers get the source and the validators of the response as well.
+		assert.Equal(t, url, docs[0].MetaData[MetaKeySource])
+		assert.IsType(t, time.Time{}, docs[0].MetaData[MetaKeyLastModified])
 	})
 
 	t.Run("custom request builder and custom client", func(t *testing.T) {
@@ -0,0 +1,302 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySource = "_source"
+	// MetaKeyLanguage is the language of the source code, e.g. "go", "python", "text" if unknown.
+	MetaKeyLanguage = "_language"
+	// MetaKeyLines is the number of lines of the source code.
+	MetaKeyLines = "_lines"
+)
+
+const languageText = "text"
+
+var _ parser.Parser = (*Parser)(nil)
+
+type Config struct {
+	// Language if set, is used for every document regardless of the uri.
+	Language string
+	// Extensions maps file extensions (with the leading dot, case-insensitive) to languages,
+	// overrides and extends the builtin mapping.
+	Extensions map[string]string
+	// WrapInCodeBlock if true, the content is wrapped in a Markdown fenced code block tagged with the language.
+	WrapInCodeBlock bool
+}
+
+var defaultExtensions = map[string]string{
+	".go":     "go",
+	".py":     "python",
+	".pyi":    "python",
+	".js":     "javascript",
+	".mjs":    "javascript",
+	".cjs":    "javascript",
+	".jsx":    "javascript",
+	".ts":     "typescript",
+	".tsx":    "typescript",
+	".java":   "java",
+	".kt":     "kotlin",
+	".kts":    "kotlin",
+	".scala":  "scala",
+	".c":      "c",
+	".h":      "c",
+	".cc":     "cpp",
+	".cpp":    "cpp",
+	".cxx":    "cpp",
+	".hpp":    "cpp",
+	".hh":     "cpp",
+	".cs":     "csharp",
+	".rs":     "rust",
+	".rb":     "ruby",
+	".php":    "php",
+	".swift":  "swift",
+	".m":      "objective-c",
+	".lua":    "lua",
+	".pl":     "perl",
+	".r":      "r",
+	".sh":     "bash",
+	".bash":   "bash",
+	".zsh":    "zsh",
+	".ps1":    "powershell",
+	".sql":    "sql",
+	".html":   "html",
+	".htm":    "html",
+	".css":    "css",
+	".scss":   "scss",
+	".json":   "json",
+	".yaml":   "yaml",
+	".yml":    "yaml",
+	".toml":   "toml",
+	".xml":    "xml",
+	".proto":  "protobuf",
+	".thrift": "thrift",
+	".md":     "markdown",
+	".dart":   "dart",
+	".ex":     "elixir",
+	".exs":    "elixir",
+	".erl":    "erlang",
+	".hs":     "haskell",
+	".clj":    "clojure",
+	".vue":    "vue",
+}
+
+// defaultFileNames maps well known file names without a meaningful extension to languages.
+var defaultFileNames = map[string]string{
+	"dockerfile":     "dockerfile",
+	"makefile":       "makefile",
+	"cmakelists.txt": "cmake",
+	"go.mod":         "go-mod",
+}
+
+// shebangInterpreters maps interpreters of a shebang line to languages.
+var shebangInterpreters = map[string]string{
+	"sh":      "bash",
+	"bash":    "bash",
+	"zsh":     "zsh",
+	"python":  "python",
+	"python2": "python",
+	"python3": "python",
+	"node":    "javascript",
+	"ruby":    "ruby",
+	"perl":    "perl",
+	"php":     "php",
+	"lua":     "lua",
+}
+
+// NewParser returns a new source code parser.
+func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+
+	extensions := make(map[string]string, len(defaultExtensions)+len(conf.Extensions))
+	for k, v := range defaultExtensions {
+		extensions[k] = v
+	}
+	for k, v := range conf.Extensions {
+		if !strings.HasPrefix(k, ".") {
+			k = "." + k
+		}
+		extensions[strings.ToLower(k)] = v
+	}
+
+	return &Parser{
+		conf:       conf,
+		extensions: extensions,
+	}, nil
+}
+
+// Parser implements parser.Parser. It reads the whole source file into one document tagged with its language.
+type Parser struct {
+	conf       *Config
+	extensions map[string]string
+}
+
+func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read source failed: %w", err)
+	}
+	source := string(data)
+
+	language := p.detect(option.URI, data)
+
+	content := source
+	if p.conf.WrapInCodeBlock {
+		content = wrap(source, language)
+	}
+
+	meta := make(map[string]any, len(option.ExtraMeta)+3)
+	meta[MetaKeyLanguage] = language
+	meta[MetaKeyLines] = countLines(source)
+	if option.URI != "" {
+		meta[MetaKeySource] = option.URI
+	}
+	for k, v := range option.ExtraMeta {
+		meta[k] = v
+	}
+
+	return []*schema.Document{
+		{
+			Content:  content,
+			MetaData: meta,
+		},
+	}, nil
+}
+
+// detect returns the language by config, then the file name, then the shebang line.
+func (p *Parser) detect(uri string, data []byte) string {
+	if p.conf.Language != "" {
+		return p.conf.Language
+	}
+
+	name := uri
+	if i := strings.IndexAny(name, "?#"); i >= 0 && strings.Contains(name, "://") {
+		name = name[:i]
+	}
+	name = path.Base(name)
+
+	if lang, ok := p.extensions[strings.ToLower(path.Ext(name))]; ok {
+		return lang
+	}
+	if lang, ok := defaultFileNames[strings.ToLower(name)]; ok {
+		return lang
+	}
+	if lang := shebang(data); lang != "" {
+		return lang
+	}
+
+	return languageText
+}
+
+// shebang returns the language of the interpreter of a "#!" line, e.g. "#!/usr/bin/env python3".
+func shebang(data []byte) string {
+	if !bytes.HasPrefix(data, []byte("#!")) {
+		return ""
+	}
+
+	line, _, _ := bufio.NewReader(bytes.NewReader(data[2:])).ReadLine()
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	interpreter := path.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, f := range fields[1:] {
+			if !strings.HasPrefix(f, "-") {
+				interpreter = f
+				break
+			}
+		}
+	}
+
+	if lang, ok := shebangInterpreters[interpreter]; ok {
+		return lang
+	}
+	// versioned interpreters, e.g. python3.11
+	if i := strings.IndexAny(interpreter, "0123456789."); i > 0 {
+		return shebangInterpreters[interpreter[:i]]
+	}
+
+	return ""
+}
+
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+
+	n := strings.Count(s, "\n")
+	if !strings.HasSuffix(s, "\n") {
+		n++
+	}
+
+	return n
+}
+
+// wrap encloses the source in a fence longer than any backtick run in it.
+func wrap(source, language string) string {
+	longest, run := 0, 0
+	for _, r := range source {
+		if r == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+			continue
+		}
+		run = 0
+	}
+
+	n := longest + 1
+	if n < 3 {
+		n = 3
+	}
+	fence := strings.Repeat("`", n)
+	if language == languageText {
+		language = ""
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fence)
+	sb.WriteString(language)
+	sb.WriteString("\n")
+	sb.WriteString(source)
+	if !strings.HasSuffix(source, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fence)
+
+	return sb.String()
+}
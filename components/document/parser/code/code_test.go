@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name     string
+		conf     *Config
+		uri      string
+		input    string
+		language string
+		lines    int
+	}{
+		{name: "extension", uri: "main.go", input: "package main\n\nfunc main() {}\n", language: "go", lines: 3},
+		{name: "url with query", uri: "https://example.com/a/b.PY?raw=1", input: "print(1)", language: "python", lines: 1},
+		{name: "file name", uri: "/repo/Dockerfile", input: "FROM scratch\n", language: "dockerfile", lines: 1},
+		{name: "shebang env", uri: "run", input: "#!/usr/bin/env -S python3.11 -u\nprint(1)\n", language: "python", lines: 2},
+		{name: "shebang path", input: "#!/bin/bash\necho hi\n", language: "bash", lines: 2},
+		{name: "unknown", uri: "notes", input: "", language: "text", lines: 0},
+		{name: "custom extension", conf: &Config{Extensions: map[string]string{"tpl": "gotemplate", ".go": "golang"}}, uri: "a.TPL", input: "x", language: "gotemplate", lines: 1},
+		{name: "override builtin", conf: &Config{Extensions: map[string]string{".go": "golang"}}, uri: "a.go", input: "x", language: "golang", lines: 1},
+		{name: "configured language", conf: &Config{Language: "sql"}, uri: "a.go", input: "select 1", language: "sql", lines: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p, err := NewParser(ctx, c.conf)
+			assert.NoError(t, err)
+
+			docs, err := p.Parse(ctx, strings.NewReader(c.input), parser.WithURI(c.uri),
+				parser.WithExtraMeta(map[string]any{"k": "v"}))
+			assert.NoError(t, err)
+			assert.Len(t, docs, 1)
+			assert.Equal(t, c.input, docs[0].Content)
+			assert.Equal(t, c.language, docs[0].MetaData[MetaKeyLanguage])
+			assert.Equal(t, c.lines, docs[0].MetaData[MetaKeyLines])
+			assert.Equal(t, "v", docs[0].MetaData["k"])
+			if c.uri != "" {
+				assert.Equal(t, c.uri, docs[0].MetaData[MetaKeySource])
+			}
+		})
+	}
+}
+
+func TestWrapInCodeBlock(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewParser(ctx, &Config{WrapInCodeBlock: true})
+	assert.NoError(t, err)
+
+	docs, err := p.Parse(ctx, strings.NewReader("x := 1"), parser.WithURI("a.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "```go\nx := 1\n```", docs[0].Content)
+
+	docs, err = p.Parse(ctx, strings.NewReader("see ```code```\n"), parser.WithURI("README"))
+	assert.NoError(t, err)
+	assert.Equal(t, "````\nsee ```code```\n````", docs[0].Content)
+}
@@ -0,0 +1,403 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package docx
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySource = "_source"
+	MetaKeyTitle  = "_title"
+	MetaKeyAuthor = "_author"
+)
+
+// TableFormat is how tables are rendered in the document content.
+type TableFormat string
+
+const (
+	TableFormatMarkdown TableFormat = "markdown"
+	TableFormatCSV      TableFormat = "csv"
+)
+
+const maxStyleDepth = 10
+
+var _ parser.Parser = (*Parser)(nil)
+
+type Config struct {
+	// TableFormat of the tables in the content, TableFormatMarkdown by default.
+	TableFormat TableFormat
+	// MaxSize is the max size in bytes of the file, larger files fail to parse.
+	// Default is 100MB.
+	MaxSize int64
+	// MaxPartSize is the max uncompressed size in bytes of a part of the zip package, it guards against zip bombs.
+	// Default is 100MB.
+	MaxPartSize int64
+}
+
+// NewParser returns a new docx parser.
+func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+
+	c := *conf
+	if c.TableFormat == "" {
+		c.TableFormat = TableFormatMarkdown
+	}
+	if c.TableFormat != TableFormatMarkdown && c.TableFormat != TableFormatCSV {
+		return nil, fmt.Errorf("unknown table format: %s", c.TableFormat)
+	}
+
+	return &Parser{
+		conf: &c,
+	}, nil
+}
+
+// Parser implements parser.Parser. It parses a Word (.docx) document to Markdown text.
+// Headings are rendered as Markdown headers by their paragraph style or outline level,
+// list items as "- " with indentation, and tables as Markdown or CSV.
+// Title and author in the document properties are extracted as meta data.
+//
+// Register it to parser.ExtParser with the ".docx" extension to use it with the file loader.
+type Parser struct {
+	conf *Config
+}
+
+func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	pkg, err := openPackage(reader, p.conf.MaxSize, p.conf.MaxPartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	styles, err := headingStyles(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := pkg.open("word/document.xml")
+	if err != nil {
+		return nil, fmt.Errorf("invalid docx: %w", err)
+	}
+	defer rc.Close()
+
+	blocks, err := p.parseDocument(rc, styles)
+	if err != nil {
+		return nil, fmt.Errorf("parse docx document failed: %w", err)
+	}
+
+	meta := map[string]any{}
+	title, author, err := pkg.coreProperties()
+	if err != nil {
+		return nil, err
+	}
+	if title != "" {
+		meta[MetaKeyTitle] = title
+	}
+	if author != "" {
+		meta[MetaKeyAuthor] = author
+	}
+	if option.URI != "" {
+		meta[MetaKeySource] = option.URI
+	}
+	for k, v := range option.ExtraMeta {
+		meta[k] = v
+	}
+
+	return []*schema.Document{
+		{
+			Content:  joinBlocks(blocks),
+			MetaData: meta,
+		},
+	}, nil
+}
+
+type docxBlock struct {
+	text string
+	list bool
+}
+
+type paragraphState struct {
+	style   string
+	outline int
+	list    bool
+	level   int
+	sb      strings.Builder
+}
+
+type tableState struct {
+	rows [][]string
+	row  []string
+	cell []string
+}
+
+// parseDocument walks word/document.xml and returns its blocks in order.
+func (p *Parser) parseDocument(r io.Reader, styles map[string]int) ([]*docxBlock, error) {
+	var (
+		dec    = xml.NewDecoder(r)
+		blocks []*docxBlock
+		// paragraphs and tables nest in text boxes and table cells.
+		paras  []*paragraphState
+		tables []*tableState
+		inRun  bool
+	)
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var para *paragraphState
+			if len(paras) > 0 {
+				para = paras[len(paras)-1]
+			}
+
+			switch t.Name.Local {
+			case "Fallback", "del", "instrText":
+				// alternate content duplicates the choice, deleted revisions and field codes are not text.
+				if err = dec.Skip(); err != nil {
+					return nil, err
+				}
+			case "tbl":
+				tables = append(tables, &tableState{})
+			case "tr":
+				if len(tables) > 0 {
+					tables[len(tables)-1].row = nil
+				}
+			case "tc":
+				if len(tables) > 0 {
+					tables[len(tables)-1].cell = nil
+				}
+			case "p":
+				paras = append(paras, &paragraphState{outline: -1})
+			case "r":
+				inRun = true
+			case "pStyle":
+				if para != nil {
+					para.style = attrValue(t, "val")
+				}
+			case "outlineLvl":
+				if para != nil {
+					if lvl, err := strconv.Atoi(attrValue(t, "val")); err == nil {
+						para.outline = lvl
+					}
+				}
+			case "numPr":
+				if para != nil {
+					para.list = true
+				}
+			case "ilvl":
+				if para != nil {
+					para.level, _ = strconv.Atoi(attrValue(t, "val"))
+				}
+			case "t":
+				var s string
+				if err = dec.DecodeElement(&s, &t); err != nil {
+					return nil, err
+				}
+				if para != nil {
+					para.sb.WriteString(s)
+				}
+			case "tab":
+				if para != nil && inRun {
+					para.sb.WriteString("\t")
+				}
+			case "br", "cr":
+				if para != nil && inRun {
+					para.sb.WriteString("\n")
+				}
+			}
+
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "r":
+				inRun = false
+			case "p":
+				if len(paras) == 0 {
+					continue
+				}
+				para := paras[len(paras)-1]
+				paras = paras[:len(paras)-1]
+
+				text := strings.TrimSpace(para.sb.String())
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					if text != "" {
+						tbl.cell = append(tbl.cell, text)
+					}
+					continue
+				}
+				if text != "" {
+					blocks = append(blocks, paragraphBlock(para, text, styles))
+				}
+			case "tc":
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					sep := " "
+					if p.conf.TableFormat == TableFormatCSV {
+						sep = "\n"
+					}
+					tbl.row = append(tbl.row, strings.Join(tbl.cell, sep))
+				}
+			case "tr":
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					tbl.rows = append(tbl.rows, tbl.row)
+				}
+			case "tbl":
+				if len(tables) == 0 {
+					continue
+				}
+				tbl := tables[len(tables)-1]
+				tables = tables[:len(tables)-1]
+				if len(tbl.rows) == 0 {
+					continue
+				}
+				if len(tables) > 0 {
+					// a nested table is flattened into the cell of the outer table.
+					parent := tables[len(tables)-1]
+					for _, row := range tbl.rows {
+						parent.cell = append(parent.cell, strings.Join(row, " "))
+					}
+					continue
+				}
+				blocks = append(blocks, &docxBlock{text: renderTable(tbl.rows, p.conf.TableFormat == TableFormatCSV)})
+			}
+		}
+	}
+
+	return blocks, nil
+}
+
+func paragraphBlock(para *paragraphState, text string, styles map[string]int) *docxBlock {
+	level := styles[para.style]
+	if level == 0 {
+		level = headingLevel(para.style)
+	}
+	if level == 0 && para.outline >= 0 && para.outline < 9 {
+		level = para.outline + 1
+	}
+	if level > 0 {
+		return &docxBlock{text: strings.Repeat("#", level) + " " + strings.Join(strings.Fields(text), " ")}
+	}
+
+	if para.list {
+		return &docxBlock{text: strings.Repeat("  ", para.level) + "- " + text, list: true}
+	}
+
+	return &docxBlock{text: text}
+}
+
+// joinBlocks separates blocks by blank lines, except consecutive list items.
+func joinBlocks(blocks []*docxBlock) string {
+	var sb strings.Builder
+	for i, b := range blocks {
+		if i > 0 {
+			if b.list && blocks[i-1].list {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(b.text)
+	}
+
+	return sb.String()
+}
+
+// headingStyles maps paragraph style ids in word/styles.xml to heading levels,
+// following basedOn so that custom styles derived from headings are headings as well.
+func headingStyles(pkg *ooxmlPackage) (map[string]int, error) {
+	type val struct {
+		Val string `xml:"val,attr"`
+	}
+	var doc struct {
+		Styles []struct {
+			Type       string `xml:"type,attr"`
+			ID         string `xml:"styleId,attr"`
+			Name       val    `xml:"name"`
+			BasedOn    val    `xml:"basedOn"`
+			OutlineLvl *val   `xml:"pPr>outlineLvl"`
+		} `xml:"style"`
+	}
+	if err := pkg.decode("word/styles.xml", &doc); err != nil {
+		return nil, err
+	}
+
+	direct := make(map[string]int, len(doc.Styles))
+	basedOn := make(map[string]string, len(doc.Styles))
+	for _, s := range doc.Styles {
+		if s.Type != "" && s.Type != "paragraph" {
+			continue
+		}
+		level := headingLevel(s.Name.Val)
+		if level == 0 && s.OutlineLvl != nil {
+			if lvl, err := strconv.Atoi(s.OutlineLvl.Val); err == nil && lvl < 9 {
+				level = lvl + 1
+			}
+		}
+		direct[s.ID] = level
+		basedOn[s.ID] = s.BasedOn.Val
+	}
+
+	ret := make(map[string]int, len(direct))
+	for id := range direct {
+		cur := id
+		for i := 0; i < maxStyleDepth && cur != ""; i++ {
+			if level := direct[cur]; level > 0 {
+				ret[id] = level
+				break
+			}
+			cur = basedOn[cur]
+		}
+	}
+
+	return ret, nil
+}
+
+// headingLevel parses style names such as "heading 2", "Heading2" and "Title".
+func headingLevel(name string) int {
+	n := strings.ToLower(strings.ReplaceAll(name, " ", ""))
+	if n == "title" {
+		return 1
+	}
+	if !strings.HasPrefix(n, "heading") {
+		return 0
+	}
+	level, err := strconv.Atoi(strings.TrimPrefix(n, "heading"))
+	if err != nil || level < 1 || level > 9 {
+		return 0
+	}
+
+	return level
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:style w:type="paragraph" w:styleId="Normal"><w:name w:val="Normal"/></w:style>
+  <w:style w:type="paragraph" w:styleId="1"><w:name w:val="heading 1"/></w:style>
+  <w:style w:type="paragraph" w:styleId="MyHeading"><w:name w:val="My Heading"/><w:basedOn w:val="Heading2Custom"/></w:style>
+  <w:style w:type="paragraph" w:styleId="Heading2Custom"><w:name w:val="Custom"/><w:pPr><w:outlineLvl w:val="1"/></w:pPr></w:style>
+</w:styles>`
+
+	testDocument = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+  xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006">
+<w:body>
+  <w:p><w:pPr><w:pStyle w:val="Title"/></w:pPr><w:r><w:t>Report</w:t></w:r></w:p>
+  <w:p><w:pPr><w:pStyle w:val="1"/><w:tabs><w:tab w:val="left" w:pos="720"/></w:tabs></w:pPr><w:r><w:t xml:space="preserve">Intro </w:t></w:r><w:r><w:t>part</w:t></w:r></w:p>
+  <w:p><w:r><w:t>First line</w:t><w:br/><w:t>second</w:t><w:tab/><w:t>tabbed</w:t></w:r>
+    <w:del><w:r><w:delText>removed</w:delText></w:r></w:del></w:p>
+  <w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>item one</w:t></w:r></w:p>
+  <w:p><w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>item two</w:t></w:r></w:p>
+  <w:p><w:pPr><w:pStyle w:val="MyHeading"/></w:pPr><w:r><w:t>Data</w:t></w:r></w:p>
+  <w:tbl>
+    <w:tr><w:tc><w:p><w:r><w:t>Name</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Value</w:t></w:r></w:p></w:tc></w:tr>
+    <w:tr><w:tc><w:p><w:r><w:t>a|b</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>1</w:t></w:r></w:p><w:p><w:r><w:t>2</w:t></w:r></w:p></w:tc></w:tr>
+  </w:tbl>
+  <w:p><w:r><mc:AlternateContent><mc:Choice><w:t>choice</w:t></mc:Choice><mc:Fallback><w:t>fallback</w:t></mc:Fallback></mc:AlternateContent></w:r></w:p>
+  <w:p><w:pPr><w:outlineLvl w:val="2"/></w:pPr><w:r><w:t>Outline heading</w:t></w:r></w:p>
+</w:body>
+</w:document>`
+
+	testCore = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties"
+  xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Annual Report</dc:title><dc:creator>Alice</dc:creator></cp:coreProperties>`
+)
+
+func buildPackage(t *testing.T, parts map[string]string) *bytes.Reader {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	parts := map[string]string{
+		"word/document.xml": testDocument,
+		"word/styles.xml":   testStyles,
+		"docProps/core.xml": testCore,
+	}
+
+	t.Run("markdown", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, buildPackage(t, parts), parser.WithURI("report.docx"),
+			parser.WithExtraMeta(map[string]any{"k": "v"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "# Report\n\n"+
+			"# Intro part\n\n"+
+			"First line\nsecond\ttabbed\n\n"+
+			"- item one\n  - item two\n\n"+
+			"## Data\n\n"+
+			"| Name | Value |\n| --- | --- |\n| a\\|b | 1 2 |\n\n"+
+			"choice\n\n"+
+			"### Outline heading", docs[0].Content)
+		assert.Equal(t, map[string]any{
+			MetaKeyTitle:  "Annual Report",
+			MetaKeyAuthor: "Alice",
+			MetaKeySource: "report.docx",
+			"k":           "v",
+		}, docs[0].MetaData)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{TableFormat: TableFormatCSV})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, buildPackage(t, parts))
+		assert.NoError(t, err)
+		assert.Contains(t, docs[0].Content, "Name,Value\na|b,\"1\n2\"\n\nchoice")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewParser(ctx, &Config{TableFormat: "xml"})
+		assert.Error(t, err)
+
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, bytes.NewReader([]byte("not a zip")))
+		assert.Error(t, err)
+
+		_, err = p.Parse(ctx, buildPackage(t, map[string]string{"word/styles.xml": testStyles}))
+		assert.Error(t, err)
+
+		// parts larger than MaxPartSize once uncompressed are rejected.
+		p, err = NewParser(ctx, &Config{MaxPartSize: int64(len(testDocument) - 1)})
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, buildPackage(t, map[string]string{"word/document.xml": testDocument}))
+		assert.ErrorContains(t, err, "part too large")
+	})
+}
+
+func TestHeadingLevel(t *testing.T) {
+	assert.Equal(t, 1, headingLevel("Title"))
+	assert.Equal(t, 2, headingLevel("heading 2"))
+	assert.Equal(t, 3, headingLevel("Heading3"))
+	assert.Equal(t, 0, headingLevel("Heading"))
+	assert.Equal(t, 0, headingLevel("Normal"))
+}
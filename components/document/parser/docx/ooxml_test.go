@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		assert.Nil(t, err)
+		_, err = w.Write([]byte(content))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestPackage(t *testing.T) {
+	data := zipFiles(t, map[string]string{
+		"xl/workbook.xml": `<workbook/>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships>
+  <Relationship Id="rId1" Type="worksheet" Target="worksheets/sheet1.xml"/>
+  <Relationship Id="rId2" Type="worksheet" Target="/xl/worksheets/sheet2.xml"/>
+  <Relationship Id="rId3" Type="hyperlink" Target="https://example.com" TargetMode="External"/>
+</Relationships>`,
+		"docProps/core.xml": `<cp:coreProperties xmlns:cp="cp" xmlns:dc="dc"><dc:title> Title </dc:title><dc:creator>Alice</dc:creator></cp:coreProperties>`,
+		"xl/big.xml":        strings.Repeat("a", 1024),
+	})
+
+	t.Run("parts", func(t *testing.T) {
+		pkg, err := openPackage(bytes.NewReader(data), 0, 0)
+		assert.Nil(t, err)
+		assert.True(t, pkg.has("xl/workbook.xml"))
+		assert.False(t, pkg.has("xl/styles.xml"))
+
+		title, creator, err := pkg.coreProperties()
+		assert.Nil(t, err)
+		assert.Equal(t, "Title", title)
+		assert.Equal(t, "Alice", creator)
+
+		_, err = pkg.open("xl/styles.xml")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		_, err := openPackage(bytes.NewReader(data), int64(len(data)-1), 0)
+		assert.True(t, errors.Is(err, ErrTooLarge))
+
+		pkg, err := openPackage(bytes.NewReader(data), int64(len(data)), 1023)
+		assert.Nil(t, err)
+		_, err = pkg.open("xl/big.xml")
+		assert.True(t, errors.Is(err, ErrPartTooLarge))
+
+		// the size in the zip header is not trusted.
+		r := &partReader{ReadCloser: io.NopCloser(strings.NewReader("abcd")), name: "x", remaining: 3}
+		_, err = io.ReadAll(r)
+		assert.True(t, errors.Is(err, ErrPartTooLarge))
+
+		r = &partReader{ReadCloser: io.NopCloser(strings.NewReader("abc")), name: "x", remaining: 3}
+		content, err := io.ReadAll(r)
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", string(content))
+	})
+}
+
+func TestRenderTable(t *testing.T) {
+	rows := [][]string{{"Name", "Value"}, {"a|b", "1\n2"}, {"c"}}
+	assert.Equal(t, "| Name | Value |\n| --- | --- |\n| a\\|b | 1 2 |\n| c |  |", renderTable(rows, false))
+	assert.Equal(t, "Name,Value\na|b,\"1\n2\"\nc,", renderTable(rows, true))
+	assert.Equal(t, "", renderTable(nil, false))
+}
+
+func TestAttrValue(t *testing.T) {
+	el := xml.StartElement{Attr: []xml.Attr{{Name: xml.Name{Space: "w", Local: "val"}, Value: "1"}}}
+	assert.Equal(t, "1", attrValue(el, "val"))
+	assert.Equal(t, "", attrValue(el, "lvl"))
+}
@@ -19,6 +19,7 @@ package html
 import (
 	"context"
 	"io"
+	"net/url"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -34,13 +35,36 @@ const (
 	MetaKeyLang    = "_language"
 	MetaKeyCharset = "_charset"
 	MetaKeySource  = "_source"
+	// MetaKeyCanonical is the absolute url of <link rel="canonical">.
+	MetaKeyCanonical = "_canonical"
+	// MetaKeyOpenGraph is the OpenGraph properties as map[string]string, keyed without the "og:" prefix, e.g. "title", "image".
+	MetaKeyOpenGraph = "_open_graph"
+	// MetaKeyLinks is the links in the content as []*Link, only set when Config.ExtractLinks is true.
+	MetaKeyLinks = "_links"
 )
 
+// Link is a hyperlink in the content, URL is resolved against the base url of the page if any.
+type Link struct {
+	Text string
+	URL  string
+}
+
 var _ parser.Parser = (*Parser)(nil)
 
 type Config struct {
 	// content selector of goquery. eg: body for <body>, #id for <div id="id">
 	Selector *string
+
+	// Readability if true, strips boilerplate such as navigation, footers, sidebars and ads,
+	// and keeps only the main content, found by scoring text density in the style of readability.
+	// It is applied within Selector if both are set.
+	Readability bool
+	// ToMarkdown if true, the content is converted to Markdown, keeping headings, lists, tables, links and code blocks.
+	ToMarkdown bool
+	// ExtractLinks if true, the http(s) links in the content are stored in MetaKeyLinks.
+	ExtractLinks bool
+	// DetectLanguage if true, guesses the language from the text when the page does not declare it.
+	DetectLanguage bool
 }
 
 var (
@@ -59,8 +83,8 @@ func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
 }
 
 // Parser implements parser.Parser. It parses HTML content to text.
-// use goquery to parse the HTML content, will read the <body> content as text (remove tags).
-// will extract title/description/language/charset from the HTML content as meta data.
+// use goquery to parse the HTML content, will read the <body> content as text (remove tags), or as Markdown if Config.ToMarkdown.
+// will extract title/description/language/charset/canonical/OpenGraph from the HTML content as meta data.
 type Parser struct {
 	conf *Config
 }
@@ -72,20 +96,47 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 	}
 
 	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+	base := baseURL(doc, option.URI)
+
+	meta, err := p.getMetaData(ctx, doc, base)
+	if err != nil {
+		return nil, err
+	}
+	meta[MetaKeySource] = option.URI
 
 	var contentSel *goquery.Selection
 
-	if p.conf.Selector != nil {
+	switch {
+	case p.conf.Readability:
+		root := doc.Selection
+		if p.conf.Selector != nil {
+			root = doc.Find(*p.conf.Selector)
+		}
+		contentSel = doc.FindNodes(extractMainContent(root)...)
+	case p.conf.Selector != nil:
 		contentSel = doc.Find(*p.conf.Selector).Contents()
-	} else {
+	default:
 		contentSel = doc.Contents()
 	}
 
-	meta, err := p.getMetaData(ctx, doc)
-	if err != nil {
-		return nil, err
+	var content string
+	if p.conf.ToMarkdown {
+		content = (&converter{base: base}).convert(contentSel.Nodes)
+	} else {
+		sanitized := bluemonday.UGCPolicy().Sanitize(contentSel.Text())
+		content = strings.TrimSpace(sanitized)
+	}
+
+	if p.conf.ExtractLinks {
+		if links := extractLinks(contentSel, base); len(links) > 0 {
+			meta[MetaKeyLinks] = links
+		}
+	}
+	if _, ok := meta[MetaKeyLang]; !ok && p.conf.DetectLanguage {
+		if language := detectLanguage(contentSel.Text()); language != "" {
+			meta[MetaKeyLang] = language
+		}
 	}
-	meta[MetaKeySource] = option.URI
 
 	if option.ExtraMeta != nil {
 		for k, v := range option.ExtraMeta {
@@ -93,9 +144,6 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 		}
 	}
 
-	sanitized := bluemonday.UGCPolicy().Sanitize(contentSel.Text())
-	content := strings.TrimSpace(sanitized)
-
 	document := &schema.Document{
 		Content:  content,
 		MetaData: meta,
@@ -106,7 +154,7 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 	}, nil
 }
 
-func (p *Parser) getMetaData(ctx context.Context, doc *goquery.Document) (map[string]any, error) {
+func (p *Parser) getMetaData(ctx context.Context, doc *goquery.Document, base *url.URL) (map[string]any, error) {
 	meta := map[string]any{}
 
 	title := doc.Find("title")
@@ -130,6 +178,18 @@ func (p *Parser) getMetaData(ctx context.Context, doc *goquery.Document) (map[st
 		}
 	}
 
+	if _, ok := meta[MetaKeyLang]; !ok {
+		doc.Find("meta[http-equiv]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+			if !strings.EqualFold(s.AttrOr("http-equiv", ""), "content-language") {
+				return true
+			}
+			if language := strings.TrimSpace(strings.Split(s.AttrOr("content", ""), ",")[0]); language != "" {
+				meta[MetaKeyLang] = language
+			}
+			return false
+		})
+	}
+
 	charset := doc.Find("meta[charset]")
 	if charset != nil {
 		if c := charset.AttrOr("charset", ""); c != "" {
@@ -137,5 +197,83 @@ func (p *Parser) getMetaData(ctx context.Context, doc *goquery.Document) (map[st
 		}
 	}
 
+	doc.Find("link[rel][href]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		for _, rel := range strings.Fields(s.AttrOr("rel", "")) {
+			if strings.EqualFold(rel, "canonical") {
+				if canonical := resolveURL(base, s.AttrOr("href", ""), "http", "https"); canonical != "" {
+					meta[MetaKeyCanonical] = canonical
+				}
+				return false
+			}
+		}
+		return true
+	})
+
+	openGraph := map[string]string{}
+	doc.Find("meta[content]").Each(func(_ int, s *goquery.Selection) {
+		property := s.AttrOr("property", s.AttrOr("name", ""))
+		if !strings.HasPrefix(strings.ToLower(property), "og:") {
+			return
+		}
+		key := strings.ToLower(property[len("og:"):])
+		if _, ok := openGraph[key]; !ok {
+			openGraph[key] = strings.TrimSpace(s.AttrOr("content", ""))
+		}
+	})
+	if len(openGraph) > 0 {
+		meta[MetaKeyOpenGraph] = openGraph
+		if _, ok := meta[MetaKeyTitle]; !ok && openGraph["title"] != "" {
+			meta[MetaKeyTitle] = openGraph["title"]
+		}
+		if _, ok := meta[MetaKeyDesc]; !ok && openGraph["description"] != "" {
+			meta[MetaKeyDesc] = openGraph["description"]
+		}
+	}
+
 	return meta, nil
 }
+
+// baseURL returns the url to resolve relative links against, it is the uri of the page, overridden by <base href>.
+// nil if there is no absolute url.
+func baseURL(doc *goquery.Document, uri string) *url.URL {
+	base, err := url.Parse(uri)
+	if err != nil || uri == "" {
+		base = nil
+	}
+
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if u, err := url.Parse(strings.TrimSpace(href)); err == nil {
+			if base != nil {
+				u = base.ResolveReference(u)
+			}
+			base = u
+		}
+	}
+
+	if base == nil || !base.IsAbs() {
+		return nil
+	}
+
+	return base
+}
+
+// extractLinks returns the distinct http(s) links in the selection with their text.
+func extractLinks(sel *goquery.Selection, base *url.URL) []*Link {
+	var (
+		links []*Link
+		seen  = map[string]bool{}
+	)
+	sel.Find("a[href]").AddSelection(sel.Filter("a[href]")).Each(func(_ int, s *goquery.Selection) {
+		u := resolveURL(base, s.AttrOr("href", ""), "http", "https")
+		if u == "" || seen[u] {
+			return
+		}
+		seen[u] = true
+		links = append(links, &Link{
+			Text: normalizeSpace(s.Text()),
+			URL:  u,
+		})
+	})
+
+	return links
+}
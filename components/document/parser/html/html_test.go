@@ -18,11 +18,14 @@ package html
 
 import (
 	"context"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
 )
 
 func TestHTMLParser(t *testing.T) {
@@ -84,3 +87,156 @@ func TestHTMLParser(t *testing.T) {
 	})
 
 }
+
+func TestHTMLParserReadability(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("main content as markdown", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{
+			Readability:    true,
+			ToMarkdown:     true,
+			ExtractLinks:   true,
+			DetectLanguage: true,
+		})
+		assert.NoError(t, err)
+
+		f, err := os.Open("testdata/article.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("https://example.com/blog/gc-tuning?utm=x"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "# Tuning the Garbage Collector\n\n"+
+			"The garbage collector trades memory for CPU time, and understanding that trade-off is the key to tuning it well in production services.\n\n"+
+			"## Knobs\n\n"+
+			"There are two settings worth knowing, both can be set through the environment or at runtime with the `debug` package.\n\n"+
+			"- **GOGC** sets the heap growth ratio.\n"+
+			"- **GOMEMLIMIT** sets a soft memory limit.\n"+
+			"  1. Set it below the container limit.\n"+
+			"  2. Leave some headroom.\n\n"+
+			"```go\ndebug.SetGCPercent(200)\ndebug.SetMemoryLimit(1 << 30)\n```\n\n"+
+			"| GOGC | Heap |\n| --- | --- |\n| 100 | 2x live heap |\n| 200 | 3x live heap |\n\n"+
+			"Read the [official guide](https://example.com/blog/guide) for the details, "+
+			"or see [the GC guide](https://go.dev/doc/gc-guide#top) and share.", docs[0].Content)
+
+		meta := docs[0].MetaData
+		assert.Equal(t, "Tuning the Garbage Collector", meta[MetaKeyTitle])
+		assert.Equal(t, "https://example.com/blog/gc-tuning", meta[MetaKeyCanonical])
+		assert.Equal(t, map[string]string{
+			"title": "Tuning the GC",
+			"type":  "article",
+			"image": "https://example.com/gc.png",
+		}, meta[MetaKeyOpenGraph])
+		assert.Equal(t, []*Link{
+			{Text: "official guide", URL: "https://example.com/blog/guide"},
+			{Text: "the GC guide", URL: "https://go.dev/doc/gc-guide#top"},
+		}, meta[MetaKeyLinks])
+		assert.Equal(t, "en", meta[MetaKeyLang])
+	})
+
+	t.Run("main content as text", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{Readability: true, Selector: &BodySelector})
+		assert.NoError(t, err)
+
+		f, err := os.Open("testdata/article.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Contains(t, docs[0].Content, "The garbage collector trades memory")
+		for _, boilerplate := range []string{"Archive", "Home", "newsletter", "Buy now", "Copyright"} {
+			assert.NotContains(t, docs[0].Content, boilerplate)
+		}
+		_, ok := docs[0].MetaData[MetaKeyLinks]
+		assert.False(t, ok)
+	})
+
+	t.Run("whole page as markdown", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{ToMarkdown: true})
+		assert.NoError(t, err)
+
+		f, err := os.Open("testdata/normal.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world!\n\ncontent in xid", docs[0].Content)
+		assert.Equal(t, "this is a test html", docs[0].MetaData[MetaKeyDesc])
+	})
+
+	t.Run("detect language", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{DetectLanguage: true})
+		assert.NoError(t, err)
+
+		cases := map[string]string{
+			"<p>垃圾回收器用内存换取处理器时间，理解这一点是调优的关键。</p>":                                                   "zh",
+			"<p>ガベージコレクタはメモリとCPU時間を交換します。</p>":                                                      "ja",
+			"<p>Der Speicher wird von dem Sammler nicht sofort freigegeben, und das ist gut.</p>":   "de",
+			"<p>Le ramasse-miettes échange la mémoire contre le temps de calcul et les pauses.</p>": "fr",
+			"<p>12345</p>": "",
+		}
+		for input, expected := range cases {
+			docs, err := p.Parse(ctx, strings.NewReader(input))
+			assert.NoError(t, err)
+			language, _ := docs[0].MetaData[MetaKeyLang].(string)
+			assert.Equal(t, expected, language, input)
+		}
+
+		docs, err := p.Parse(ctx, strings.NewReader(`<html><head><meta http-equiv="Content-Language" content="pt-BR"></head><body>hello and the world</body></html>`))
+		assert.NoError(t, err)
+		assert.Equal(t, "pt-BR", docs[0].MetaData[MetaKeyLang])
+	})
+}
+
+func TestConvertMarkdown(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewParser(ctx, &Config{ToMarkdown: true, Selector: &BodySelector})
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "blockquote and line breaks",
+			input:    "<blockquote><p>first<br>second</p><p>third</p></blockquote>",
+			expected: "> first\n> second\n>\n> third",
+		},
+		{
+			name:     "inline formatting",
+			input:    "<p>a <em> b </em>c <code>x `y`</code> <del>d</del> <img src=\"/i.png\" alt=\"pic\"></p>",
+			expected: "a *b* c `` x `y` `` ~~d~~ ![pic](/i.png)",
+		},
+		{
+			name:     "layout table",
+			input:    "<table><tr><td><p>only</p><p>column</p></td></tr></table>",
+			expected: "only\n\ncolumn",
+		},
+		{
+			name:     "ordered list start and hr",
+			input:    "<ol start=\"3\"><li>c</li><li>d</li></ol><hr><pre>a\n  b</pre>",
+			expected: "3. c\n4. d\n\n---\n\n```\na\n  b\n```",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			docs, err := p.Parse(ctx, strings.NewReader("<html><body>"+c.input+"</body></html>"))
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, docs[0].Content)
+		})
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<ul><li><a href="/a">a</a></li><li>b</li></ul>`))
+	assert.NoError(t, err)
+
+	base, _ := url.Parse("https://example.com/docs/")
+	assert.Equal(t, "- [a](https://example.com/a)\n- b", RenderMarkdown([]*html.Node{doc}, base))
+	assert.Equal(t, "- [a](/a)\n- b", RenderMarkdown([]*html.Node{doc}, nil))
+}
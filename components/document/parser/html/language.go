@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxDetectRunes limits the text examined by detectLanguage.
+	maxDetectRunes = 10000
+	// minStopWordHits is the minimal number of stop words to tell a Latin language.
+	minStopWordHits = 2
+)
+
+// stopWords are frequent words of languages written in Latin script.
+var stopWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "with", "for", "was", "this", "are", "be", "on", "not", "have", "you"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "dans", "que", "qui", "pour", "pas", "sur", "du", "au", "avec", "ce", "sont"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "sich", "auf", "für", "dem", "auch", "wird", "von"},
+	"es": {"el", "los", "las", "y", "es", "una", "del", "que", "por", "para", "con", "se", "como", "pero", "más", "está", "su"},
+	"pt": {"o", "os", "as", "e", "é", "um", "uma", "do", "da", "dos", "das", "não", "com", "para", "que", "em", "por", "mais"},
+	"it": {"il", "lo", "gli", "e", "è", "una", "di", "che", "per", "non", "con", "del", "della", "sono", "nel", "anche", "ma"},
+	"nl": {"de", "het", "een", "en", "van", "is", "dat", "niet", "op", "te", "met", "zijn", "voor", "ook", "wordt", "aan"},
+}
+
+var stopWordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopWords {
+		for _, w := range words {
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}()
+
+var scripts = []struct {
+	lang  string
+	table *unicode.RangeTable
+}{
+	{"ko", unicode.Hangul},
+	{"ru", unicode.Cyrillic},
+	{"ar", unicode.Arabic},
+	{"he", unicode.Hebrew},
+	{"el", unicode.Greek},
+	{"th", unicode.Thai},
+	{"hi", unicode.Devanagari},
+}
+
+// detectLanguage guesses the language of text by its script, and by stop words for Latin scripts.
+// It returns an ISO 639-1 code, or empty string if undetermined.
+func detectLanguage(text string) string {
+	var (
+		letters, latin, han, kana int
+		counts                    = make(map[string]int)
+		n                         int
+	)
+	for _, r := range text {
+		if n++; n > maxDetectRunes {
+			break
+		}
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		default:
+			for _, s := range scripts {
+				if unicode.Is(s.table, r) {
+					counts[s.lang]++
+					break
+				}
+			}
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	// Japanese mixes kana with kanji, a noticeable share of kana tells it from Chinese.
+	if cjk := han + kana; cjk*2 >= letters {
+		if kana*10 >= cjk {
+			return "ja"
+		}
+		return "zh"
+	}
+	for _, s := range scripts {
+		if counts[s.lang]*2 >= letters {
+			return s.lang
+		}
+	}
+	if latin*2 >= letters {
+		return latinLanguage(text)
+	}
+
+	return ""
+}
+
+func latinLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	hits := make(map[string]int)
+	for i, w := range words {
+		if i >= maxDetectRunes/5 {
+			break
+		}
+		for _, lang := range stopWordLanguages[w] {
+			hits[lang]++
+		}
+	}
+
+	best, bestHits := "", 0
+	for _, lang := range []string{"en", "fr", "de", "es", "pt", "it", "nl"} {
+		if hits[lang] > bestHits {
+			best, bestHits = lang, hits[lang]
+		}
+	}
+	if bestHits < minStopWordHits {
+		return ""
+	}
+
+	return best
+}
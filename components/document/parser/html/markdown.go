@@ -0,0 +1,479 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockTags are the elements rendered as separate Markdown blocks.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "body": true, "caption": true,
+	"dd": true, "details": true, "dialog": true, "div": true, "dl": true, "dt": true, "fieldset": true,
+	"figcaption": true, "figure": true, "footer": true, "form": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "header": true, "hgroup": true, "hr": true, "html": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true, "section": true, "summary": true,
+	"table": true, "tbody": true, "td": true, "tfoot": true, "th": true, "thead": true, "tr": true, "ul": true,
+}
+
+// skipTags are the elements that have no readable content.
+var skipTags = map[string]bool{
+	"head": true, "title": true, "meta": true, "link": true, "script": true, "style": true, "noscript": true,
+	"template": true, "iframe": true, "object": true, "embed": true, "svg": true, "canvas": true,
+	"button": true, "input": true, "select": true, "textarea": true,
+}
+
+// RenderMarkdown renders HTML nodes as Markdown, the same way as the parser does with Config.ToMarkdown.
+// Links and images are resolved against base if it is not nil.
+func RenderMarkdown(nodes []*html.Node, base *url.URL) string {
+	return (&converter{base: base}).convert(nodes)
+}
+
+// converter renders HTML nodes as Markdown, links and images are resolved against base.
+type converter struct {
+	base *url.URL
+}
+
+func (c *converter) convert(nodes []*html.Node) string {
+	return strings.Join(c.blocks(nodes), "\n\n")
+}
+
+// blocks renders nodes as Markdown blocks, consecutive inline nodes form a paragraph.
+func (c *converter) blocks(nodes []*html.Node) []string {
+	var (
+		blocks []string
+		inline strings.Builder
+	)
+	flush := func() {
+		if p := cleanInline(inline.String()); p != "" {
+			blocks = append(blocks, p)
+		}
+		inline.Reset()
+	}
+
+	for _, n := range nodes {
+		switch {
+		case n.Type == html.DocumentNode:
+			flush()
+			blocks = append(blocks, c.blocks(children(n))...)
+		case n.Type == html.ElementNode && skipTags[n.Data]:
+		case n.Type == html.ElementNode && blockTags[n.Data]:
+			flush()
+			blocks = append(blocks, c.block(n)...)
+		default:
+			inline.WriteString(c.inline(n))
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+func (c *converter) block(n *html.Node) []string {
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := cleanInline(strings.ReplaceAll(c.inlineChildren(n), "\n", " "))
+		if text == "" {
+			return nil
+		}
+		return []string{strings.Repeat("#", int(n.Data[1]-'0')) + " " + text}
+	case "ul", "ol":
+		if list := c.list(n); list != "" {
+			return []string{list}
+		}
+		return nil
+	case "pre":
+		return []string{c.code(n)}
+	case "blockquote":
+		inner := c.blocks(children(n))
+		if len(inner) == 0 {
+			return nil
+		}
+		lines := strings.Split(strings.Join(inner, "\n\n"), "\n")
+		for i, line := range lines {
+			if line == "" {
+				lines[i] = ">"
+			} else {
+				lines[i] = "> " + line
+			}
+		}
+		return []string{strings.Join(lines, "\n")}
+	case "table":
+		return c.table(n)
+	case "hr":
+		return []string{"---"}
+	default:
+		return c.blocks(children(n))
+	}
+}
+
+func (c *converter) list(n *html.Node) string {
+	ordered := n.Data == "ol"
+	index := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+		index = start
+	}
+
+	var lines []string
+	for _, item := range children(n) {
+		if item.Type != html.ElementNode {
+			continue
+		}
+
+		var body string
+		if item.Data == "li" {
+			body = strings.Join(c.blocks(children(item)), "\n")
+		} else {
+			// lists nested directly in a list, which is invalid but common.
+			body = strings.Join(c.block(item), "\n")
+			for _, line := range strings.Split(body, "\n") {
+				lines = append(lines, "  "+line)
+			}
+			continue
+		}
+		if body == "" {
+			continue
+		}
+
+		marker := "- "
+		if ordered {
+			marker = strconv.Itoa(index) + ". "
+			index++
+		}
+		indent := strings.Repeat(" ", len(marker))
+		for i, line := range strings.Split(body, "\n") {
+			switch {
+			case i == 0:
+				lines = append(lines, marker+line)
+			case line == "":
+				lines = append(lines, "")
+			default:
+				lines = append(lines, indent+line)
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *converter) code(n *html.Node) string {
+	language := codeLanguage(n)
+	if language == "" {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && child.Data == "code" {
+				language = codeLanguage(child)
+				break
+			}
+		}
+	}
+
+	text := strings.TrimRight(textContent(n), "\n ")
+	fence := fenceFor(text, "```")
+
+	return fence + language + "\n" + text + "\n" + fence
+}
+
+// codeLanguage returns the language in class names like "language-go" or "lang-go".
+func codeLanguage(n *html.Node) string {
+	for _, class := range strings.Fields(attr(n, "class")) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if strings.HasPrefix(class, prefix) {
+				return strings.TrimPrefix(class, prefix)
+			}
+		}
+	}
+
+	return ""
+}
+
+// table renders a Markdown table, layout tables with a single column or nested tables are rendered as blocks.
+func (c *converter) table(n *html.Node) []string {
+	var (
+		caption string
+		rows    [][]string
+		columns int
+		nested  bool
+	)
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for _, child := range children(n) {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.Data {
+			case "caption":
+				caption = cleanInline(strings.ReplaceAll(c.inlineChildren(child), "\n", " "))
+			case "thead", "tbody", "tfoot":
+				walk(child)
+			case "tr":
+				var row []string
+				for _, cell := range children(child) {
+					if cell.Type != html.ElementNode || (cell.Data != "td" && cell.Data != "th") {
+						continue
+					}
+					if hasDescendant(cell, "table") {
+						nested = true
+					}
+					text := cleanInline(strings.ReplaceAll(c.inlineChildren(cell), "\n", " "))
+					row = append(row, strings.ReplaceAll(text, "|", `\|`))
+					if span, err := strconv.Atoi(attr(cell, "colspan")); err == nil {
+						for i := 1; i < span && i < 64; i++ {
+							row = append(row, "")
+						}
+					}
+				}
+				if len(row) > columns {
+					columns = len(row)
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	walk(n)
+
+	if nested || columns < 2 {
+		return c.blocks(children(n))
+	}
+
+	var lines []string
+	if caption != "" {
+		lines = append(lines, caption, "")
+	}
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+
+	return []string{strings.Join(lines, "\n")}
+}
+
+func (c *converter) inlineChildren(n *html.Node) string {
+	sb := strings.Builder{}
+	for _, child := range children(n) {
+		sb.WriteString(c.inline(child))
+	}
+
+	return sb.String()
+}
+
+func (c *converter) inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return collapseSpace(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	if skipTags[n.Data] {
+		return ""
+	}
+
+	switch n.Data {
+	case "br":
+		return "\n"
+	case "a":
+		text := c.inlineChildren(n)
+		href := c.resolve(attr(n, "href"))
+		if href == "" {
+			return text
+		}
+		return wrapInline(strings.ReplaceAll(text, "\n", " "), "[", "]("+href+")")
+	case "img":
+		src := c.resolve(attr(n, "src"))
+		if src == "" {
+			return ""
+		}
+		return "![" + normalizeSpace(attr(n, "alt")) + "](" + src + ")"
+	case "strong", "b":
+		return wrapInline(c.inlineChildren(n), "**", "**")
+	case "em", "i":
+		return wrapInline(c.inlineChildren(n), "*", "*")
+	case "del", "s", "strike":
+		return wrapInline(c.inlineChildren(n), "~~", "~~")
+	case "code", "kbd", "samp", "tt":
+		text := collapseSpace(textContent(n))
+		if strings.TrimSpace(text) == "" {
+			return text
+		}
+		fence := fenceFor(text, "`")
+		if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+			return fence + " " + text + " " + fence
+		}
+		return fence + text + fence
+	}
+
+	if blockTags[n.Data] {
+		return " " + c.inlineChildren(n) + " "
+	}
+
+	return c.inlineChildren(n)
+}
+
+// resolve returns the absolute url of href, or empty string for fragments and scripts.
+func (c *converter) resolve(href string) string {
+	return resolveURL(c.base, href, "http", "https", "mailto", "ftp")
+}
+
+// resolveURL resolves href against base and returns it if its scheme is allowed or it stays relative.
+func resolveURL(base *url.URL, href string, schemes ...string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return ""
+	}
+
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme == "" {
+		return u.String()
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return u.String()
+		}
+	}
+
+	return ""
+}
+
+func children(n *html.Node) []*html.Node {
+	var nodes []*html.Node
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		nodes = append(nodes, child)
+	}
+
+	return nodes
+}
+
+func hasDescendant(n *html.Node, tag string) bool {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && (child.Data == tag || hasDescendant(child, tag)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// textContent returns the raw text of n, line breaks are kept.
+func textContent(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return n.Data
+	case html.ElementNode:
+		if n.Data == "br" {
+			return "\n"
+		}
+	}
+
+	sb := strings.Builder{}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		sb.WriteString(textContent(child))
+	}
+
+	return sb.String()
+}
+
+// collapseSpace replaces every run of white spaces with a single space.
+func collapseSpace(s string) string {
+	sb := strings.Builder{}
+	space := false
+	for _, r := range s {
+		switch r {
+		case ' ', '\t', '\n', '\r', '\f':
+			if !space {
+				sb.WriteByte(' ')
+			}
+			space = true
+		default:
+			sb.WriteRune(r)
+			space = false
+		}
+	}
+
+	return sb.String()
+}
+
+// cleanInline trims the lines of a paragraph and drops repeated empty lines.
+func cleanInline(s string) string {
+	lines := strings.Split(s, "\n")
+	ret := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" && (len(ret) == 0 || ret[len(ret)-1] == "") {
+			continue
+		}
+		ret = append(ret, line)
+	}
+
+	return strings.TrimSpace(strings.Join(ret, "\n"))
+}
+
+// wrapInline encloses the trimmed text with open and close, the surrounding spaces are kept outside.
+func wrapInline(text, open, close string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+
+	var lead, trail string
+	if trimmed[0] != text[0] {
+		lead = " "
+	}
+	if trimmed[len(trimmed)-1] != text[len(text)-1] {
+		trail = " "
+	}
+
+	return lead + open + trimmed + close + trail
+}
+
+// fenceFor returns a run of the fence character longer than any run of it in text.
+func fenceFor(text, fence string) string {
+	ch := fence[0]
+	longest, run := 0, 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != ch {
+			run = 0
+			continue
+		}
+		run++
+		if run > longest {
+			longest = run
+		}
+	}
+
+	if longest >= len(fence) {
+		return strings.Repeat(string(ch), longest+1)
+	}
+
+	return fence
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// boilerplateSelector matches elements that never belong to the main content.
+const boilerplateSelector = "script, style, noscript, template, iframe, object, embed, svg, canvas, " +
+	"form, button, input, select, textarea, nav, footer, aside, dialog, " +
+	"[role=navigation], [role=banner], [role=contentinfo], [role=complementary], [role=dialog], " +
+	"[aria-hidden=true], [hidden]"
+
+var (
+	unlikelyCandidates = regexp.MustCompile(`(?i)-ad-|\bads?\b|advert|banner|breadcrumb|combx|comment|community|cookie|` +
+		`disqus|footer|gdpr|header|legends|menu|modal|\bnav|newsletter|pager|pagination|popup|promo|related|remark|` +
+		`replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|toolbar|widget`)
+	maybeCandidates = regexp.MustCompile(`(?i)article|body|column|content|main|post|entry|story|text`)
+	positiveWeight  = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativeWeight  = regexp.MustCompile(`(?i)-ad-|\bhid\b|hidden|banner|combx|comment|com-|contact|foot|footnote|gdpr|` +
+		`masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+)
+
+const (
+	// minParagraphLength is the minimal number of characters for a paragraph to contribute to the score.
+	minParagraphLength = 25
+	// scoreLevels is how many ancestors a paragraph contributes its score to.
+	scoreLevels = 3
+	// minSiblingScore is the minimal score of a sibling of the top candidate to be kept.
+	minSiblingScore = 10
+)
+
+// paragraphBlockTags are the tags that make a div a container rather than a paragraph.
+var paragraphBlockTags = map[string]bool{
+	"blockquote": true, "dl": true, "div": true, "img": true, "ol": true,
+	"p": true, "pre": true, "table": true, "ul": true, "section": true, "article": true,
+}
+
+// extractMainContent removes the boilerplate under root and returns the nodes of the main content,
+// it scores the blocks by their text length, comma count, class names and link density in the style of readability.
+// root is returned as is if no block has enough text.
+func extractMainContent(root *goquery.Selection) []*html.Node {
+	removeBoilerplate(root)
+
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+	root.Find("p, pre, td, blockquote, div").Each(func(_ int, s *goquery.Selection) {
+		n := s.Get(0)
+		if n.Data == "div" && hasBlockChild(n) {
+			return
+		}
+
+		text := normalizeSpace(s.Text())
+		length := utf8.RuneCountInString(text)
+		if length < minParagraphLength {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，"))
+		if bonus := float64(length) / 100; bonus < 3 {
+			score += bonus
+		} else {
+			score += 3
+		}
+
+		ancestor := n.Parent
+		for level := 0; level < scoreLevels && ancestor != nil && ancestor.Type == html.ElementNode; level++ {
+			if _, ok := scores[ancestor]; !ok {
+				scores[ancestor] = initialScore(ancestor)
+				candidates = append(candidates, ancestor)
+			}
+
+			switch level {
+			case 0:
+				scores[ancestor] += score
+			case 1:
+				scores[ancestor] += score / 2
+			default:
+				scores[ancestor] += score / float64(level*3)
+			}
+			ancestor = ancestor.Parent
+		}
+	})
+
+	var (
+		top      *html.Node
+		topScore float64
+	)
+	for _, n := range candidates {
+		scores[n] *= 1 - linkDensity(n)
+		if top == nil || scores[n] > topScore {
+			top, topScore = n, scores[n]
+		}
+	}
+	if top == nil {
+		return root.Nodes
+	}
+	if top.Parent == nil || top.Data == "body" || top.Data == "html" {
+		return []*html.Node{top}
+	}
+
+	threshold := topScore * 0.2
+	if threshold < minSiblingScore {
+		threshold = minSiblingScore
+	}
+	topClass := attr(top, "class")
+
+	var nodes []*html.Node
+	for sibling := top.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+		if sibling.Type != html.ElementNode {
+			continue
+		}
+		if sibling == top {
+			nodes = append(nodes, sibling)
+			continue
+		}
+
+		bonus := 0.0
+		if topClass != "" && attr(sibling, "class") == topClass {
+			bonus = topScore * 0.2
+		}
+		if score, ok := scores[sibling]; ok && score+bonus >= threshold {
+			nodes = append(nodes, sibling)
+			continue
+		}
+
+		if sibling.Data == "p" {
+			text := normalizeSpace(goquery.NewDocumentFromNode(sibling).Text())
+			length := utf8.RuneCountInString(text)
+			density := linkDensity(sibling)
+			if length > 80 && density < 0.25 || length > 0 && density == 0 && strings.Contains(text, ". ") {
+				nodes = append(nodes, sibling)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// removeBoilerplate removes the navigation, footers, sidebars, ads and other unlikely content under root.
+func removeBoilerplate(root *goquery.Selection) {
+	root.Find(boilerplateSelector).Remove()
+	root.Find("header").Each(func(_ int, s *goquery.Selection) {
+		if s.Closest("article, main").Length() == 0 {
+			s.Remove()
+		}
+	})
+
+	root.Find("*").Each(func(_ int, s *goquery.Selection) {
+		n := s.Get(0)
+		switch n.Data {
+		case "html", "body", "article", "main", "a":
+			return
+		}
+
+		match := attr(n, "class") + " " + attr(n, "id")
+		if unlikelyCandidates.MatchString(match) && !maybeCandidates.MatchString(match) &&
+			s.Closest("table, pre, code").Length() == 0 {
+			s.Remove()
+		}
+	})
+}
+
+func initialScore(n *html.Node) float64 {
+	score := classWeight(n)
+	switch n.Data {
+	case "div", "article", "main", "section":
+		score += 5
+	case "pre", "td", "blockquote":
+		score += 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
+		score -= 3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score -= 5
+	}
+
+	return score
+}
+
+func classWeight(n *html.Node) float64 {
+	var weight float64
+	for _, name := range []string{attr(n, "class"), attr(n, "id")} {
+		if name == "" {
+			continue
+		}
+		if negativeWeight.MatchString(name) {
+			weight -= 25
+		}
+		if positiveWeight.MatchString(name) {
+			weight += 25
+		}
+	}
+
+	return weight
+}
+
+// linkDensity is the ratio of the text inside links to all the text of n.
+func linkDensity(n *html.Node) float64 {
+	s := goquery.NewDocumentFromNode(n)
+	length := utf8.RuneCountInString(normalizeSpace(s.Text()))
+	if length == 0 {
+		return 0
+	}
+
+	linkLength := 0
+	s.Find("a").Each(func(_ int, a *goquery.Selection) {
+		linkLength += utf8.RuneCountInString(normalizeSpace(a.Text()))
+	})
+
+	return float64(linkLength) / float64(length)
+}
+
+func hasBlockChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && paragraphBlockTags[c.Data] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySource      = "_source"
+	MetaKeyTitle       = "_title"
+	MetaKeyFrontMatter = "_front_matter"
+	MetaKeyLinks       = "_links"
+	MetaKeyCodeBlocks  = "_code_blocks"
+)
+
+var _ parser.Parser = (*Parser)(nil)
+
+// Link is a link found in the Markdown content, images are not included.
+type Link struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// CodeBlock is a fenced code block found in the Markdown content.
+type CodeBlock struct {
+	// Language is the first word of the info string, empty if not provided.
+	Language string `json:"language"`
+	Code     string `json:"code"`
+}
+
+type Config struct {
+	// FlattenFrontMatter if true, front-matter fields are set as top-level meta data,
+	// otherwise the front-matter is set as a map under MetaKeyFrontMatter.
+	FlattenFrontMatter bool
+	// KeepFrontMatter if true, the front-matter is kept in the content.
+	KeepFrontMatter bool
+}
+
+// NewParser returns a new markdown parser.
+func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+
+	return &Parser{
+		conf: conf,
+	}, nil
+}
+
+// Parser implements parser.Parser. It parses Markdown content, with its YAML front-matter as meta data.
+// Links and fenced code blocks are extracted as meta data as well, the title is the "title" field
+// of the front-matter or the first level 1 header.
+type Parser struct {
+	conf *Config
+}
+
+func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("markdown parser read all from reader failed: %w", err)
+	}
+
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	frontMatter, body, err := splitFrontMatter(text)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := map[string]any{}
+	if len(frontMatter) > 0 {
+		if p.conf.FlattenFrontMatter {
+			for k, v := range frontMatter {
+				meta[k] = v
+			}
+		} else {
+			meta[MetaKeyFrontMatter] = frontMatter
+		}
+	}
+
+	links, codeBlocks, h1 := scan(body)
+	if len(links) > 0 {
+		meta[MetaKeyLinks] = links
+	}
+	if len(codeBlocks) > 0 {
+		meta[MetaKeyCodeBlocks] = codeBlocks
+	}
+	if title, ok := frontMatter["title"].(string); ok && title != "" {
+		meta[MetaKeyTitle] = title
+	} else if h1 != "" {
+		meta[MetaKeyTitle] = h1
+	}
+	if option.URI != "" {
+		meta[MetaKeySource] = option.URI
+	}
+	for k, v := range option.ExtraMeta {
+		meta[k] = v
+	}
+
+	content := body
+	if p.conf.KeepFrontMatter {
+		content = text
+	}
+
+	return []*schema.Document{
+		{
+			Content:  strings.TrimSpace(content),
+			MetaData: meta,
+		},
+	}, nil
+}
+
+// splitFrontMatter splits the YAML front-matter delimited by "---" lines at the beginning of text.
+func splitFrontMatter(text string) (map[string]any, string, error) {
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, text, nil
+	}
+
+	rest := text[len("---\n"):]
+	end, next := -1, 0
+	for off := 0; off <= len(rest); {
+		i := strings.IndexByte(rest[off:], '\n')
+		lineEnd := len(rest)
+		if i >= 0 {
+			lineEnd = off + i
+		}
+		if l := strings.TrimRight(rest[off:lineEnd], " \t"); l == "---" || l == "..." {
+			end, next = off, lineEnd+1
+			break
+		}
+		if i < 0 {
+			break
+		}
+		off = lineEnd + 1
+	}
+	if end < 0 {
+		// an unterminated block is a thematic break rather than front-matter.
+		return nil, text, nil
+	}
+	if next > len(rest) {
+		next = len(rest)
+	}
+
+	fm := map[string]any{}
+	if err := yaml.Unmarshal([]byte(rest[:end]), &fm); err != nil {
+		return nil, "", fmt.Errorf("parse front matter failed: %w", err)
+	}
+
+	return fm, rest[next:], nil
+}
+
+var (
+	fenceRe      = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+	headerRe     = regexp.MustCompile(`^ {0,3}#\s+(.+?)\s*#*\s*$`)
+	codeSpanRe   = regexp.MustCompile("`+[^`]*`+")
+	inlineLinkRe = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*<?([^\s)>]+)>?(?:\s+(?:"[^"]*"|'[^']*'|\([^)]*\)))?\s*\)`)
+	refLinkRe    = regexp.MustCompile(`(!?)\[([^\]]+)\]\[([^\]]*)\]`)
+	autoLinkRe   = regexp.MustCompile(`<((?:https?|ftp|mailto):[^\s>]+)>`)
+	refDefRe     = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:\s*<?(\S+?)>?(?:\s+.*)?$`)
+)
+
+// scan extracts links and fenced code blocks, and the first level 1 header. Links in code are ignored.
+func scan(body string) (links []*Link, codeBlocks []*CodeBlock, h1 string) {
+	type refLink struct {
+		text, id string
+	}
+
+	var (
+		fence    string
+		lang     string
+		code     []string
+		refs     = make(map[string]string)
+		ordering []any
+	)
+	for _, line := range strings.Split(body, "\n") {
+		if fence != "" {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				codeBlocks = append(codeBlocks, &CodeBlock{Language: lang, Code: strings.Join(code, "\n")})
+				fence, lang, code = "", "", nil
+				continue
+			}
+			code = append(code, line)
+			continue
+		}
+
+		if m := fenceRe.FindStringSubmatch(line); m != nil {
+			fence, lang = m[1], m[2]
+			continue
+		}
+		if m := refDefRe.FindStringSubmatch(line); m != nil {
+			refs[strings.ToLower(m[1])] = m[2]
+			continue
+		}
+		if h1 == "" {
+			if m := headerRe.FindStringSubmatch(line); m != nil {
+				h1 = m[1]
+			}
+		}
+
+		line = codeSpanRe.ReplaceAllString(line, "")
+		for _, m := range inlineLinkRe.FindAllStringSubmatch(line, -1) {
+			if m[1] == "" {
+				ordering = append(ordering, &Link{Text: m[2], URL: m[3]})
+			}
+		}
+		for _, m := range refLinkRe.FindAllStringSubmatch(line, -1) {
+			if m[1] == "" {
+				id := m[3]
+				if id == "" {
+					id = m[2]
+				}
+				ordering = append(ordering, refLink{text: m[2], id: strings.ToLower(id)})
+			}
+		}
+		for _, m := range autoLinkRe.FindAllStringSubmatch(line, -1) {
+			ordering = append(ordering, &Link{Text: m[1], URL: m[1]})
+		}
+	}
+	if fence != "" {
+		// an unclosed fence runs to the end of the document.
+		codeBlocks = append(codeBlocks, &CodeBlock{Language: lang, Code: strings.Join(code, "\n")})
+	}
+
+	// reference definitions may come after their use.
+	seen := make(map[Link]bool)
+	for _, o := range ordering {
+		var l *Link
+		switch v := o.(type) {
+		case *Link:
+			l = v
+		case refLink:
+			url, ok := refs[v.id]
+			if !ok {
+				continue
+			}
+			l = &Link{Text: v.text, URL: url}
+		}
+		if seen[*l] {
+			continue
+		}
+		seen[*l] = true
+		links = append(links, l)
+	}
+
+	return links, codeBlocks, h1
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package markdown
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMarkdown = "---\r\n" +
+	"title: Getting Started\r\n" +
+	"tags: [go, eino]\r\n" +
+	"draft: false\r\n" +
+	"---\r\n" +
+	"# Introduction\r\n" +
+	"\r\n" +
+	"See [the docs](https://example.com/docs \"Docs\") and ![logo](logo.png).\r\n" +
+	"Read the [guide][g] or <https://example.com/auto>, not `[code](x)`.\r\n" +
+	"\r\n" +
+	"```go\r\n" +
+	"fmt.Println(\"[not](a link)\")\r\n" +
+	"```\r\n" +
+	"\r\n" +
+	"~~~\r\n" +
+	"plain\r\n" +
+	"~~~\r\n" +
+	"\r\n" +
+	"[g]: https://example.com/guide\r\n"
+
+func TestParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("front matter", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(testMarkdown), parser.WithURI("start.md"),
+			parser.WithExtraMeta(map[string]any{"k": "v"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.True(t, strings.HasPrefix(docs[0].Content, "# Introduction\n"))
+
+		meta := docs[0].MetaData
+		assert.Equal(t, map[string]any{
+			"title": "Getting Started",
+			"tags":  []any{"go", "eino"},
+			"draft": false,
+		}, meta[MetaKeyFrontMatter])
+		assert.Equal(t, "Getting Started", meta[MetaKeyTitle])
+		assert.Equal(t, "start.md", meta[MetaKeySource])
+		assert.Equal(t, "v", meta["k"])
+		assert.Equal(t, []*Link{
+			{Text: "the docs", URL: "https://example.com/docs"},
+			{Text: "guide", URL: "https://example.com/guide"},
+			{Text: "https://example.com/auto", URL: "https://example.com/auto"},
+		}, meta[MetaKeyLinks])
+		assert.Equal(t, []*CodeBlock{
+			{Language: "go", Code: "fmt.Println(\"[not](a link)\")"},
+			{Language: "", Code: "plain"},
+		}, meta[MetaKeyCodeBlocks])
+	})
+
+	t.Run("flatten and keep", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{FlattenFrontMatter: true, KeepFrontMatter: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(testMarkdown))
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(docs[0].Content, "---\ntitle: Getting Started\n"))
+		assert.Equal(t, false, docs[0].MetaData["draft"])
+		assert.Nil(t, docs[0].MetaData[MetaKeyFrontMatter])
+	})
+
+	t.Run("no front matter", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("---\nnot closed\n\n# Title #\n\n```\nunclosed"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Title", docs[0].MetaData[MetaKeyTitle])
+		assert.Nil(t, docs[0].MetaData[MetaKeyFrontMatter])
+		assert.Equal(t, []*CodeBlock{{Code: "unclosed"}}, docs[0].MetaData[MetaKeyCodeBlocks])
+	})
+
+	t.Run("invalid front matter", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("---\n: [\n---\nbody"))
+		assert.Error(t, err)
+	})
+}
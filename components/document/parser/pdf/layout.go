@@ -0,0 +1,563 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// glyph is a piece of text drawn at a position of the page, in PDF points with the origin at bottom left.
+type glyph struct {
+	x, y, w, size float64
+	text          string
+}
+
+// span is a run of glyphs on the same baseline without a large horizontal gap.
+type span struct {
+	x0, x1, y, size float64
+	text            string
+}
+
+type line struct {
+	spans []*span
+	y     float64
+	size  float64
+}
+
+type blockKind string
+
+const (
+	blockParagraph blockKind = "paragraph"
+	blockHeading   blockKind = "heading"
+	blockTable     blockKind = "table"
+)
+
+// block is a unit of page content in reading order.
+type block struct {
+	kind  blockKind
+	lines []*line
+	rows  [][]string
+	level int
+	title string
+	bbox  bbox
+	// section is the path of outline titles the block belongs to.
+	section []string
+}
+
+// bbox is [x0, y0, x1, y1] in PDF points.
+type bbox [4]float64
+
+const (
+	// wordGapRatio is the gap, in font size, above which a space is inserted between two glyphs.
+	wordGapRatio = 0.15
+	// minGutterRatio is the min width, in font size, of the gap between two columns.
+	minGutterRatio = 0.5
+	// minColumnLines is the min number of lines with text on both sides of a gutter to be treated as columns.
+	minColumnLines = 3
+	// maxColumnLineGap is the max vertical gap, in font size, between two lines of a column layout.
+	maxColumnLineGap = 3
+	// minColumnRatio is the min average span width of a column layout, relative to its width.
+	minColumnRatio = 0.25
+	// maxTableCellRatio is the max average cell width of a two-column table, relative to the table width.
+	maxTableCellRatio = 0.35
+)
+
+type layout struct {
+	// spanGap is the gap, in font size, above which two glyphs of a line belong to different spans.
+	spanGap float64
+	tables  bool
+}
+
+// blocks lays out the glyphs of a page as blocks in reading order.
+func (l *layout) blocks(glyphs []glyph) []*block {
+	return l.flow(l.lines(glyphs))
+}
+
+// lines groups glyphs by baseline from top to bottom, and splits each line into spans at large gaps.
+func (l *layout) lines(glyphs []glyph) []*line {
+	gs := make([]glyph, 0, len(glyphs))
+	for _, g := range glyphs {
+		if strings.TrimSpace(g.text) == "" {
+			continue
+		}
+		if g.size <= 0 {
+			g.size = 1
+		}
+		gs = append(gs, g)
+	}
+	sort.SliceStable(gs, func(i, j int) bool {
+		if gs[i].y != gs[j].y {
+			return gs[i].y > gs[j].y
+		}
+		return gs[i].x < gs[j].x
+	})
+
+	var (
+		rows [][]glyph
+		cur  []glyph
+	)
+	for _, g := range gs {
+		if len(cur) > 0 && math.Abs(cur[0].y-g.y) > 0.5*math.Max(cur[0].size, g.size) {
+			rows = append(rows, cur)
+			cur = nil
+		}
+		cur = append(cur, g)
+	}
+	if len(cur) > 0 {
+		rows = append(rows, cur)
+	}
+
+	lines := make([]*line, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, l.line(row))
+	}
+
+	return lines
+}
+
+func (l *layout) line(row []glyph) *line {
+	sort.SliceStable(row, func(i, j int) bool { return row[i].x < row[j].x })
+
+	ln := &line{y: row[0].y}
+	var (
+		sb   strings.Builder
+		sp   *span
+		prev *glyph
+	)
+	flush := func() {
+		if sp != nil {
+			sp.text = strings.TrimSpace(sb.String())
+			ln.spans = append(ln.spans, sp)
+		}
+		sb.Reset()
+	}
+	for i := range row {
+		g := &row[i]
+		ln.size = math.Max(ln.size, g.size)
+		if prev != nil {
+			// some producers draw a glyph twice with a small offset to fake bold.
+			if g.text == prev.text && math.Abs(g.x-prev.x) < 0.1*g.size {
+				continue
+			}
+			gap := g.x - (prev.x + prev.w)
+			switch {
+			case gap > l.spanGap*math.Max(g.size, prev.size):
+				flush()
+				sp = nil
+			case gap > wordGapRatio*g.size && !strings.HasSuffix(prev.text, " ") && !strings.HasPrefix(g.text, " "):
+				sb.WriteByte(' ')
+			}
+		}
+		if sp == nil {
+			sp = &span{x0: g.x, y: g.y}
+		}
+		sp.x1 = math.Max(sp.x1, g.x+g.w)
+		sp.size = math.Max(sp.size, g.size)
+		sb.WriteString(g.text)
+		prev = g
+	}
+	flush()
+
+	return ln
+}
+
+func (ln *line) text() string {
+	texts := make([]string, 0, len(ln.spans))
+	for _, s := range ln.spans {
+		texts = append(texts, s.text)
+	}
+
+	return strings.Join(texts, " ")
+}
+
+func (ln *line) bbox() bbox {
+	b := bbox{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, s := range ln.spans {
+		b = b.union(bbox{s.x0, s.y, s.x1, s.y + s.size})
+	}
+
+	return b
+}
+
+func (b bbox) union(o bbox) bbox {
+	return bbox{math.Min(b[0], o[0]), math.Min(b[1], o[1]), math.Max(b[2], o[2]), math.Max(b[3], o[3])}
+}
+
+func (b bbox) valid() bool {
+	return b[0] <= b[2] && b[1] <= b[3]
+}
+
+func emptyBBox() bbox {
+	return bbox{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+}
+
+// flow orders lines from top to bottom, tables are kept as a whole and
+// columns are read one after another before continuing below them.
+func (l *layout) flow(lines []*line) []*block {
+	var (
+		blocks []*block
+		plain  []*line
+	)
+	flush := func() {
+		blocks = append(blocks, paragraphs(plain)...)
+		plain = nil
+	}
+
+	for i := 0; i < len(lines); {
+		if l.tables {
+			if end, rows := detectTable(lines, i); end > i {
+				flush()
+				blocks = append(blocks, tableBlock(lines[i:end], rows))
+				i = end
+				continue
+			}
+		}
+		if end, gutter := detectColumns(lines, i); end > i {
+			flush()
+			left, right := splitLines(lines[i:end], gutter)
+			blocks = append(blocks, l.flow(left)...)
+			blocks = append(blocks, l.flow(right)...)
+			i = end
+			continue
+		}
+		plain = append(plain, lines[i])
+		i++
+	}
+	flush()
+
+	return blocks
+}
+
+// detectColumns looks for a vertical gutter starting at line start, which is not crossed by
+// any span of the following lines. It returns the end of the lines sharing the gutter and
+// the x of the gutter, or start if there is no such gutter.
+func detectColumns(lines []*line, start int) (int, float64) {
+	first := lines[start]
+	for k := 0; k+1 < len(first.spans); k++ {
+		lo, hi := first.spans[k].x1, first.spans[k+1].x0
+		end, both := start, 0
+		for end < len(lines) {
+			ln := lines[end]
+			if end > start && lines[end-1].y-ln.y > maxColumnLineGap*ln.size {
+				break
+			}
+			nlo, nhi, left, right, ok := narrowGutter(ln, lo, hi)
+			if !ok || nhi-nlo < minGutterRatio*ln.size {
+				break
+			}
+			lo, hi = nlo, nhi
+			if left && right {
+				both++
+			}
+			end++
+		}
+		if both >= minColumnLines && !narrowSpans(lines[start:end]) {
+			return end, (lo + hi) / 2
+		}
+	}
+
+	return start, 0
+}
+
+// narrowSpans reports whether spans are too narrow to be columns of text, which is likely a table.
+func narrowSpans(lines []*line) bool {
+	var (
+		b     = emptyBBox()
+		width float64
+		n     int
+	)
+	for _, ln := range lines {
+		for _, s := range ln.spans {
+			width += s.x1 - s.x0
+			n++
+		}
+		b = b.union(ln.bbox())
+	}
+
+	return width/float64(n) < minColumnRatio*(b[2]-b[0])
+}
+
+// narrowGutter shrinks [lo, hi] so that no span of ln overlaps it, ok is false if a span crosses it.
+func narrowGutter(ln *line, lo, hi float64) (nlo, nhi float64, left, right, ok bool) {
+	for _, s := range ln.spans {
+		switch {
+		case s.x1 <= lo:
+			left = true
+		case s.x0 >= hi:
+			right = true
+		case s.x0 <= lo && s.x1 < hi:
+			lo, left = s.x1, true
+		case s.x0 > lo && s.x1 >= hi:
+			hi, right = s.x0, true
+		default:
+			return 0, 0, false, false, false
+		}
+	}
+
+	return lo, hi, left, right, true
+}
+
+func splitLines(lines []*line, gutter float64) (left, right []*line) {
+	for _, ln := range lines {
+		var l, r []*span
+		for _, s := range ln.spans {
+			if s.x1 <= gutter {
+				l = append(l, s)
+			} else {
+				r = append(r, s)
+			}
+		}
+		if len(l) > 0 {
+			left = append(left, newLine(l))
+		}
+		if len(r) > 0 {
+			right = append(right, newLine(r))
+		}
+	}
+
+	return left, right
+}
+
+func newLine(spans []*span) *line {
+	ln := &line{spans: spans, y: spans[0].y}
+	for _, s := range spans {
+		ln.size = math.Max(ln.size, s.size)
+	}
+
+	return ln
+}
+
+// detectTable looks for at least two consecutive lines whose spans line up in the same columns.
+// It returns the end of the table and its cells, or start if there is no table.
+func detectTable(lines []*line, start int) (int, [][]string) {
+	first := lines[start]
+	if len(first.spans) < 2 {
+		return start, nil
+	}
+
+	cols := make([][2]float64, 0, len(first.spans))
+	for _, s := range first.spans {
+		cols = append(cols, [2]float64{s.x0, s.x1})
+	}
+
+	var (
+		rows  [][]string
+		end   = start
+		width float64
+		cells int
+	)
+	for end < len(lines) {
+		ln := lines[end]
+		if len(ln.spans) < 2 {
+			break
+		}
+		if end > start && lines[end-1].y-ln.y > 2.5*ln.size {
+			break
+		}
+		row, ok := assignCells(ln, cols)
+		if !ok {
+			break
+		}
+		for _, s := range ln.spans {
+			width += s.x1 - s.x0
+			cells++
+		}
+		rows = append(rows, row)
+		end++
+	}
+	if len(rows) < 2 {
+		return start, nil
+	}
+
+	if len(cols) == 2 {
+		total := cols[len(cols)-1][1] - cols[0][0]
+		if total <= 0 || width/float64(cells) > maxTableCellRatio*total {
+			return start, nil
+		}
+	}
+
+	return end, rows
+}
+
+// assignCells puts every span of ln into the single column it overlaps, and widens the columns.
+func assignCells(ln *line, cols [][2]float64) ([]string, bool) {
+	row := make([]string, len(cols))
+	for _, s := range ln.spans {
+		idx := -1
+		for c, col := range cols {
+			if s.x0 < col[1] && s.x1 > col[0] {
+				if idx >= 0 {
+					return nil, false
+				}
+				idx = c
+			}
+		}
+		if idx < 0 {
+			return nil, false
+		}
+		if row[idx] != "" {
+			row[idx] += " "
+		}
+		row[idx] += s.text
+	}
+	for _, s := range ln.spans {
+		for c := range cols {
+			if s.x0 < cols[c][1] && s.x1 > cols[c][0] {
+				cols[c][0] = math.Min(cols[c][0], s.x0)
+				cols[c][1] = math.Max(cols[c][1], s.x1)
+			}
+		}
+	}
+	for c := 0; c+1 < len(cols); c++ {
+		if cols[c][1] >= cols[c+1][0] {
+			return nil, false
+		}
+	}
+
+	return row, true
+}
+
+func tableBlock(lines []*line, rows [][]string) *block {
+	b := &block{kind: blockTable, rows: rows, bbox: emptyBBox()}
+	for _, ln := range lines {
+		b.bbox = b.bbox.union(ln.bbox())
+	}
+
+	return b
+}
+
+// paragraphs splits lines of a single column at large vertical gaps and font size changes.
+func paragraphs(lines []*line) []*block {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	gaps := make([]float64, 0, len(lines))
+	for i := 1; i < len(lines); i++ {
+		if g := lines[i-1].y - lines[i].y; g > 0 {
+			gaps = append(gaps, g)
+		}
+	}
+	typical := median(gaps)
+
+	var (
+		blocks []*block
+		cur    *block
+	)
+	for i, ln := range lines {
+		if cur != nil {
+			prev := lines[i-1]
+			gap := prev.y - ln.y
+			ratio := ln.size / prev.size
+			if gap <= 0 || gap > 1.5*typical+0.01 || gap > 2*math.Max(ln.size, prev.size) || ratio > 1.25 || ratio < 0.8 {
+				blocks = append(blocks, cur)
+				cur = nil
+			}
+		}
+		if cur == nil {
+			cur = &block{kind: blockParagraph, bbox: emptyBBox()}
+		}
+		cur.lines = append(cur.lines, ln)
+		cur.bbox = cur.bbox.union(ln.bbox())
+	}
+	blocks = append(blocks, cur)
+
+	return blocks
+}
+
+func median(vs []float64) float64 {
+	if len(vs) == 0 {
+		return math.Inf(1)
+	}
+	s := append([]float64(nil), vs...)
+	sort.Float64s(s)
+
+	return s[len(s)/2]
+}
+
+// text renders the block as Markdown.
+func (b *block) text() string {
+	switch b.kind {
+	case blockHeading:
+		return strings.Repeat("#", b.level) + " " + b.title
+	case blockTable:
+		return markdownTable(b.rows)
+	default:
+		texts := make([]string, 0, len(b.lines))
+		for _, ln := range b.lines {
+			texts = append(texts, ln.text())
+		}
+		return strings.Join(texts, "\n")
+	}
+}
+
+func markdownTable(rows [][]string) string {
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for _, c := range cells {
+			sb.WriteString(" ")
+			sb.WriteString(strings.ReplaceAll(c, "|", `\|`))
+			sb.WriteString(" |")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(rows[0])
+	sep := make([]string, len(rows[0]))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	writeRow(sep)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// helveticaWidths are the widths of printable ASCII characters in Helvetica, in 1/1000 of the font size.
+// They are used to estimate the width of glyphs whose font does not provide widths.
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // ' ' - '/'
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // '0' - '?'
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // '@' - 'O'
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // 'P' - '_'
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // '`' - 'o'
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // 'p' - '~'
+}
+
+// estimateWidth guesses the width of text, wide characters such as CJK take a full em.
+func estimateWidth(text string, size float64) float64 {
+	var w float64
+	for _, r := range text {
+		switch {
+		case r >= ' ' && r <= '~':
+			w += float64(helveticaWidths[r-' ']) / 1000
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+			(r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef):
+			w++
+		default:
+			w += 0.556
+		}
+	}
+
+	return w * size
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dslipak/pdf"
+	"github.com/stretchr/testify/assert"
+)
+
+// words places space separated words on a baseline starting at x.
+func words(x, y, size float64, text string) []glyph {
+	var gs []glyph
+	for _, w := range strings.Fields(text) {
+		width := estimateWidth(w, size)
+		gs = append(gs, glyph{x: x, y: y, w: width, size: size, text: w})
+		x += width + estimateWidth(" ", size)
+	}
+	return gs
+}
+
+func texts(blocks []*block) []string {
+	ret := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		ret = append(ret, b.text())
+	}
+	return ret
+}
+
+func TestLayout(t *testing.T) {
+	lay := &layout{spanGap: defaultColumnGap, tables: true}
+
+	t.Run("lines and paragraphs", func(t *testing.T) {
+		var gs []glyph
+		gs = append(gs, words(72, 700, 10, "second line")...)
+		gs = append(gs, words(72, 712, 10, "first line")...)
+		gs = append(gs, words(72, 670, 10, "new paragraph")...)
+		// glyphs without space between them, and a duplicated glyph faking bold.
+		gs = append(gs, glyph{x: 72, y: 658, w: 5, size: 10, text: "a"}, glyph{x: 77, y: 658, w: 5, size: 10, text: "b"},
+			glyph{x: 77.3, y: 658, w: 5, size: 10, text: "b"})
+
+		blocks := lay.blocks(gs)
+		assert.Equal(t, []string{"first line\nsecond line", "new paragraph\nab"}, texts(blocks))
+		assert.InDelta(t, 72, blocks[0].bbox[0], 0.01)
+		assert.InDelta(t, 700, blocks[0].bbox[1], 0.01)
+		assert.InDelta(t, 722, blocks[0].bbox[3], 0.01)
+	})
+
+	t.Run("columns", func(t *testing.T) {
+		var gs []glyph
+		gs = append(gs, words(72, 750, 14, "A title across the page width")...)
+		left := []string{"left column one is a longer line", "left column two is a longer line",
+			"left column three is a longer line", "left four"}
+		right := []string{"right column one is a longer line", "right column two is a longer line",
+			"right column three is a longer line"}
+		for i, s := range left {
+			gs = append(gs, words(72, 720-float64(i)*12, 10, s)...)
+		}
+		for i, s := range right {
+			gs = append(gs, words(300, 720-float64(i)*12, 10, s)...)
+		}
+		gs = append(gs, words(72, 640, 10, "footer text")...)
+
+		blocks := lay.blocks(gs)
+		assert.Equal(t, []string{
+			"A title across the page width",
+			"left column one is a longer line\nleft column two is a longer line\nleft column three is a longer line\nleft four",
+			"right column one is a longer line\nright column two is a longer line\nright column three is a longer line",
+			"footer text",
+		}, texts(blocks))
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var gs []glyph
+		rows := [][]string{{"Name", "Qty", "Price"}, {"apple", "3", "1.5"}, {"pear | green", "10", "2"}}
+		for i, row := range rows {
+			for j, cell := range row {
+				gs = append(gs, words(72+float64(j)*120, 700-float64(i)*14, 10, cell)...)
+			}
+		}
+		gs = append(gs, words(72, 640, 10, "after the table")...)
+
+		blocks := lay.blocks(gs)
+		assert.Equal(t, []string{
+			"| Name | Qty | Price |\n| --- | --- | --- |\n| apple | 3 | 1.5 |\n| pear \\| green | 10 | 2 |",
+			"after the table",
+		}, texts(blocks))
+		assert.Equal(t, blockTable, blocks[0].kind)
+
+		// without table detection, the cells are read as lines.
+		blocks = (&layout{spanGap: defaultColumnGap}).blocks(gs)
+		assert.Equal(t, "Name Qty Price\napple 3 1.5\npear | green 10 2", blocks[0].text())
+	})
+}
+
+func TestSections(t *testing.T) {
+	secs := newSections(pdf.Outline{Child: []pdf.Outline{
+		{Title: "Introduction", Child: []pdf.Outline{{Title: "Missing In Text"}, {Title: "Background  "}}},
+		{Title: "Usage"},
+	}})
+
+	lay := &layout{spanGap: defaultColumnGap}
+	var gs []glyph
+	gs = append(gs, words(72, 750, 16, "Introduction")...)
+	gs = append(gs, words(72, 720, 12, "BACKGROUND")...)
+	gs = append(gs, words(72, 706, 12, "some text")...)
+	blocks := secs.apply(lay.blocks(gs))
+	assert.Equal(t, []string{"# Introduction", "## Background", "some text"}, texts(blocks))
+	assert.Equal(t, []string{"Introduction"}, blocks[0].section)
+	assert.Equal(t, []string{"Introduction", "Background"}, blocks[2].section)
+
+	gs = words(72, 750, 16, "Usage")
+	blocks = secs.apply(lay.blocks(gs))
+	assert.Equal(t, []string{"# Usage"}, texts(blocks))
+	assert.Equal(t, []string{"Usage"}, blocks[0].section)
+}
+
+func TestEstimateWidth(t *testing.T) {
+	assert.InDelta(t, 5.56, estimateWidth("a", 10), 0.001)
+	assert.InDelta(t, 20, estimateWidth("中文", 10), 0.001)
+}
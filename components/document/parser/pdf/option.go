@@ -19,7 +19,8 @@ package pdf
 import "github.com/cloudwego/eino/components/document/parser"
 
 type options struct {
-	toPages *bool
+	toPages  *bool
+	toBlocks *bool
 }
 
 // WithToPages is a parser option that specifies whether to parse the PDF into pages.
@@ -28,3 +29,10 @@ func WithToPages(toPages bool) parser.Option {
 		opts.toPages = &toPages
 	})
 }
+
+// WithToBlocks is a parser option that specifies whether to parse the PDF into paragraphs, headings and tables.
+func WithToBlocks(toBlocks bool) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.toBlocks = &toBlocks
+	})
+}
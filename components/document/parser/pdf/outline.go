@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"strings"
+
+	"github.com/dslipak/pdf"
+)
+
+// outlineLookahead is how many outline entries are tried ahead of the expected one,
+// so that an entry whose title is not found in the text does not stop the matching.
+const outlineLookahead = 5
+
+type outlineEntry struct {
+	title string
+	key   string
+	level int
+}
+
+// sections turns the outline titles into Markdown headers while blocks are read in order,
+// and keeps the path of the current section.
+type sections struct {
+	entries []*outlineEntry
+	next    int
+	path    []string
+}
+
+func newSections(o pdf.Outline) *sections {
+	s := &sections{}
+	var walk func(o pdf.Outline, level int)
+	walk = func(o pdf.Outline, level int) {
+		for _, c := range o.Child {
+			if key := normalizeTitle(c.Title); key != "" {
+				s.entries = append(s.entries, &outlineEntry{title: strings.TrimSpace(c.Title), key: key, level: level})
+			}
+			walk(c, level+1)
+		}
+	}
+	walk(o, 1)
+
+	return s
+}
+
+// apply turns paragraphs starting with the next outline titles into headings.
+func (s *sections) apply(blocks []*block) []*block {
+	if len(s.entries) == 0 {
+		return blocks
+	}
+
+	ret := make([]*block, 0, len(blocks))
+	for _, b := range blocks {
+		if b.kind != blockParagraph {
+			b.section = s.current()
+			ret = append(ret, b)
+			continue
+		}
+
+		// wrapped titles span the whole paragraph, otherwise only the first line may be a title.
+		if e := s.match(b.text()); e != nil {
+			ret = append(ret, s.heading(e, b.lines))
+			continue
+		}
+		if len(b.lines) > 1 {
+			if e := s.match(b.lines[0].text()); e != nil {
+				rest := &block{kind: blockParagraph, lines: b.lines[1:], bbox: emptyBBox()}
+				for _, ln := range rest.lines {
+					rest.bbox = rest.bbox.union(ln.bbox())
+				}
+				h := s.heading(e, b.lines[:1])
+				rest.section = s.current()
+				ret = append(ret, h, rest)
+				continue
+			}
+		}
+		b.section = s.current()
+		ret = append(ret, b)
+	}
+
+	return ret
+}
+
+func (s *sections) match(text string) *outlineEntry {
+	key := normalizeTitle(text)
+	for i := s.next; i < len(s.entries) && i < s.next+outlineLookahead; i++ {
+		e := s.entries[i]
+		if e.key != key {
+			continue
+		}
+		s.next = i + 1
+		if len(s.path) >= e.level {
+			s.path = s.path[:e.level-1]
+		}
+		s.path = append(s.path, e.title)
+		return e
+	}
+
+	return nil
+}
+
+// current returns a copy of the path of the current section.
+func (s *sections) current() []string {
+	if len(s.path) == 0 {
+		return nil
+	}
+
+	return append([]string(nil), s.path...)
+}
+
+func (s *sections) heading(e *outlineEntry, lines []*line) *block {
+	b := &block{kind: blockHeading, lines: lines, level: e.level, title: e.title, section: s.current(), bbox: emptyBBox()}
+	for _, ln := range lines {
+		b.bbox = b.bbox.union(ln.bbox())
+	}
+
+	return b
+}
+
+func normalizeTitle(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
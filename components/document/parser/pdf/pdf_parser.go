@@ -21,22 +21,52 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
 	"github.com/dslipak/pdf"
 )
 
+const (
+	// MetaKeyPage is the 1-based page number of the document.
+	MetaKeyPage = "_page"
+	// MetaKeyPageCount is the number of pages of the pdf, set when the pdf is parsed into a single document.
+	MetaKeyPageCount = "_page_count"
+	// MetaKeyBBox is the bounding box of the text as []float64{x0, y0, x1, y1},
+	// in PDF points with the origin at the bottom left of the page.
+	MetaKeyBBox = "_bbox"
+	// MetaKeyBlockType is one of "paragraph", "heading" and "table", set when parsed into blocks.
+	MetaKeyBlockType = "_block_type"
+	// MetaKeySection is the path of outline titles the document belongs to, as []string.
+	MetaKeySection = "_section"
+)
+
+const defaultColumnGap = 1.2
+
 // Config is the configuration for PDF parser.
 type Config struct {
-	ToPages bool // whether to
+	ToPages bool // whether to split the pdf into one document per page
+	// ToBlocks if true, every paragraph, heading and table is a document, overrides ToPages.
+	ToBlocks bool
+	// DetectTables if true, text aligned in rows and columns is rendered as a Markdown table.
+	DetectTables bool
+	// UseOutline if true, titles of the outline (bookmarks) found in the text are rendered as Markdown headers,
+	// and the section path is set in MetaKeySection.
+	UseOutline bool
+	// ColumnGap is the min horizontal gap between two runs of text on the same line, in multiples of the font size,
+	// to consider them as different columns or table cells.
+	// Default is 1.2.
+	ColumnGap float64
 }
 
-// PDFParser reads from io.Reader and parse its content as plain text.
-// Attention: This is in alpha stage, and may not support all PDF use cases well enough.
-// For example, it will not preserve whitespace and new line for now.
+// PDFParser reads from io.Reader and parse its content as text in reading order.
+// Lines and paragraphs are kept, and multi-column layouts are read column by column.
+// Pages whose layout can not be extracted fall back to plain text.
 type PDFParser struct {
 	ToPages bool
+
+	conf Config
 }
 
 // NewPDFParser creates a new PDF parser.
@@ -44,7 +74,11 @@ func NewPDFParser(ctx context.Context, config *Config) (*PDFParser, error) {
 	if config == nil {
 		config = &Config{}
 	}
-	return &PDFParser{ToPages: config.ToPages}, nil
+	conf := *config
+	if conf.ColumnGap <= 0 {
+		conf.ColumnGap = defaultColumnGap
+	}
+	return &PDFParser{ToPages: config.ToPages, conf: conf}, nil
 }
 
 // Parse parses the PDF content from io.Reader.
@@ -52,7 +86,8 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 	commonOpts := parser.GetCommonOptions(nil, opts...)
 
 	specificOpts := parser.GetImplSpecificOptions(&options{
-		toPages: &pp.ToPages,
+		toPages:  &pp.ToPages,
+		toBlocks: &pp.conf.ToBlocks,
 	}, opts...)
 
 	data, err := io.ReadAll(reader)
@@ -67,11 +102,18 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 		return nil, fmt.Errorf("create new pdf reader failed: %w", err)
 	}
 
-	pages := f.NumPage()
 	var (
-		buf     bytes.Buffer
-		toPages = specificOpts.toPages != nil && *specificOpts.toPages
+		pages    = f.NumPage()
+		toPages  = specificOpts.toPages != nil && *specificOpts.toPages
+		toBlocks = specificOpts.toBlocks != nil && *specificOpts.toBlocks
+		texts    = make([]string, 0, pages)
+		lay      = &layout{spanGap: pp.conf.ColumnGap, tables: pp.conf.DetectTables}
+		secs     = &sections{}
 	)
+	if pp.conf.UseOutline {
+		secs = outlineSections(f)
+	}
+
 	fonts := make(map[string]*pdf.Font)
 	for i := 1; i <= pages; i++ {
 		p := f.Page(i)
@@ -81,27 +123,132 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 				fonts[name] = &font
 			}
 		}
-		text, err := p.GetPlainText(fonts)
+
+		blocks, err := pageBlocks(p, fonts, lay)
 		if err != nil {
 			return nil, fmt.Errorf("read pdf page failed: %w, page= %d", err, i)
 		}
+		blocks = secs.apply(blocks)
 
-		if toPages {
+		switch {
+		case toBlocks:
+			for _, b := range blocks {
+				meta := pageMeta(commonOpts.ExtraMeta, i, b.bbox, b.section)
+				meta[MetaKeyBlockType] = string(b.kind)
+				docs = append(docs, &schema.Document{
+					Content:  b.text(),
+					MetaData: meta,
+				})
+			}
+		case toPages:
+			pageBox := emptyBBox()
+			var section []string
+			for j, b := range blocks {
+				pageBox = pageBox.union(b.bbox)
+				if j == 0 {
+					section = b.section
+				}
+			}
 			docs = append(docs, &schema.Document{
-				Content:  text,
-				MetaData: commonOpts.ExtraMeta,
+				Content:  joinBlocks(blocks),
+				MetaData: pageMeta(commonOpts.ExtraMeta, i, pageBox, section),
 			})
-		} else {
-			buf.WriteString(text + "\n")
+		default:
+			texts = append(texts, joinBlocks(blocks))
 		}
 	}
 
-	if !toPages {
+	if !toPages && !toBlocks {
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+1)
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeyPageCount] = pages
 		docs = append(docs, &schema.Document{
-			Content:  buf.String(),
-			MetaData: commonOpts.ExtraMeta,
+			Content:  strings.Join(texts, "\n\n"),
+			MetaData: meta,
 		})
 	}
 
 	return docs, nil
 }
+
+// pageBlocks extracts the blocks of a page in reading order, or a single paragraph of plain text
+// if the positions of the text are not available.
+func pageBlocks(p pdf.Page, fonts map[string]*pdf.Font, lay *layout) ([]*block, error) {
+	glyphs, err := pageGlyphs(p)
+	if err == nil {
+		return lay.blocks(glyphs), nil
+	}
+
+	text, err := p.GetPlainText(fonts)
+	if err != nil {
+		return nil, err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+
+	b := &block{kind: blockParagraph, bbox: emptyBBox()}
+	for _, l := range strings.Split(text, "\n") {
+		b.lines = append(b.lines, &line{spans: []*span{{text: l}}})
+	}
+
+	return []*block{b}, nil
+}
+
+func pageGlyphs(p pdf.Page) (glyphs []glyph, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("read page content failed: %v", e)
+		}
+	}()
+
+	content := p.Content()
+	glyphs = make([]glyph, 0, len(content.Text))
+	for _, t := range content.Text {
+		g := glyph{x: t.X, y: t.Y, w: t.W, size: t.FontSize, text: t.S}
+		if g.w <= 0 {
+			g.w = estimateWidth(g.text, g.size)
+		}
+		glyphs = append(glyphs, g)
+	}
+
+	return glyphs, nil
+}
+
+func outlineSections(f *pdf.Reader) (s *sections) {
+	defer func() {
+		if e := recover(); e != nil {
+			s = &sections{}
+		}
+	}()
+
+	return newSections(f.Outline())
+}
+
+func joinBlocks(blocks []*block) string {
+	texts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		texts = append(texts, b.text())
+	}
+
+	return strings.Join(texts, "\n\n")
+}
+
+func pageMeta(extra map[string]any, page int, box bbox, section []string) map[string]any {
+	meta := make(map[string]any, len(extra)+3)
+	for k, v := range extra {
+		meta[k] = v
+	}
+	meta[MetaKeyPage] = page
+	if box.valid() {
+		meta[MetaKeyBBox] = []float64{box[0], box[1], box[2], box[3]}
+	}
+	if len(section) > 0 {
+		meta[MetaKeySection] = section
+	}
+
+	return meta
+}
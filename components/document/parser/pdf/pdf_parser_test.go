@@ -39,8 +39,35 @@ func TestLoader_Load(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, 2, len(docs))
 		assert.True(t, len(docs[0].Content) > 0)
-		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData)
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyPage])
 		assert.True(t, len(docs[0].Content) > 0)
-		assert.Equal(t, map[string]any{"test": "test"}, docs[1].MetaData)
+		assert.Equal(t, "test", docs[1].MetaData["test"])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyPage])
+		assert.Equal(t, 4, len(docs[1].MetaData[MetaKeyBBox].([]float64)))
+	})
+
+	t.Run("TestLoader_Load_Layout", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./testdata/test_pdf.pdf")
+		assert.NoError(t, err)
+
+		p, err := NewPDFParser(ctx, &Config{DetectTables: true, UseOutline: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "test a new pdf.\na new line with 中文。\n\n尝试一些样式。", docs[0].Content)
+		assert.Equal(t, 2, docs[0].MetaData[MetaKeyPageCount])
+
+		_, err = f.Seek(0, 0)
+		assert.NoError(t, err)
+		docs, err = p.Parse(ctx, f, WithToBlocks(true))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "paragraph", docs[1].MetaData[MetaKeyBlockType])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyPage])
 	})
 }
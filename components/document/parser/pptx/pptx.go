@@ -0,0 +1,445 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySource     = "_source"
+	MetaKeyTitle      = "_title"
+	MetaKeyAuthor     = "_author"
+	MetaKeySlide      = "_slide"
+	MetaKeySlideTitle = "_slide_title"
+	MetaKeyNotes      = "_notes"
+)
+
+// TableFormat is how tables are rendered in the document content.
+type TableFormat string
+
+const (
+	TableFormatMarkdown TableFormat = "markdown"
+	TableFormatCSV      TableFormat = "csv"
+)
+
+const relTypeNotesSlide = "/notesSlide"
+
+var _ parser.Parser = (*Parser)(nil)
+
+type Config struct {
+	// ToSlides if true, every slide is parsed into a document, with its speaker notes in MetaKeyNotes.
+	ToSlides bool
+	// IncludeNotes if true, speaker notes are appended to the content of the slide.
+	IncludeNotes bool
+	// IncludeHidden if true, hidden slides are parsed as well.
+	IncludeHidden bool
+	// TableFormat of the tables in the content, TableFormatMarkdown by default.
+	TableFormat TableFormat
+	// MaxSize is the max size in bytes of the file, larger files fail to parse.
+	// Default is 100MB.
+	MaxSize int64
+	// MaxPartSize is the max uncompressed size in bytes of a part of the zip package, it guards against zip bombs.
+	// Default is 100MB.
+	MaxPartSize int64
+}
+
+// NewParser returns a new pptx parser.
+func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+
+	c := *conf
+	if c.TableFormat == "" {
+		c.TableFormat = TableFormatMarkdown
+	}
+	if c.TableFormat != TableFormatMarkdown && c.TableFormat != TableFormatCSV {
+		return nil, fmt.Errorf("unknown table format: %s", c.TableFormat)
+	}
+
+	return &Parser{
+		conf: &c,
+	}, nil
+}
+
+// Parser implements parser.Parser. It parses a PowerPoint (.pptx) presentation to Markdown text.
+// The title of every slide is rendered as a Markdown header, text of body placeholders as list items,
+// and tables as Markdown or CSV. Slide number, date and footer placeholders are skipped.
+//
+// Register it to parser.ExtParser with the ".pptx" extension to use it with the file loader.
+type Parser struct {
+	conf *Config
+}
+
+func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	pkg, err := openPackage(reader, p.conf.MaxSize, p.conf.MaxPartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := slideParts(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := map[string]any{}
+	title, author, err := pkg.coreProperties()
+	if err != nil {
+		return nil, err
+	}
+	if title != "" {
+		meta[MetaKeyTitle] = title
+	}
+	if author != "" {
+		meta[MetaKeyAuthor] = author
+	}
+	if option.URI != "" {
+		meta[MetaKeySource] = option.URI
+	}
+	for k, v := range option.ExtraMeta {
+		meta[k] = v
+	}
+
+	var (
+		docs  []*schema.Document
+		texts []string
+	)
+	for i, part := range parts {
+		s, err := p.readSlide(pkg, part)
+		if err != nil {
+			return nil, fmt.Errorf("read slide %d failed: %w", i+1, err)
+		}
+		if s.hidden && !p.conf.IncludeHidden {
+			continue
+		}
+
+		content := s.content(p.conf.IncludeNotes)
+		if p.conf.ToSlides {
+			m := make(map[string]any, len(meta)+3)
+			for k, v := range meta {
+				m[k] = v
+			}
+			m[MetaKeySlide] = i + 1
+			if s.title != "" {
+				m[MetaKeySlideTitle] = s.title
+			}
+			if s.notes != "" {
+				m[MetaKeyNotes] = s.notes
+			}
+			docs = append(docs, &schema.Document{
+				Content:  content,
+				MetaData: m,
+			})
+			continue
+		}
+		if content != "" {
+			texts = append(texts, content)
+		}
+	}
+
+	if !p.conf.ToSlides {
+		docs = append(docs, &schema.Document{
+			Content:  strings.Join(texts, "\n\n"),
+			MetaData: meta,
+		})
+	}
+
+	return docs, nil
+}
+
+// slideParts returns the slide part names in presentation order.
+func slideParts(pkg *ooxmlPackage) ([]string, error) {
+	const part = "ppt/presentation.xml"
+	if !pkg.has(part) {
+		return nil, fmt.Errorf("invalid pptx: part %s not found", part)
+	}
+
+	rels, err := pkg.relationships(part)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := pkg.open(part)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var (
+		ret []string
+		dec = xml.NewDecoder(rc)
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode part %s failed: %w", part, err)
+		}
+
+		t, ok := tok.(xml.StartElement)
+		if !ok || t.Name.Local != "sldId" {
+			continue
+		}
+		// sldId has both id and r:id attributes, only the namespaced one refers to a relationship.
+		for _, a := range t.Attr {
+			if a.Name.Local != "id" || a.Name.Space == "" {
+				continue
+			}
+			if rel, ok := rels[a.Value]; ok {
+				ret = append(ret, rel.Target)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+type slide struct {
+	hidden bool
+	title  string
+	blocks []string
+	notes  string
+}
+
+func (s *slide) content(includeNotes bool) string {
+	texts := make([]string, 0, len(s.blocks)+2)
+	if s.title != "" {
+		texts = append(texts, "# "+s.title)
+	}
+	texts = append(texts, s.blocks...)
+	if includeNotes && s.notes != "" {
+		texts = append(texts, "Notes:\n"+s.notes)
+	}
+
+	return strings.Join(texts, "\n\n")
+}
+
+func (p *Parser) readSlide(pkg *ooxmlPackage, part string) (*slide, error) {
+	shapes, hidden, err := p.readShapes(pkg, part)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &slide{hidden: hidden}
+	for _, sh := range shapes {
+		switch {
+		case sh.isTitle():
+			if s.title == "" {
+				s.title = strings.Join(strings.Fields(sh.text(false)), " ")
+				continue
+			}
+			s.blocks = append(s.blocks, sh.text(false))
+		case sh.table != nil:
+			s.blocks = append(s.blocks, renderTable(sh.table, p.conf.TableFormat == TableFormatCSV))
+		default:
+			if text := sh.text(sh.isBody()); text != "" {
+				s.blocks = append(s.blocks, text)
+			}
+		}
+	}
+
+	rels, err := pkg.relationships(part)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range rels {
+		if !strings.HasSuffix(rel.Type, relTypeNotesSlide) {
+			continue
+		}
+		notes, _, err := p.readShapes(pkg, rel.Target)
+		if err != nil {
+			return nil, fmt.Errorf("read notes failed: %w", err)
+		}
+		var texts []string
+		for _, sh := range notes {
+			// the notes page also has the slide image and the slide number.
+			if sh.placeholder == "body" {
+				if text := sh.text(false); text != "" {
+					texts = append(texts, text)
+				}
+			}
+		}
+		s.notes = strings.Join(texts, "\n\n")
+		break
+	}
+
+	return s, nil
+}
+
+type paragraph struct {
+	level int
+	text  string
+}
+
+type shape struct {
+	placeholder string
+	isPH        bool
+	paragraphs  []*paragraph
+	table       [][]string
+}
+
+func (sh *shape) isTitle() bool {
+	return sh.placeholder == "title" || sh.placeholder == "ctrTitle"
+}
+
+// isBody reports whether the shape is a body placeholder, whose paragraphs are bullets by default.
+func (sh *shape) isBody() bool {
+	return sh.isPH && (sh.placeholder == "" || sh.placeholder == "body" || sh.placeholder == "obj")
+}
+
+func (sh *shape) text(bullets bool) string {
+	lines := make([]string, 0, len(sh.paragraphs))
+	for _, para := range sh.paragraphs {
+		if bullets {
+			lines = append(lines, strings.Repeat("  ", para.level)+"- "+para.text)
+		} else {
+			lines = append(lines, para.text)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// skippedPlaceholders are placeholders whose text is generated rather than content.
+var skippedPlaceholders = map[string]bool{
+	"sldNum": true,
+	"dt":     true,
+	"ftr":    true,
+	"hdr":    true,
+	"sldImg": true,
+}
+
+// readShapes walks the shape tree of a slide or notes slide, and returns the shapes with text in order.
+func (p *Parser) readShapes(pkg *ooxmlPackage, part string) (shapes []*shape, hidden bool, err error) {
+	rc, err := pkg.open(part)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rc.Close()
+
+	var (
+		dec   = xml.NewDecoder(rc)
+		cur   *shape
+		para  *paragraph
+		sb    strings.Builder
+		row   []string
+		cell  []string
+		inTbl bool
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "sld":
+				hidden = attrValue(t, "show") == "0"
+			case "Fallback":
+				if err = dec.Skip(); err != nil {
+					return nil, false, err
+				}
+			case "sp", "graphicFrame":
+				cur = &shape{}
+			case "ph":
+				if cur != nil {
+					cur.isPH = true
+					cur.placeholder = attrValue(t, "type")
+				}
+			case "tbl":
+				inTbl = true
+				if cur != nil {
+					cur.table = [][]string{}
+				}
+			case "tr":
+				row = nil
+			case "tc":
+				cell = nil
+			case "p":
+				para = &paragraph{}
+				sb.Reset()
+			case "pPr":
+				if para != nil {
+					para.level, _ = strconv.Atoi(attrValue(t, "lvl"))
+				}
+			case "t":
+				var s string
+				if err = dec.DecodeElement(&s, &t); err != nil {
+					return nil, false, err
+				}
+				sb.WriteString(s)
+			case "br":
+				sb.WriteString("\n")
+			}
+
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "p":
+				if para == nil || cur == nil {
+					continue
+				}
+				para.text = strings.TrimSpace(sb.String())
+				if para.text != "" {
+					if inTbl {
+						cell = append(cell, para.text)
+					} else {
+						cur.paragraphs = append(cur.paragraphs, para)
+					}
+				}
+				para = nil
+			case "tc":
+				sep := " "
+				if p.conf.TableFormat == TableFormatCSV {
+					sep = "\n"
+				}
+				row = append(row, strings.Join(cell, sep))
+			case "tr":
+				if cur != nil && cur.table != nil {
+					cur.table = append(cur.table, row)
+				}
+			case "tbl":
+				inTbl = false
+			case "sp", "graphicFrame":
+				if cur != nil && !skippedPlaceholders[cur.placeholder] &&
+					(len(cur.paragraphs) > 0 || len(cur.table) > 0) {
+					shapes = append(shapes, cur)
+				}
+				cur = nil
+			}
+		}
+	}
+
+	return shapes, hidden, nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPresentation = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:presentation xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:sldIdLst><p:sldId id="257" r:id="rId3"/><p:sldId id="256" r:id="rId2"/><p:sldId id="258" r:id="rId4"/></p:sldIdLst>
+</p:presentation>`
+
+	testPresentationRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide1.xml"/>
+  <Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide2.xml"/>
+  <Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide3.xml"/>
+</Relationships>`
+
+	testSlide1 = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><p:cSld><p:spTree>
+  <p:sp><p:nvSpPr><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr>
+    <p:txBody><a:p><a:r><a:t>Quarterly</a:t></a:r><a:br/><a:r><a:t>Review</a:t></a:r></a:p></p:txBody></p:sp>
+  <p:sp><p:nvSpPr><p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr>
+    <p:txBody><a:p><a:r><a:t>Revenue up</a:t></a:r></a:p><a:p><a:pPr lvl="1"/><a:r><a:t>mostly </a:t></a:r><a:r><a:t>Asia</a:t></a:r></a:p><a:p/></p:txBody></p:sp>
+  <p:sp><p:nvSpPr><p:nvPr/></p:nvSpPr><p:txBody><a:p><a:r><a:t>Free text box</a:t></a:r></a:p></p:txBody></p:sp>
+  <p:sp><p:nvSpPr><p:nvPr><p:ph type="sldNum"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:fld><a:t>2</a:t></a:fld></a:p></p:txBody></p:sp>
+  <p:graphicFrame><a:graphic><a:graphicData><a:tbl>
+    <a:tr><a:tc><a:txBody><a:p><a:r><a:t>Region</a:t></a:r></a:p></a:txBody></a:tc><a:tc><a:txBody><a:p><a:r><a:t>Growth</a:t></a:r></a:p></a:txBody></a:tc></a:tr>
+    <a:tr><a:tc><a:txBody><a:p><a:r><a:t>Asia</a:t></a:r></a:p></a:txBody></a:tc><a:tc><a:txBody><a:p><a:r><a:t>12%</a:t></a:r></a:p></a:txBody></a:tc></a:tr>
+  </a:tbl></a:graphicData></a:graphic></p:graphicFrame>
+</p:spTree></p:cSld></p:sld>`
+
+	testSlide1Rels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideLayout" Target="../slideLayouts/slideLayout2.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/>
+</Relationships>`
+
+	testNotes1 = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:notes xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><p:cSld><p:spTree>
+  <p:sp><p:nvSpPr><p:nvPr><p:ph type="sldImg"/></p:nvPr></p:nvSpPr></p:sp>
+  <p:sp><p:nvSpPr><p:nvPr><p:ph type="body" idx="1"/></p:nvPr></p:nvSpPr>
+    <p:txBody><a:p><a:r><a:t>Mention the new office.</a:t></a:r></a:p></p:txBody></p:sp>
+  <p:sp><p:nvSpPr><p:nvPr><p:ph type="sldNum" idx="5"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>2</a:t></a:r></a:p></p:txBody></p:sp>
+</p:spTree></p:cSld></p:notes>`
+
+	testSlide2 = `<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"
+  xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><p:cSld><p:spTree>
+  <p:sp><p:nvSpPr><p:nvPr><p:ph type="ctrTitle"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>Welcome</a:t></a:r></a:p></p:txBody></p:sp>
+  <p:sp><p:nvSpPr><p:nvPr><p:ph type="subTitle" idx="1"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>2024</a:t></a:r></a:p></p:txBody></p:sp>
+</p:spTree></p:cSld></p:sld>`
+
+	testSlide3 = `<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" show="0"
+  xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><p:cSld><p:spTree>
+  <p:sp><p:nvSpPr><p:nvPr/></p:nvSpPr><p:txBody><a:p><a:r><a:t>Backup</a:t></a:r></a:p></p:txBody></p:sp>
+</p:spTree></p:cSld></p:sld>`
+)
+
+func buildPresentation(t *testing.T) *bytes.Reader {
+	parts := map[string]string{
+		"ppt/presentation.xml":              testPresentation,
+		"ppt/_rels/presentation.xml.rels":   testPresentationRels,
+		"ppt/slides/slide1.xml":             testSlide1,
+		"ppt/slides/_rels/slide1.xml.rels":  testSlide1Rels,
+		"ppt/notesSlides/notesSlide1.xml":   testNotes1,
+		"ppt/slides/slide2.xml":             testSlide2,
+		"ppt/slides/slide3.xml":             testSlide3,
+		"docProps/core.xml":                 `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Deck</dc:title></cp:coreProperties>`,
+		"ppt/slideLayouts/slideLayout2.xml": `<p:sldLayout xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"/>`,
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("presentation", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{IncludeNotes: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, buildPresentation(t), parser.WithURI("deck.pptx"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "# Welcome\n\n2024\n\n"+
+			"# Quarterly Review\n\n"+
+			"- Revenue up\n  - mostly Asia\n\n"+
+			"Free text box\n\n"+
+			"| Region | Growth |\n| --- | --- |\n| Asia | 12% |\n\n"+
+			"Notes:\nMention the new office.", docs[0].Content)
+		assert.Equal(t, map[string]any{MetaKeyTitle: "Deck", MetaKeySource: "deck.pptx"}, docs[0].MetaData)
+	})
+
+	t.Run("slides", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{ToSlides: true, IncludeHidden: true, TableFormat: TableFormatCSV})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, buildPresentation(t))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeySlide])
+		assert.Equal(t, "Welcome", docs[0].MetaData[MetaKeySlideTitle])
+
+		assert.Equal(t, "# Quarterly Review\n\n- Revenue up\n  - mostly Asia\n\nFree text box\n\nRegion,Growth\nAsia,12%", docs[1].Content)
+		assert.Equal(t, "Mention the new office.", docs[1].MetaData[MetaKeyNotes])
+		assert.Equal(t, "Deck", docs[1].MetaData[MetaKeyTitle])
+
+		assert.Equal(t, "Backup", docs[2].Content)
+		assert.Equal(t, 3, docs[2].MetaData[MetaKeySlide])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewParser(ctx, &Config{TableFormat: "xml"})
+		assert.Error(t, err)
+
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, bytes.NewReader([]byte("not a zip")))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,343 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package records
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySource = "_source"
+	// MetaKeyRow is the zero based index of the record, the header of a CSV is not counted.
+	MetaKeyRow = "_row"
+)
+
+// Format is the format of the records.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatTSV   Format = "tsv"
+	FormatJSONL Format = "jsonl"
+)
+
+const maxLineSize = 16 * 1024 * 1024
+
+var _ parser.Parser = (*Parser)(nil)
+
+type Config struct {
+	// Format of the records.
+	// Detected by the extension of the uri if not set (.csv, .tsv, .jsonl and .ndjson), FormatCSV by default.
+	Format Format
+	// Comma is the field delimiter of CSV, ',' by default and '\t' for FormatTSV.
+	Comma rune
+	// NoHeader if true, the first row of a CSV is a record, and columns are named "0", "1", ...
+	NoHeader bool
+
+	// ContentColumns are the columns forming the content of the document.
+	// If there is a single column, the content is its value, otherwise "column: value" lines.
+	// Default is all the columns, in the order of the header or of the JSON object.
+	// Nested fields of JSONL are addressed with dots, e.g. "author.name".
+	ContentColumns []string
+	// MetadataColumns are the columns copied into the meta data, keyed by column name.
+	MetadataColumns []string
+	// IDColumn if set, its value is used as the document id.
+	IDColumn string
+	// SkipEmpty if true, records with empty content are skipped.
+	SkipEmpty bool
+}
+
+// NewParser returns a new parser of CSV and JSONL records.
+func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+
+	switch conf.Format {
+	case "", FormatCSV, FormatTSV, FormatJSONL:
+	default:
+		return nil, fmt.Errorf("unknown format: %s", conf.Format)
+	}
+
+	return &Parser{
+		conf: conf,
+	}, nil
+}
+
+// Parser implements parser.Parser. It parses every CSV row or JSONL line into a document.
+type Parser struct {
+	conf *Config
+}
+
+// record is a parsed row, values of CSV are strings, values of JSONL are decoded JSON values.
+type record struct {
+	columns []string
+	values  map[string]any
+}
+
+func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	var (
+		records []*record
+		err     error
+	)
+	switch format := p.format(option.URI); format {
+	case FormatJSONL:
+		records, err = readJSONL(reader)
+	default:
+		comma := p.conf.Comma
+		if comma == 0 {
+			comma = ','
+			if format == FormatTSV {
+				comma = '\t'
+			}
+		}
+		records, err = readCSV(reader, comma, p.conf.NoHeader)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]*schema.Document, 0, len(records))
+	for i, rec := range records {
+		content := p.content(rec)
+		if p.conf.SkipEmpty && strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		meta := make(map[string]any, len(p.conf.MetadataColumns)+len(option.ExtraMeta)+2)
+		for _, col := range p.conf.MetadataColumns {
+			if v, ok := rec.lookup(col); ok {
+				meta[col] = v
+			}
+		}
+		meta[MetaKeyRow] = i
+		if option.URI != "" {
+			meta[MetaKeySource] = option.URI
+		}
+		for k, v := range option.ExtraMeta {
+			meta[k] = v
+		}
+
+		doc := &schema.Document{
+			Content:  content,
+			MetaData: meta,
+		}
+		if p.conf.IDColumn != "" {
+			if v, ok := rec.lookup(p.conf.IDColumn); ok {
+				doc.ID = stringify(v)
+			}
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func (p *Parser) format(uri string) Format {
+	if p.conf.Format != "" {
+		return p.conf.Format
+	}
+
+	switch strings.ToLower(path.Ext(uri)) {
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	case ".tsv":
+		return FormatTSV
+	default:
+		return FormatCSV
+	}
+}
+
+func (p *Parser) content(rec *record) string {
+	columns := p.conf.ContentColumns
+	if len(columns) == 0 {
+		columns = rec.columns
+	}
+
+	if len(columns) == 1 {
+		v, _ := rec.lookup(columns[0])
+		return stringify(v)
+	}
+
+	lines := make([]string, 0, len(columns))
+	for _, col := range columns {
+		v, ok := rec.lookup(col)
+		if !ok {
+			continue
+		}
+		lines = append(lines, col+": "+stringify(v))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// lookup returns the value of a column, dots address nested objects if there is no such column.
+func (r *record) lookup(col string) (any, bool) {
+	if v, ok := r.values[col]; ok {
+		return v, true
+	}
+
+	var cur any = r.values
+	for _, key := range strings.Split(col, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+func stringify(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(b)
+	}
+}
+
+func readCSV(reader io.Reader, comma rune, noHeader bool) ([]*record, error) {
+	r := csv.NewReader(reader)
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var (
+		header  []string
+		records []*record
+	)
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv failed: %w", err)
+		}
+
+		if header == nil && !noHeader {
+			header = make([]string, len(row))
+			for i, h := range row {
+				header[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+			}
+			continue
+		}
+
+		rec := &record{values: make(map[string]any, len(row))}
+		for i, v := range row {
+			name := strconv.Itoa(i)
+			if i < len(header) && header[i] != "" {
+				name = header[i]
+			}
+			rec.columns = append(rec.columns, name)
+			rec.values[name] = v
+		}
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+func readJSONL(reader io.Reader) ([]*record, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var records []*record
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		columns, values, err := decodeObject(dec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid json at line %d: %w", line, err)
+		}
+		records = append(records, &record{columns: columns, values: values})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read jsonl failed: %w", err)
+	}
+
+	return records, nil
+}
+
+// decodeObject decodes a JSON object and keeps the order of its keys.
+func decodeObject(dec *json.Decoder) ([]string, map[string]any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, nil, errors.New("not a json object")
+	}
+
+	var (
+		columns []string
+		values  = make(map[string]any)
+	)
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := tok.(string)
+
+		var v any
+		if err = dec.Decode(&v); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := values[key]; !ok {
+			columns = append(columns, key)
+		}
+		values[key] = v
+	}
+	if _, err = dec.Token(); err != nil {
+		return nil, nil, err
+	}
+
+	return columns, values, nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package records
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all columns", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("\ufeffid,title,body\n1,hello,\"a, b\"\n2,world,c\n"),
+			parser.WithURI("data.csv"))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, "id: 1\ntitle: hello\nbody: a, b", docs[0].Content)
+		assert.Equal(t, 0, docs[0].MetaData[MetaKeyRow])
+		assert.Equal(t, "data.csv", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, 1, docs[1].MetaData[MetaKeyRow])
+	})
+
+	t.Run("content, metadata and id columns", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{
+			ContentColumns:  []string{"body"},
+			MetadataColumns: []string{"title"},
+			IDColumn:        "id",
+			SkipEmpty:       true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("id,title,body\n1,hello,text\n2,empty,\n"))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "1", docs[0].ID)
+		assert.Equal(t, "text", docs[0].Content)
+		assert.Equal(t, "hello", docs[0].MetaData["title"])
+	})
+
+	t.Run("tsv without header", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{NoHeader: true, ContentColumns: []string{"1"}})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("a\tb\nc\td\n"), parser.WithURI("x.tsv"))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, "b", docs[0].Content)
+		assert.Equal(t, "d", docs[1].Content)
+	})
+}
+
+func TestParseJSONL(t *testing.T) {
+	ctx := context.Background()
+
+	input := `{"id": 7, "text": "first", "author": {"name": "ann"}, "score": 1.5}
+
+{"id": 8, "text": "second", "author": {"name": "bob"}, "tags": ["x"]}
+`
+
+	t.Run("dotted columns", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{
+			ContentColumns:  []string{"text"},
+			MetadataColumns: []string{"author.name", "score"},
+			IDColumn:        "id",
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(input), parser.WithURI("data.jsonl"))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, "7", docs[0].ID)
+		assert.Equal(t, "first", docs[0].Content)
+		assert.Equal(t, "ann", docs[0].MetaData["author.name"])
+		assert.Equal(t, "1.5", docs[0].MetaData["score"].(interface{ String() string }).String())
+		assert.Equal(t, "bob", docs[1].MetaData["author.name"])
+		_, ok := docs[1].MetaData["score"]
+		assert.False(t, ok)
+	})
+
+	t.Run("all columns keep key order", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{Format: FormatJSONL})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(input))
+		assert.NoError(t, err)
+		assert.Equal(t, "id: 8\ntext: second\nauthor: {\"name\":\"bob\"}\ntags: [\"x\"]", docs[1].Content)
+	})
+
+	t.Run("invalid line", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{Format: FormatJSONL})
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("{\"a\":1}\n[1]\n"))
+		assert.ErrorContains(t, err, "line 2")
+	})
+}
+
+func TestNewParser(t *testing.T) {
+	_, err := NewParser(context.Background(), &Config{Format: "xml"})
+	assert.Error(t, err)
+}
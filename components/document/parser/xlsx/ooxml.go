@@ -0,0 +1,262 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	defaultMaxSize     = 100 << 20
+	defaultMaxPartSize = 100 << 20
+)
+
+var (
+	// ErrTooLarge is returned when the file is larger than the max size.
+	ErrTooLarge = errors.New("file too large")
+	// ErrPartTooLarge is returned when the uncompressed size of a part is larger than the max part size.
+	ErrPartTooLarge = errors.New("part too large")
+)
+
+// ooxmlPackage is the zip container of an Office Open XML file.
+type ooxmlPackage struct {
+	files       map[string]*zip.File
+	maxPartSize int64
+}
+
+// openPackage reads at most maxSize bytes from reader as a zip package, parts larger than maxPartSize
+// once uncompressed fail to open or read. Zero limits use defaultMaxSize and defaultMaxPartSize.
+func openPackage(reader io.Reader, maxSize, maxPartSize int64) (*ooxmlPackage, error) {
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	if maxPartSize <= 0 {
+		maxPartSize = defaultMaxPartSize
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read all from reader failed: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, maxSize)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open zip package failed: %w", err)
+	}
+
+	pkg := &ooxmlPackage{
+		files:       make(map[string]*zip.File, len(zr.File)),
+		maxPartSize: maxPartSize,
+	}
+	for _, f := range zr.File {
+		pkg.files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+
+	return pkg, nil
+}
+
+// has reports whether the package contains the part.
+func (p *ooxmlPackage) has(name string) bool {
+	_, ok := p.files[name]
+	return ok
+}
+
+// open opens a part for reading, reading more than the max part size fails with ErrPartTooLarge,
+// even if the size recorded in the zip header is smaller.
+func (p *ooxmlPackage) open(name string) (io.ReadCloser, error) {
+	f, ok := p.files[name]
+	if !ok {
+		return nil, fmt.Errorf("part %s not found", name)
+	}
+	if f.UncompressedSize64 > uint64(p.maxPartSize) {
+		return nil, fmt.Errorf("%w: %s has %d bytes", ErrPartTooLarge, name, f.UncompressedSize64)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &partReader{ReadCloser: rc, name: name, remaining: p.maxPartSize}, nil
+}
+
+// decode unmarshals a part into v, a missing part leaves v untouched.
+func (p *ooxmlPackage) decode(name string, v any) error {
+	if !p.has(name) {
+		return nil
+	}
+
+	rc, err := p.open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err = xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("decode part %s failed: %w", name, err)
+	}
+
+	return nil
+}
+
+// relationship is a relationship of a part.
+type relationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+	Mode   string `xml:"TargetMode,attr"`
+}
+
+// relationships returns the relationships of a part by id, with targets resolved to part names.
+func (p *ooxmlPackage) relationships(part string) (map[string]*relationship, error) {
+	var rels struct {
+		Relationships []*relationship `xml:"Relationship"`
+	}
+	dir, base := path.Split(part)
+	if err := p.decode(path.Join(dir, "_rels", base+".rels"), &rels); err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]*relationship, len(rels.Relationships))
+	for _, r := range rels.Relationships {
+		if r.Mode != "External" {
+			if strings.HasPrefix(r.Target, "/") {
+				r.Target = strings.TrimPrefix(r.Target, "/")
+			} else {
+				r.Target = path.Join(dir, r.Target)
+			}
+		}
+		ret[r.ID] = r
+	}
+
+	return ret, nil
+}
+
+// coreProperties returns the title and the creator in docProps/core.xml.
+func (p *ooxmlPackage) coreProperties() (title, creator string, err error) {
+	var core struct {
+		Title   string `xml:"title"`
+		Creator string `xml:"creator"`
+	}
+	if err = p.decode("docProps/core.xml", &core); err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(core.Title), strings.TrimSpace(core.Creator), nil
+}
+
+// partReader fails with ErrPartTooLarge once more than remaining bytes are read.
+type partReader struct {
+	io.ReadCloser
+	name      string
+	remaining int64
+}
+
+func (r *partReader) Read(b []byte) (int, error) {
+	if r.remaining <= 0 {
+		// the limit is reached, the part must end here.
+		var one [1]byte
+		n, err := r.ReadCloser.Read(one[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: %s", ErrPartTooLarge, r.name)
+		}
+		return 0, err
+	}
+
+	if int64(len(b)) > r.remaining {
+		b = b[:r.remaining]
+	}
+	n, err := r.ReadCloser.Read(b)
+	r.remaining -= int64(n)
+
+	return n, err
+}
+
+// renderTable renders rows as a Markdown table with the first row as header, or as CSV.
+func renderTable(rows [][]string, csvFormat bool) string {
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	if width == 0 {
+		return ""
+	}
+
+	if csvFormat {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		for _, row := range rows {
+			_ = w.Write(padRow(row, width))
+		}
+		w.Flush()
+		return strings.TrimSuffix(buf.String(), "\n")
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for _, c := range cells {
+			c = strings.ReplaceAll(c, "|", `\|`)
+			c = strings.ReplaceAll(c, "\n", " ")
+			sb.WriteString(" " + c + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(padRow(rows[0], width))
+	sep := make([]string, width)
+	for i := range sep {
+		sep[i] = "---"
+	}
+	writeRow(sep)
+	for _, row := range rows[1:] {
+		writeRow(padRow(row, width))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func padRow(row []string, width int) []string {
+	if len(row) >= width {
+		return row
+	}
+	ret := make([]string, width)
+	copy(ret, row)
+	return ret
+}
+
+// attrValue returns the value of the attribute by local name, ignoring the namespace.
+func attrValue(el xml.StartElement, local string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
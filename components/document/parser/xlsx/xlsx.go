@@ -0,0 +1,532 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xlsx
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySource     = "_source"
+	MetaKeyTitle      = "_title"
+	MetaKeyAuthor     = "_author"
+	MetaKeySheet      = "_sheet"
+	MetaKeySheetIndex = "_sheet_index"
+)
+
+// TableFormat is how sheets are rendered in the document content.
+type TableFormat string
+
+const (
+	TableFormatMarkdown TableFormat = "markdown"
+	TableFormatCSV      TableFormat = "csv"
+)
+
+var _ parser.Parser = (*Parser)(nil)
+
+type Config struct {
+	// ToSheets if true, every sheet is parsed into a document.
+	ToSheets bool
+	// TableFormat of the sheets in the content, TableFormatMarkdown by default.
+	// The first row of a sheet is used as the header of a Markdown table.
+	TableFormat TableFormat
+	// IncludeHidden if true, hidden sheets are parsed as well.
+	IncludeHidden bool
+	// MaxSize is the max size in bytes of the file, larger files fail to parse.
+	// Default is 100MB.
+	MaxSize int64
+	// MaxPartSize is the max uncompressed size in bytes of a part of the zip package, it guards against zip bombs.
+	// Default is 100MB.
+	MaxPartSize int64
+}
+
+// NewParser returns a new xlsx parser.
+func NewParser(ctx context.Context, conf *Config) (*Parser, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+
+	c := *conf
+	if c.TableFormat == "" {
+		c.TableFormat = TableFormatMarkdown
+	}
+	if c.TableFormat != TableFormatMarkdown && c.TableFormat != TableFormatCSV {
+		return nil, fmt.Errorf("unknown table format: %s", c.TableFormat)
+	}
+
+	return &Parser{
+		conf: &c,
+	}, nil
+}
+
+// Parser implements parser.Parser. It parses an Excel (.xlsx) workbook to Markdown or CSV tables.
+// Cached values of formulas are used, and numbers formatted as dates are converted to dates.
+// Empty rows and columns around the data are dropped, empty sheets are skipped.
+//
+// Register it to parser.ExtParser with the ".xlsx" extension to use it with the file loader.
+type Parser struct {
+	conf *Config
+}
+
+func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	pkg, err := openPackage(reader, p.conf.MaxSize, p.conf.MaxPartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	wb, err := readWorkbook(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := map[string]any{}
+	title, author, err := pkg.coreProperties()
+	if err != nil {
+		return nil, err
+	}
+	if title != "" {
+		meta[MetaKeyTitle] = title
+	}
+	if author != "" {
+		meta[MetaKeyAuthor] = author
+	}
+	if option.URI != "" {
+		meta[MetaKeySource] = option.URI
+	}
+	for k, v := range option.ExtraMeta {
+		meta[k] = v
+	}
+
+	var (
+		docs     []*schema.Document
+		sections []string
+	)
+	for i, sheet := range wb.sheets {
+		if sheet.hidden && !p.conf.IncludeHidden {
+			continue
+		}
+
+		rows, err := wb.readSheet(pkg, sheet.part)
+		if err != nil {
+			return nil, fmt.Errorf("read sheet %s failed: %w", sheet.name, err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		table := renderTable(rows, p.conf.TableFormat == TableFormatCSV)
+
+		if p.conf.ToSheets {
+			m := make(map[string]any, len(meta)+2)
+			for k, v := range meta {
+				m[k] = v
+			}
+			m[MetaKeySheet] = sheet.name
+			m[MetaKeySheetIndex] = i
+			docs = append(docs, &schema.Document{
+				Content:  table,
+				MetaData: m,
+			})
+			continue
+		}
+		sections = append(sections, "## "+sheet.name+"\n\n"+table)
+	}
+
+	if !p.conf.ToSheets {
+		docs = append(docs, &schema.Document{
+			Content:  strings.Join(sections, "\n\n"),
+			MetaData: meta,
+		})
+	}
+
+	return docs, nil
+}
+
+type sheetInfo struct {
+	name   string
+	part   string
+	hidden bool
+}
+
+type workbook struct {
+	sheets  []*sheetInfo
+	strings []string
+	// dateStyles are the indexes of cell styles with a date or time number format.
+	dateStyles map[int]bool
+	date1904   bool
+}
+
+func readWorkbook(pkg *ooxmlPackage) (*workbook, error) {
+	const part = "xl/workbook.xml"
+	if !pkg.has(part) {
+		return nil, fmt.Errorf("invalid xlsx: part %s not found", part)
+	}
+
+	var doc struct {
+		Pr struct {
+			Date1904 string `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name  string `xml:"name,attr"`
+			State string `xml:"state,attr"`
+			RID   string `xml:"id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := pkg.decode(part, &doc); err != nil {
+		return nil, err
+	}
+
+	rels, err := pkg.relationships(part)
+	if err != nil {
+		return nil, err
+	}
+
+	wb := &workbook{date1904: doc.Pr.Date1904 == "1" || doc.Pr.Date1904 == "true"}
+	for _, s := range doc.Sheets {
+		rel, ok := rels[s.RID]
+		if !ok {
+			continue
+		}
+		wb.sheets = append(wb.sheets, &sheetInfo{
+			name:   s.Name,
+			part:   rel.Target,
+			hidden: s.State == "hidden" || s.State == "veryHidden",
+		})
+	}
+
+	var sharedStrings, styles string
+	for _, rel := range rels {
+		switch {
+		case strings.HasSuffix(rel.Type, "/sharedStrings"):
+			sharedStrings = rel.Target
+		case strings.HasSuffix(rel.Type, "/styles"):
+			styles = rel.Target
+		}
+	}
+	if sharedStrings != "" {
+		if wb.strings, err = readSharedStrings(pkg, sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+	if styles != "" {
+		if wb.dateStyles, err = readDateStyles(pkg, styles); err != nil {
+			return nil, err
+		}
+	}
+
+	return wb, nil
+}
+
+// readSharedStrings concatenates the rich text runs of every string item, phonetic hints are skipped.
+func readSharedStrings(pkg *ooxmlPackage, part string) ([]string, error) {
+	rc, err := pkg.open(part)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var (
+		ret []string
+		sb  strings.Builder
+		dec = xml.NewDecoder(rc)
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode part %s failed: %w", part, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				sb.Reset()
+			case "rPh":
+				if err = dec.Skip(); err != nil {
+					return nil, err
+				}
+			case "t":
+				var s string
+				if err = dec.DecodeElement(&s, &t); err != nil {
+					return nil, err
+				}
+				sb.WriteString(s)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "si" {
+				ret = append(ret, sb.String())
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// builtinDateFormats are the built-in number format ids of dates and times.
+var builtinDateFormats = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	27: true, 30: true, 36: true, 45: true, 46: true, 47: true, 50: true, 57: true,
+}
+
+var (
+	quotedOrBracketed = regexp.MustCompile(`"[^"]*"|\[[^\]]*\]|\\.`)
+	dateTokens        = regexp.MustCompile(`[dyhs]|m{1,5}`)
+)
+
+func readDateStyles(pkg *ooxmlPackage, part string) (map[int]bool, error) {
+	var doc struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		Xfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := pkg.decode(part, &doc); err != nil {
+		return nil, err
+	}
+
+	dateFormats := make(map[int]bool, len(doc.NumFmts))
+	for _, f := range doc.NumFmts {
+		dateFormats[f.ID] = isDateFormat(f.Code)
+	}
+
+	ret := make(map[int]bool)
+	for i, xf := range doc.Xfs {
+		isDate, ok := dateFormats[xf.NumFmtID]
+		if !ok {
+			isDate = builtinDateFormats[xf.NumFmtID]
+		}
+		if isDate {
+			ret[i] = true
+		}
+	}
+
+	return ret, nil
+}
+
+// isDateFormat reports whether a custom number format code displays dates or times.
+func isDateFormat(code string) bool {
+	code = strings.ToLower(quotedOrBracketed.ReplaceAllString(code, ""))
+	// only the positive section matters, the others usually repeat it.
+	code, _, _ = strings.Cut(code, ";")
+	if code == "general" {
+		return false
+	}
+
+	return dateTokens.MatchString(code)
+}
+
+// readSheet returns the cell values of a worksheet, rows and columns without any value around the data are dropped.
+func (wb *workbook) readSheet(pkg *ooxmlPackage, part string) ([][]string, error) {
+	rc, err := pkg.open(part)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	type cellXML struct {
+		Ref    string `xml:"r,attr"`
+		Type   string `xml:"t,attr"`
+		Style  int    `xml:"s,attr"`
+		Value  string `xml:"v"`
+		Inline struct {
+			Text string `xml:"t"`
+			Runs []struct {
+				Text string `xml:"t"`
+			} `xml:"r"`
+		} `xml:"is"`
+	}
+
+	var (
+		dec    = xml.NewDecoder(rc)
+		grid   = make(map[int]map[int]string)
+		rowIdx = -1
+		colIdx = -1
+		minCol = math.MaxInt32
+		maxCol = -1
+		minRow = math.MaxInt32
+		maxRow = -1
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch t.Name.Local {
+		case "row":
+			if r, err := strconv.Atoi(attrValue(t, "r")); err == nil {
+				rowIdx = r - 1
+			} else {
+				rowIdx++
+			}
+			colIdx = -1
+		case "c":
+			var c cellXML
+			if err = dec.DecodeElement(&c, &t); err != nil {
+				return nil, err
+			}
+			if row, col, ok := parseCellRef(c.Ref); ok {
+				rowIdx, colIdx = row, col
+			} else {
+				colIdx++
+			}
+
+			var v string
+			switch c.Type {
+			case "s":
+				if i, err := strconv.Atoi(c.Value); err == nil && i >= 0 && i < len(wb.strings) {
+					v = wb.strings[i]
+				}
+			case "inlineStr":
+				v = c.Inline.Text
+				for _, r := range c.Inline.Runs {
+					v += r.Text
+				}
+			case "b":
+				v = "FALSE"
+				if c.Value == "1" {
+					v = "TRUE"
+				}
+			case "str", "e":
+				v = c.Value
+			default:
+				v = wb.formatNumber(c.Value, c.Style)
+			}
+			v = strings.TrimSpace(v)
+			if v == "" || rowIdx < 0 {
+				continue
+			}
+
+			if grid[rowIdx] == nil {
+				grid[rowIdx] = make(map[int]string)
+			}
+			grid[rowIdx][colIdx] = v
+			minRow, maxRow = minInt(minRow, rowIdx), maxInt(maxRow, rowIdx)
+			minCol, maxCol = minInt(minCol, colIdx), maxInt(maxCol, colIdx)
+		}
+	}
+
+	if maxRow < 0 {
+		return nil, nil
+	}
+
+	rows := make([][]string, 0, maxRow-minRow+1)
+	for r := minRow; r <= maxRow; r++ {
+		cells, ok := grid[r]
+		if !ok {
+			continue
+		}
+		row := make([]string, maxCol-minCol+1)
+		for c, v := range cells {
+			row[c-minCol] = v
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (wb *workbook) formatNumber(v string, style int) string {
+	if v == "" || !wb.dateStyles[style] {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			// Excel displays at most 15 significant digits.
+			f, _ = strconv.ParseFloat(strconv.FormatFloat(f, 'g', 15, 64), 64)
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return v
+	}
+
+	serial, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return v
+	}
+
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if wb.date1904 {
+		base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	days := math.Floor(serial)
+	secs := math.Round((serial - days) * 86400)
+	t := base.AddDate(0, 0, int(days)).Add(time.Duration(secs) * time.Second)
+
+	switch {
+	case secs == 0:
+		return t.Format("2006-01-02")
+	case days == 0:
+		return t.Format("15:04:05")
+	default:
+		return t.Format("2006-01-02 15:04:05")
+	}
+}
+
+// parseCellRef parses references such as "B12" into zero based row and column.
+func parseCellRef(ref string) (row, col int, ok bool) {
+	i := 0
+	col = 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		col = col*26 + int(ref[i]-'A'+1)
+		i++
+	}
+	if i == 0 || i == len(ref) {
+		return 0, 0, false
+	}
+	r, err := strconv.Atoi(ref[i:])
+	if err != nil || r < 1 {
+		return 0, 0, false
+	}
+
+	return r - 1, col - 1, true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
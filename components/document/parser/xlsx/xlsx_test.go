@@ -0,0 +1,175 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <workbookPr/>
+  <sheets>
+    <sheet name="Sales" sheetId="1" r:id="rId1"/>
+    <sheet name="Secret" sheetId="2" state="hidden" r:id="rId2"/>
+    <sheet name="Empty" sheetId="3" r:id="rId3"/>
+  </sheets>
+</workbook>`
+
+	testWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>
+  <Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet3.xml"/>
+  <Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>
+  <Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+	testSharedStrings = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>Item</t></si>
+  <si><t>Date</t></si>
+  <si><r><t>Ri</t></r><r><t>ch</t></r><rPh><t>ignored</t></rPh></si>
+  <si><t>Price</t></si>
+</sst>`
+
+	testStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <numFmts><numFmt numFmtId="164" formatCode="yyyy/mm/dd;@"/><numFmt numFmtId="165" formatCode="&quot;$&quot;#,##0.00"/></numFmts>
+  <cellXfs>
+    <xf numFmtId="0"/>
+    <xf numFmtId="164"/>
+    <xf numFmtId="22"/>
+    <xf numFmtId="165"/>
+  </cellXfs>
+</styleSheet>`
+
+	testSheet1 = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="2"><c r="B2" t="s"><v>0</v></c><c r="C2" t="s"><v>1</v></c><c r="D2" t="s"><v>3</v></c></row>
+    <row r="3"><c r="B3" t="s"><v>2</v></c><c r="C3" s="1"><v>45292</v></c><c r="D3" s="3"><v>3.5000000000000004</v></c></row>
+    <row r="4"><c r="B4" t="inlineStr"><is><t>a|b</t></is></c><c r="C4" s="2"><v>45292.5</v></c><c r="D4"><f>SUM(D3)</f><v>12</v></c></row>
+    <row r="5"><c r="B5" t="b"><v>1</v></c><c r="E5" t="e"><v>#DIV/0!</v></c></row>
+    <row r="6"><c r="B6" t="str"><v> </v></c></row>
+  </sheetData>
+</worksheet>`
+
+	testSheet2 = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData><row><c t="inlineStr"><is><t>hidden</t></is></c><c t="inlineStr"><is><t>value</t></is></c></row></sheetData>
+</worksheet>`
+
+	testSheet3 = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`
+)
+
+func buildWorkbook(t *testing.T) *bytes.Reader {
+	parts := map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testWorkbookRels,
+		"xl/sharedStrings.xml":       testSharedStrings,
+		"xl/styles.xml":              testStyles,
+		"xl/worksheets/sheet1.xml":   testSheet1,
+		"xl/worksheets/sheet2.xml":   testSheet2,
+		"xl/worksheets/sheet3.xml":   testSheet3,
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("workbook", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, buildWorkbook(t), parser.WithURI("sales.xlsx"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "## Sales\n\n"+
+			"| Item | Date | Price |  |\n"+
+			"| --- | --- | --- | --- |\n"+
+			"| Rich | 2024-01-01 | 3.5 |  |\n"+
+			"| a\\|b | 2024-01-01 12:00:00 | 12 |  |\n"+
+			"| TRUE |  |  | #DIV/0! |", docs[0].Content)
+		assert.Equal(t, map[string]any{MetaKeySource: "sales.xlsx"}, docs[0].MetaData)
+	})
+
+	t.Run("sheets", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{ToSheets: true, TableFormat: TableFormatCSV, IncludeHidden: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, buildWorkbook(t), parser.WithExtraMeta(map[string]any{"k": "v"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "Item,Date,Price,\nRich,2024-01-01,3.5,\na|b,2024-01-01 12:00:00,12,\nTRUE,,,#DIV/0!", docs[0].Content)
+		assert.Equal(t, "Sales", docs[0].MetaData[MetaKeySheet])
+		assert.Equal(t, 0, docs[0].MetaData[MetaKeySheetIndex])
+		assert.Equal(t, "hidden,value", docs[1].Content)
+		assert.Equal(t, "Secret", docs[1].MetaData[MetaKeySheet])
+		assert.Equal(t, 1, docs[1].MetaData[MetaKeySheetIndex])
+		assert.Equal(t, "v", docs[1].MetaData["k"])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewParser(ctx, &Config{TableFormat: "xml"})
+		assert.Error(t, err)
+
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, bytes.NewReader([]byte("not a zip")))
+		assert.Error(t, err)
+	})
+}
+
+func TestIsDateFormat(t *testing.T) {
+	assert.True(t, isDateFormat("yyyy-mm-dd"))
+	assert.True(t, isDateFormat("[$-409]h:mm AM/PM"))
+	assert.False(t, isDateFormat(`"$"#,##0.00`))
+	assert.False(t, isDateFormat("0.00%"))
+	assert.False(t, isDateFormat("General"))
+	assert.False(t, isDateFormat(`0 "days"`))
+}
+
+func TestParseCellRef(t *testing.T) {
+	row, col, ok := parseCellRef("AB12")
+	assert.True(t, ok)
+	assert.Equal(t, 11, row)
+	assert.Equal(t, 27, col)
+
+	_, _, ok = parseCellRef("12")
+	assert.False(t, ok)
+	_, _, ok = parseCellRef("A")
+	assert.False(t, ok)
+}
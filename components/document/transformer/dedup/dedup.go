@@ -0,0 +1,183 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Algorithm is the fingerprint algorithm to estimate similarity of contents.
+type Algorithm string
+
+const (
+	// AlgorithmMinHash estimates the jaccard similarity of shingle sets, candidates are found by locality sensitive hashing.
+	AlgorithmMinHash Algorithm = "minhash"
+	// AlgorithmSimHash compares 64 bits fingerprints, similarity is 1 - hamming distance / 64.
+	AlgorithmSimHash Algorithm = "simhash"
+)
+
+// Strategy decides what to do with near-duplicates.
+type Strategy string
+
+const (
+	// StrategyDrop drops near-duplicates, keeping the first document of each group.
+	StrategyDrop Strategy = "drop"
+	// StrategyMerge drops near-duplicates like StrategyDrop, and merges them into the kept document:
+	// their IDs are recorded in MetaKeyDuplicateIDs, and their metadata keys absent in the kept document are copied.
+	StrategyMerge Strategy = "merge"
+)
+
+const (
+	// MetaKeyDuplicateIDs is the IDs of the near-duplicates merged into the document, value: []string
+	MetaKeyDuplicateIDs = "_duplicate_ids"
+)
+
+type Config struct {
+	// Algorithm of similarity estimation.
+	// Optional. Default: AlgorithmMinHash.
+	Algorithm Algorithm
+	// Threshold is the similarity in (0, 1] at or above which two documents are near-duplicates.
+	// Optional. Default: 0.8.
+	Threshold float64
+	// Strategy of near-duplicates.
+	// Optional. Default: StrategyDrop.
+	Strategy Strategy
+	// ShingleSize is the number of consecutive tokens in a shingle.
+	// Optional. Default: 3.
+	ShingleSize int
+	// NumHashes is the number of hash functions of MinHash.
+	// Optional. Default: 128.
+	NumHashes int
+	// Tokenizer splits content into tokens.
+	// Optional. Default: lower-cased words, and single characters for CJK scripts.
+	Tokenizer func(content string) []string
+}
+
+// NewDeduplicator creates a transformer which removes near-duplicate documents, comparing each document with the
+// documents kept before it. Documents are kept in input order. Everything runs locally.
+func NewDeduplicator(_ context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	conf := *config
+	if conf.Algorithm == "" {
+		conf.Algorithm = AlgorithmMinHash
+	}
+	if conf.Algorithm != AlgorithmMinHash && conf.Algorithm != AlgorithmSimHash {
+		return nil, fmt.Errorf("[NewDeduplicator] unknown algorithm: %s", conf.Algorithm)
+	}
+	if conf.Threshold == 0 {
+		conf.Threshold = defaultThreshold
+	}
+	if conf.Threshold < 0 || conf.Threshold > 1 {
+		return nil, errors.New("[NewDeduplicator] threshold must be in (0, 1]")
+	}
+	if conf.Strategy == "" {
+		conf.Strategy = StrategyDrop
+	}
+	if conf.Strategy != StrategyDrop && conf.Strategy != StrategyMerge {
+		return nil, fmt.Errorf("[NewDeduplicator] unknown strategy: %s", conf.Strategy)
+	}
+	if conf.ShingleSize <= 0 {
+		conf.ShingleSize = defaultShingleSize
+	}
+	if conf.NumHashes <= 0 {
+		conf.NumHashes = defaultNumHashes
+	}
+	if conf.Tokenizer == nil {
+		conf.Tokenizer = tokenize
+	}
+
+	return &deduplicator{config: &conf}, nil
+}
+
+type deduplicator struct {
+	config *Config
+}
+
+func (d *deduplicator) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var idx index
+	if d.config.Algorithm == AlgorithmSimHash {
+		idx = newSimHashIndex(d.config.Threshold)
+	} else {
+		idx = newMinHashIndex(d.config.NumHashes, d.config.Threshold)
+	}
+
+	var ret []*schema.Document
+	for _, doc := range src {
+		shingles := shingle(d.config.Tokenizer(doc.Content), d.config.ShingleSize)
+		if dup, ok := idx.match(len(ret), shingles); ok {
+			if d.config.Strategy == StrategyMerge {
+				merge(ret[dup], doc)
+			}
+			continue
+		}
+
+		nDoc := doc
+		if d.config.Strategy == StrategyMerge {
+			nDoc = &schema.Document{
+				ID:       doc.ID,
+				Content:  doc.Content,
+				MetaData: deepCopyMap(doc.MetaData),
+			}
+		}
+		ret = append(ret, nDoc)
+	}
+
+	return ret, nil
+}
+
+func (d *deduplicator) GetType() string {
+	return "Deduplicator"
+}
+
+func merge(kept, dup *schema.Document) {
+	if kept.MetaData == nil {
+		kept.MetaData = make(map[string]any)
+	}
+	for k, v := range dup.MetaData {
+		if _, ok := kept.MetaData[k]; !ok && k != MetaKeyDuplicateIDs {
+			kept.MetaData[k] = v
+		}
+	}
+	ids, _ := kept.MetaData[MetaKeyDuplicateIDs].([]string)
+	ids = append(ids, dup.ID)
+	if dupIDs, ok := dup.MetaData[MetaKeyDuplicateIDs].([]string); ok {
+		ids = append(ids, dupIDs...)
+	}
+	kept.MetaData[MetaKeyDuplicateIDs] = ids
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	if ids, ok := ret[MetaKeyDuplicateIDs].([]string); ok {
+		ret[MetaKeyDuplicateIDs] = append([]string(nil), ids...)
+	}
+	return ret
+}
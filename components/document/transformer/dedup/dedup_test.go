@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedup
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	article  = "Eino is a framework for building LLM applications in Go. It provides components, orchestration and tooling, so that developers can build reliable applications with ease. Components include chat models, retrievers, indexers and document transformers."
+	modified = "Eino is a framework for building LLM applications in Go! It provides components, orchestration and tooling, so developers can build reliable applications with ease. Components include chat models, retrievers, indexers and document transformers."
+	other    = "The weather is sunny today, and people are walking in the park along the river, enjoying the warm afternoon before the rain comes in the evening."
+)
+
+func TestNewDeduplicator(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewDeduplicator(ctx, &Config{Algorithm: "unknown"})
+	assert.Error(t, err)
+	_, err = NewDeduplicator(ctx, &Config{Threshold: 1.5})
+	assert.Error(t, err)
+	_, err = NewDeduplicator(ctx, &Config{Strategy: "unknown"})
+	assert.Error(t, err)
+	_, err = NewDeduplicator(ctx, nil)
+	assert.NoError(t, err)
+}
+
+func TestDeduplicator(t *testing.T) {
+	ctx := context.Background()
+	src := []*schema.Document{
+		{ID: "1", Content: article, MetaData: map[string]any{"a": 1}},
+		{ID: "2", Content: other},
+		{ID: "3", Content: modified, MetaData: map[string]any{"a": 2, "b": 3}},
+		{ID: "4", Content: strings.ToUpper(article)},
+		{ID: "5", Content: ""},
+		{ID: "6", Content: ""},
+	}
+
+	for _, algorithm := range []Algorithm{AlgorithmMinHash, AlgorithmSimHash} {
+		t.Run(string(algorithm)+" drop", func(t *testing.T) {
+			d, err := NewDeduplicator(ctx, &Config{Algorithm: algorithm, Threshold: 0.7})
+			assert.NoError(t, err)
+			docs, err := d.Transform(ctx, src)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"1", "2", "5", "6"}, ids(docs))
+			assert.Equal(t, map[string]any{"a": 1}, docs[0].MetaData)
+		})
+
+		t.Run(string(algorithm)+" merge", func(t *testing.T) {
+			d, err := NewDeduplicator(ctx, &Config{Algorithm: algorithm, Threshold: 0.7, Strategy: StrategyMerge})
+			assert.NoError(t, err)
+			docs, err := d.Transform(ctx, src)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"1", "2", "5", "6"}, ids(docs))
+			assert.Equal(t, map[string]any{"a": 1, "b": 3, MetaKeyDuplicateIDs: []string{"3", "4"}}, docs[0].MetaData)
+			assert.Equal(t, map[string]any{"a": 1}, src[0].MetaData)
+		})
+
+		t.Run(string(algorithm)+" strict", func(t *testing.T) {
+			d, err := NewDeduplicator(ctx, &Config{Algorithm: algorithm, Threshold: 1})
+			assert.NoError(t, err)
+			docs, err := d.Transform(ctx, src)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"1", "2", "3", "5", "6"}, ids(docs))
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"hello", "world", "你", "好", "v2"}, tokenize("Hello, World!你好 v2"))
+	assert.Len(t, shingle([]string{"a", "b"}, 3), 1)
+	assert.Len(t, shingle([]string{"a", "b", "c", "d"}, 3), 2)
+	assert.Nil(t, shingle(nil, 3))
+}
+
+func TestLSHParams(t *testing.T) {
+	bands, rows := lshParams(128, 0.8)
+	assert.Equal(t, 128, bands*rows)
+	assert.Equal(t, 16, bands)
+	bands, rows = lshParams(128, 1)
+	assert.Equal(t, 1, bands)
+	assert.Equal(t, 128, rows)
+}
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.ID
+	}
+	return ret
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedup
+
+import (
+	"math"
+)
+
+type minHashIndex struct {
+	seeds     []uint64
+	threshold float64
+	bands     int
+	rows      int
+
+	signatures map[int][]uint64
+	buckets    []map[uint64][]int
+}
+
+func newMinHashIndex(numHashes int, threshold float64) *minHashIndex {
+	seeds := make([]uint64, numHashes)
+	seed := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		seed += 0x9e3779b97f4a7c15
+		seeds[i] = mix(seed)
+	}
+
+	bands, rows := lshParams(numHashes, threshold)
+	buckets := make([]map[uint64][]int, bands)
+	for i := range buckets {
+		buckets[i] = make(map[uint64][]int)
+	}
+
+	return &minHashIndex{
+		seeds:      seeds,
+		threshold:  threshold,
+		bands:      bands,
+		rows:       rows,
+		signatures: make(map[int][]uint64),
+		buckets:    buckets,
+	}
+}
+
+// lshParams chooses bands * rows == numHashes, whose S-curve threshold (1/bands)^(1/rows) is the closest one not above
+// threshold, so that few similar pairs are missed, and candidates are verified by the estimated similarity.
+func lshParams(numHashes int, threshold float64) (bands, rows int) {
+	bands, rows = numHashes, 1
+	best := -1.0
+	for r := 1; r <= numHashes; r++ {
+		if numHashes%r != 0 {
+			continue
+		}
+		b := numHashes / r
+		t := math.Pow(1/float64(b), 1/float64(r))
+		if t <= threshold && t > best {
+			best, bands, rows = t, b, r
+		}
+	}
+	return bands, rows
+}
+
+func (m *minHashIndex) match(id int, shingles []uint64) (int, bool) {
+	if len(shingles) == 0 {
+		return 0, false
+	}
+
+	sig := make([]uint64, len(m.seeds))
+	for i, seed := range m.seeds {
+		minimum := uint64(math.MaxUint64)
+		for _, s := range shingles {
+			if h := mix(s ^ seed); h < minimum {
+				minimum = h
+			}
+		}
+		sig[i] = minimum
+	}
+
+	keys := make([]uint64, m.bands)
+	found, best := false, 0
+	for b := 0; b < m.bands; b++ {
+		key := uint64(b)
+		for _, v := range sig[b*m.rows : (b+1)*m.rows] {
+			key = mix(key ^ v)
+		}
+		keys[b] = key
+
+		for _, candidate := range m.buckets[b][key] {
+			if (found && candidate >= best) || similarity(sig, m.signatures[candidate]) < m.threshold {
+				continue
+			}
+			found, best = true, candidate
+		}
+	}
+	if found {
+		return best, true
+	}
+
+	m.signatures[id] = sig
+	for b, key := range keys {
+		m.buckets[b][key] = append(m.buckets[b][key], id)
+	}
+	return 0, false
+}
+
+// similarity estimates the jaccard similarity by the fraction of equal minimums.
+func similarity(a, b []uint64) float64 {
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
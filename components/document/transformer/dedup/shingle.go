@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedup
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultThreshold   = 0.8
+	defaultShingleSize = 3
+	defaultNumHashes   = 128
+)
+
+// tokenize splits content into lower-cased words, CJK characters are tokens on their own.
+func tokenize(content string) []string {
+	var tokens []string
+	word := strings.Builder{}
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range content {
+		switch {
+		case isCJK(r):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// shingle hashes every size consecutive tokens. Contents shorter than size are one shingle.
+func shingle(tokens []string, size int) []uint64 {
+	if len(tokens) == 0 {
+		return nil
+	}
+	if len(tokens) < size {
+		size = len(tokens)
+	}
+	ret := make([]uint64, 0, len(tokens)-size+1)
+	for i := 0; i+size <= len(tokens); i++ {
+		h := fnv.New64a()
+		for j, t := range tokens[i : i+size] {
+			if j > 0 {
+				_, _ = h.Write([]byte{' '})
+			}
+			_, _ = h.Write([]byte(t))
+		}
+		ret = append(ret, h.Sum64())
+	}
+	return ret
+}
+
+// index finds similar documents among the documents added to it.
+type index interface {
+	// match returns the id of a similar document added before, or adds the document with id if there is none.
+	match(id int, shingles []uint64) (int, bool)
+}
+
+// mix is the finalizer of splitmix64, used as a cheap family of hash functions.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedup
+
+import (
+	"math/bits"
+)
+
+type simHashIndex struct {
+	maxDistance  int
+	ids          []int
+	fingerprints []uint64
+}
+
+func newSimHashIndex(threshold float64) *simHashIndex {
+	return &simHashIndex{
+		maxDistance: int((1-threshold)*64 + 1e-9),
+	}
+}
+
+func (s *simHashIndex) match(id int, shingles []uint64) (int, bool) {
+	if len(shingles) == 0 {
+		return 0, false
+	}
+
+	fp := simHash(shingles)
+	for i, other := range s.fingerprints {
+		if bits.OnesCount64(fp^other) <= s.maxDistance {
+			return s.ids[i], true
+		}
+	}
+
+	s.ids = append(s.ids, id)
+	s.fingerprints = append(s.fingerprints, fp)
+	return 0, false
+}
+
+// simHash sums the bits of shingle hashes weighted by occurrences, a bit of fingerprint is set if its sum is positive.
+func simHash(shingles []uint64) uint64 {
+	var v [64]int
+	for _, s := range shingles {
+		h := mix(s)
+		for i := 0; i < 64; i++ {
+			if h&(1<<uint(i)) != 0 {
+				v[i]++
+			} else {
+				v[i]--
+			}
+		}
+	}
+
+	var fp uint64
+	for i := 0; i < 64; i++ {
+		if v[i] > 0 {
+			fp |= 1 << uint(i)
+		}
+	}
+	return fp
+}
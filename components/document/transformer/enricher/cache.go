@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+import (
+	"context"
+	"sync"
+)
+
+// Enrichment is the generated content of a chunk.
+type Enrichment struct {
+	Title         string   `json:"title,omitempty"`
+	Summary       string   `json:"summary,omitempty"`
+	Keywords      []string `json:"keywords,omitempty"`
+	Questions     []string `json:"questions,omitempty"`
+	ContextHeader string   `json:"context_header,omitempty"`
+}
+
+// Cache stores generated enrichments keyed by the hash of chunk content and generation settings,
+// so that unchanged chunks are not sent to the model again.
+type Cache interface {
+	Get(ctx context.Context, key string) (*Enrichment, bool, error)
+	Set(ctx context.Context, key string, value *Enrichment) error
+}
+
+// NewMemoryCache creates an in-process Cache without eviction.
+func NewMemoryCache() Cache {
+	return &memoryCache{}
+}
+
+type memoryCache struct {
+	m sync.Map
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (*Enrichment, bool, error) {
+	v, ok := c.m.Load(key)
+	if !ok {
+		return nil, false, nil
+	}
+	return v.(*Enrichment), true, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value *Enrichment) error {
+	c.m.Store(key, value)
+	return nil
+}
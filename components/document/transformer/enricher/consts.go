@@ -0,0 +1,39 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+const typ = "Enricher"
+
+const (
+	// MetaKeyTitle is the generated title of chunk, value: string
+	MetaKeyTitle = "_title"
+	// MetaKeySummary is the generated summary of chunk, value: string
+	MetaKeySummary = "_summary"
+	// MetaKeyKeywords is the generated keywords of chunk, value: []string
+	MetaKeyKeywords = "_keywords"
+	// MetaKeyQuestions is the generated hypothetical questions which chunk can answer, value: []string
+	MetaKeyQuestions = "_questions"
+	// MetaKeyContextHeader is the generated context header situating chunk within the whole document, value: string
+	MetaKeyContextHeader = "_context_header"
+)
+
+const (
+	defaultNumKeywords  = 5
+	defaultNumQuestions = 3
+	defaultBatchSize    = 1
+	defaultConcurrency  = 5
+)
@@ -0,0 +1,427 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Field is a piece of metadata generated for each chunk.
+type Field string
+
+const (
+	FieldTitle     Field = "title"
+	FieldSummary   Field = "summary"
+	FieldKeywords  Field = "keywords"
+	FieldQuestions Field = "questions"
+)
+
+type Config struct {
+	// ChatModel generates the metadata and context headers.
+	// Required.
+	ChatModel model.ChatModel
+	// Fields specifies the metadata to generate, written to MetaKeyTitle, MetaKeySummary, MetaKeyKeywords and MetaKeyQuestions.
+	// Optional. Default: all fields. Use an empty non-nil slice to generate context headers only.
+	Fields []Field
+	// NumKeywords is the number of keywords asked for.
+	// Optional. Default: 5.
+	NumKeywords int
+	// NumQuestions is the number of hypothetical questions asked for.
+	// Optional. Default: 3.
+	NumQuestions int
+	// Language of the generated fields, e.g. "English". Empty means leaving it to the model.
+	Language string
+	// SystemPrompt overrides the default prompt of fields generation.
+	// The model is expected to reply a JSON array with one object per chunk, keyed by the names of Fields.
+	// Optional.
+	SystemPrompt string
+	// BatchSize is the max number of chunks sent to the model in one request for fields generation.
+	// Optional. Default: 1.
+	BatchSize int
+	// Concurrency is the max number of concurrent model calls.
+	// Optional. Default: 5.
+	Concurrency int
+	// MaxContentLength truncates chunk content in runes before sending it to the model. Zero means no truncation.
+	MaxContentLength int
+	// Cache stores generated results keyed by the hash of content and prompt, see NewMemoryCache.
+	// Optional. Nil means no cache.
+	Cache Cache
+
+	// ContextualHeader if true, generates a short context situating each chunk within its whole document,
+	// and prepends it to the chunk content, separated by a blank line. The header is also written to MetaKeyContextHeader.
+	// The whole document is provided by WithSourceDocuments, or assembled from the chunks sharing the same document ID.
+	// Note that offsets recorded by splitters no longer match the prepended content.
+	ContextualHeader bool
+	// ContextPrompt overrides the default prompt of context header generation.
+	// Optional.
+	ContextPrompt string
+	// MaxDocumentLength truncates the whole document in runes before sending it to the model. Zero means no truncation.
+	MaxDocumentLength int
+}
+
+// NewEnricher creates a transformer which generates metadata of each chunk with a ChatModel,
+// such as title, summary, keywords and hypothetical questions, and optionally prepends a contextual header to it.
+func NewEnricher(_ context.Context, config *Config) (document.Transformer, error) {
+	if config == nil || config.ChatModel == nil {
+		return nil, errors.New("[NewEnricher] chat model not provided")
+	}
+
+	conf := *config
+	if conf.Fields == nil {
+		conf.Fields = []Field{FieldTitle, FieldSummary, FieldKeywords, FieldQuestions}
+	}
+	for _, f := range conf.Fields {
+		if _, ok := fieldDescriptions[f]; !ok {
+			return nil, fmt.Errorf("[NewEnricher] unknown field: %s", f)
+		}
+	}
+	if len(conf.Fields) == 0 && !conf.ContextualHeader {
+		return nil, errors.New("[NewEnricher] nothing to generate, fields are empty and contextual header is disabled")
+	}
+	if conf.NumKeywords <= 0 {
+		conf.NumKeywords = defaultNumKeywords
+	}
+	if conf.NumQuestions <= 0 {
+		conf.NumQuestions = defaultNumQuestions
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+	if conf.Concurrency <= 0 {
+		conf.Concurrency = defaultConcurrency
+	}
+	if conf.SystemPrompt == "" {
+		conf.SystemPrompt = buildFieldsPrompt(&conf)
+	}
+	if conf.ContextPrompt == "" {
+		conf.ContextPrompt = defaultContextPrompt
+	}
+
+	return &enricher{config: &conf}, nil
+}
+
+type enricher struct {
+	config *Config
+}
+
+func (e *enricher) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	options := document.GetTransformerImplSpecificOptions(&options{}, opts...)
+	if len(src) == 0 {
+		return src, nil
+	}
+
+	var (
+		fields  []*Enrichment
+		headers []*Enrichment
+		err     error
+	)
+	if len(e.config.Fields) > 0 {
+		fields, err = e.generateFields(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if e.config.ContextualHeader {
+		headers, err = e.generateHeaders(ctx, src, options.sources)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ret := make([]*schema.Document, 0, len(src))
+	for i, doc := range src {
+		nDoc := &schema.Document{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			MetaData: deepCopyMap(doc.MetaData),
+		}
+		if nDoc.MetaData == nil {
+			nDoc.MetaData = make(map[string]any)
+		}
+		if fields != nil {
+			e.setFields(nDoc, fields[i])
+		}
+		if headers != nil && headers[i].ContextHeader != "" {
+			nDoc.MetaData[MetaKeyContextHeader] = headers[i].ContextHeader
+			nDoc.Content = headers[i].ContextHeader + "\n\n" + nDoc.Content
+		}
+		ret = append(ret, nDoc)
+	}
+
+	return ret, nil
+}
+
+func (e *enricher) GetType() string {
+	return typ
+}
+
+func (e *enricher) setFields(doc *schema.Document, en *Enrichment) {
+	for _, f := range e.config.Fields {
+		switch f {
+		case FieldTitle:
+			if en.Title != "" {
+				doc.MetaData[MetaKeyTitle] = en.Title
+			}
+		case FieldSummary:
+			if en.Summary != "" {
+				doc.MetaData[MetaKeySummary] = en.Summary
+			}
+		case FieldKeywords:
+			if len(en.Keywords) > 0 {
+				doc.MetaData[MetaKeyKeywords] = en.Keywords
+			}
+		case FieldQuestions:
+			if len(en.Questions) > 0 {
+				doc.MetaData[MetaKeyQuestions] = en.Questions
+			}
+		}
+	}
+}
+
+// generateFields generates fields of every document, in batches of BatchSize.
+// Documents with the same content are sent to the model once.
+func (e *enricher) generateFields(ctx context.Context, src []*schema.Document) ([]*Enrichment, error) {
+	keys := make([]string, len(src))
+	contents := make(map[string]string, len(src))
+	for i, doc := range src {
+		content := truncate(doc.Content, e.config.MaxContentLength)
+		keys[i] = hashKey("fields", e.config.SystemPrompt, content)
+		contents[keys[i]] = content
+	}
+
+	resolved, missing, err := e.lookup(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []func(ctx context.Context) error
+	for start := 0; start < len(missing); start += e.config.BatchSize {
+		end := start + e.config.BatchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+		batch := missing[start:end]
+		tasks = append(tasks, func(ctx context.Context) error {
+			batchContents := make([]string, len(batch))
+			for i, key := range batch {
+				batchContents[i] = contents[key]
+			}
+
+			msg, err := e.config.ChatModel.Generate(ctx, []*schema.Message{
+				schema.SystemMessage(e.config.SystemPrompt),
+				schema.UserMessage(formatChunks(batchContents)),
+			})
+			if err != nil {
+				return fmt.Errorf("generate fields of %d chunks fail: %w", len(batch), err)
+			}
+			results, err := parseEnrichments(msg.Content, len(batch))
+			if err != nil {
+				return err
+			}
+			for i, key := range batch {
+				if err = e.store(ctx, resolved, key, results[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err = runTasks(ctx, e.config.Concurrency, tasks); err != nil {
+		return nil, err
+	}
+
+	return collect(keys, resolved), nil
+}
+
+// generateHeaders generates a context header of every document, one model call for each.
+func (e *enricher) generateHeaders(ctx context.Context, src []*schema.Document, sources map[string]string) ([]*Enrichment, error) {
+	wholes := make(map[string]string)
+	for id, content := range sources {
+		wholes[id] = content
+	}
+	parts := make(map[string][]string)
+	for _, doc := range src {
+		if _, ok := sources[doc.ID]; ok || doc.ID == "" {
+			continue
+		}
+		parts[doc.ID] = append(parts[doc.ID], doc.Content)
+	}
+	for id, p := range parts {
+		wholes[id] = strings.Join(p, "\n\n")
+	}
+
+	keys := make([]string, len(src))
+	inputs := make(map[string]string, len(src))
+	for i, doc := range src {
+		content := truncate(doc.Content, e.config.MaxContentLength)
+		whole, ok := wholes[doc.ID]
+		if !ok {
+			whole = doc.Content
+		}
+		whole = truncate(whole, e.config.MaxDocumentLength)
+		keys[i] = hashKey("context", e.config.ContextPrompt, whole, content)
+		inputs[keys[i]] = formatContext(whole, content)
+	}
+
+	resolved, missing, err := e.lookup(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]func(ctx context.Context) error, 0, len(missing))
+	for _, key := range missing {
+		key := key
+		tasks = append(tasks, func(ctx context.Context) error {
+			msg, err := e.config.ChatModel.Generate(ctx, []*schema.Message{
+				schema.SystemMessage(e.config.ContextPrompt),
+				schema.UserMessage(inputs[key]),
+			})
+			if err != nil {
+				return fmt.Errorf("generate context header fail: %w", err)
+			}
+			return e.store(ctx, resolved, key, &Enrichment{ContextHeader: strings.TrimSpace(msg.Content)})
+		})
+	}
+	if err = runTasks(ctx, e.config.Concurrency, tasks); err != nil {
+		return nil, err
+	}
+
+	return collect(keys, resolved), nil
+}
+
+// lookup returns the cached results of keys, and the distinct keys missing in cache in order.
+func (e *enricher) lookup(ctx context.Context, keys []string) (*sync.Map, []string, error) {
+	resolved := &sync.Map{}
+	seen := make(map[string]bool, len(keys))
+	var missing []string
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if e.config.Cache != nil {
+			v, ok, err := e.config.Cache.Get(ctx, key)
+			if err != nil {
+				return nil, nil, fmt.Errorf("[Enricher] get cache fail: %w", err)
+			}
+			if ok && v != nil {
+				resolved.Store(key, v)
+				continue
+			}
+		}
+		missing = append(missing, key)
+	}
+	return resolved, missing, nil
+}
+
+func (e *enricher) store(ctx context.Context, resolved *sync.Map, key string, value *Enrichment) error {
+	resolved.Store(key, value)
+	if e.config.Cache != nil {
+		if err := e.config.Cache.Set(ctx, key, value); err != nil {
+			return fmt.Errorf("set cache fail: %w", err)
+		}
+	}
+	return nil
+}
+
+func collect(keys []string, resolved *sync.Map) []*Enrichment {
+	ret := make([]*Enrichment, len(keys))
+	for i, key := range keys {
+		v, _ := resolved.Load(key)
+		ret[i] = v.(*Enrichment)
+	}
+	return ret
+}
+
+// runTasks runs tasks with bounded concurrency, and returns the first error.
+func runTasks(ctx context.Context, concurrency int, tasks []func(ctx context.Context) error) error {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errOnce error
+		sem     = make(chan struct{}, concurrency)
+	)
+
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return errOnce != nil
+	}
+
+	for i := range tasks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			mu.Lock()
+			if errOnce == nil {
+				errOnce = fmt.Errorf("[Enricher] %w", ctx.Err())
+			}
+			mu.Unlock()
+		}
+		if failed() {
+			// stop scheduling the remaining tasks once one has failed or ctx is done
+			break
+		}
+		wg.Add(1)
+		go func(task func(ctx context.Context) error) {
+			defer func() {
+				if e := recover(); e != nil {
+					mu.Lock()
+					if errOnce == nil {
+						errOnce = fmt.Errorf("[Enricher] panic in model call: %v", e)
+					}
+					mu.Unlock()
+				}
+				<-sem
+				wg.Done()
+			}()
+
+			if err := task(ctx); err != nil {
+				mu.Lock()
+				if errOnce == nil {
+					errOnce = fmt.Errorf("[Enricher] %w", err)
+				}
+				mu.Unlock()
+			}
+		}(tasks[i])
+	}
+	wg.Wait()
+
+	return errOnce
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChatModel struct {
+	calls int32
+	reply func(input []*schema.Message) (string, error)
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	atomic.AddInt32(&m.calls, 1)
+	content, err := m.reply(input)
+	if err != nil {
+		return nil, err
+	}
+	return schema.AssistantMessage(content, nil), nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockChatModel) BindTools(tools []*schema.ToolInfo) error {
+	return nil
+}
+
+func TestNewEnricher(t *testing.T) {
+	ctx := context.Background()
+	cm := &mockChatModel{}
+
+	_, err := NewEnricher(ctx, nil)
+	assert.Error(t, err)
+	_, err = NewEnricher(ctx, &Config{ChatModel: cm, Fields: []Field{"unknown"}})
+	assert.Error(t, err)
+	_, err = NewEnricher(ctx, &Config{ChatModel: cm, Fields: []Field{}})
+	assert.Error(t, err)
+
+	e, err := NewEnricher(ctx, &Config{ChatModel: cm, Language: "English"})
+	assert.NoError(t, err)
+	prompt := e.(*enricher).config.SystemPrompt
+	assert.Contains(t, prompt, "- keywords: a list of 5 keywords")
+	assert.Contains(t, prompt, "- questions: a list of 3 questions")
+	assert.Contains(t, prompt, "Write the fields in English.")
+}
+
+func TestEnricherFields(t *testing.T) {
+	ctx := context.Background()
+	cm := &mockChatModel{reply: func(input []*schema.Message) (string, error) {
+		var items []string
+		for _, c := range []string{"alpha", "beta", "gamma"} {
+			if strings.Contains(input[1].Content, "\n"+c+"\n") {
+				items = append(items, `{"title":"T-`+c+`","summary":"S-`+c+`","keywords":["k-`+c+`"],"questions":["q-`+c+`?"]}`)
+			}
+		}
+		return "```json\n[" + strings.Join(items, ",") + "]\n```", nil
+	}}
+	cache := NewMemoryCache()
+	e, err := NewEnricher(ctx, &Config{
+		ChatModel: cm,
+		Fields:    []Field{FieldTitle, FieldKeywords, FieldQuestions},
+		BatchSize: 2,
+		Cache:     cache,
+	})
+	assert.NoError(t, err)
+
+	src := []*schema.Document{
+		{ID: "1", Content: "alpha", MetaData: map[string]any{"k": "v"}},
+		{ID: "2", Content: "beta"},
+		{ID: "3", Content: "alpha"},
+		{ID: "4", Content: "gamma"},
+	}
+	docs, err := e.Transform(ctx, src)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), cm.calls)
+	assert.Len(t, docs, 4)
+	assert.Equal(t, map[string]any{
+		"k":              "v",
+		MetaKeyTitle:     "T-alpha",
+		MetaKeyKeywords:  []string{"k-alpha"},
+		MetaKeyQuestions: []string{"q-alpha?"},
+	}, docs[0].MetaData)
+	assert.Equal(t, "T-beta", docs[1].MetaData[MetaKeyTitle])
+	assert.Equal(t, "T-alpha", docs[2].MetaData[MetaKeyTitle])
+	assert.Equal(t, "T-gamma", docs[3].MetaData[MetaKeyTitle])
+	assert.Equal(t, "alpha", docs[0].Content)
+	assert.Nil(t, src[1].MetaData)
+
+	// all cached
+	_, err = e.Transform(ctx, src)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), cm.calls)
+
+	t.Run("invalid output", func(t *testing.T) {
+		bad := &mockChatModel{reply: func(input []*schema.Message) (string, error) {
+			return `[{"title":"only one"}]`, nil
+		}}
+		e, err := NewEnricher(ctx, &Config{ChatModel: bad, BatchSize: 2})
+		assert.NoError(t, err)
+		_, err = e.Transform(ctx, src[:2])
+		assert.Error(t, err)
+	})
+
+	t.Run("model error", func(t *testing.T) {
+		bad := &mockChatModel{reply: func(input []*schema.Message) (string, error) {
+			return "", errors.New("boom")
+		}}
+		e, err := NewEnricher(ctx, &Config{ChatModel: bad})
+		assert.NoError(t, err)
+		_, err = e.Transform(ctx, src)
+		assert.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestEnricherContextualHeader(t *testing.T) {
+	ctx := context.Background()
+	var inputs []string
+	cm := &mockChatModel{reply: func(input []*schema.Message) (string, error) {
+		assert.Equal(t, defaultContextPrompt, input[0].Content)
+		inputs = append(inputs, input[1].Content)
+		chunk := input[1].Content[strings.Index(input[1].Content, "<chunk>")+len("<chunk>\n"):]
+		return " ctx of " + strings.TrimSuffix(chunk, "\n</chunk>") + "\n", nil
+	}}
+	e, err := NewEnricher(ctx, &Config{
+		ChatModel:        cm,
+		Fields:           []Field{},
+		ContextualHeader: true,
+		Concurrency:      1,
+	})
+	assert.NoError(t, err)
+
+	docs, err := e.Transform(ctx, []*schema.Document{
+		{ID: "a", Content: "a1"},
+		{ID: "b", Content: "b1"},
+		{ID: "a", Content: "a2"},
+	}, WithSourceDocuments(&schema.Document{ID: "b", Content: "whole b"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "ctx of a1\n\na1", docs[0].Content)
+	assert.Equal(t, "ctx of a1", docs[0].MetaData[MetaKeyContextHeader])
+	assert.Equal(t, "ctx of b1\n\nb1", docs[1].Content)
+	assert.Equal(t, "ctx of a2\n\na2", docs[2].Content)
+	assert.Equal(t, []string{
+		"<document>\na1\n\na2\n</document>\n\n<chunk>\na1\n</chunk>",
+		"<document>\nwhole b\n</document>\n\n<chunk>\nb1\n</chunk>",
+		"<document>\na1\n\na2\n</document>\n\n<chunk>\na2\n</chunk>",
+	}, inputs)
+}
+
+func TestParseEnrichments(t *testing.T) {
+	ret, err := parseEnrichments(`Here you are: {"summary":"s","keywords":["a","b"]}`, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Enrichment{{Summary: "s", Keywords: []string{"a", "b"}}}, ret)
+
+	ret, err = parseEnrichments(`[{"title":"a"}, null]`, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Enrichment{{Title: "a"}, {}}, ret)
+
+	_, err = parseEnrichments("no json", 1)
+	assert.Error(t, err)
+	_, err = parseEnrichments(`{"title":"a"}`, 2)
+	assert.Error(t, err)
+	_, err = parseEnrichments(`[{"title":"a"}`, 1)
+	assert.ErrorContains(t, err, "unclosed json")
+	_, err = parseEnrichments(`] {"title":"a"`, 1)
+	assert.ErrorContains(t, err, "unclosed json")
+}
+
+func TestRunTasks(t *testing.T) {
+	t.Run("stop after error", func(t *testing.T) {
+		var calls int32
+		tasks := make([]func(ctx context.Context) error, 10)
+		for i := range tasks {
+			tasks[i] = func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return errors.New("fail")
+			}
+		}
+		err := runTasks(context.Background(), 1, tasks)
+		assert.ErrorContains(t, err, "fail")
+		assert.Less(t, atomic.LoadInt32(&calls), int32(10))
+	})
+
+	t.Run("ctx done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var calls int32
+		tasks := []func(ctx context.Context) error{
+			func(ctx context.Context) error { atomic.AddInt32(&calls, 1); return nil },
+		}
+		err := runTasks(ctx, 1, tasks)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+	})
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+import (
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+type options struct {
+	sources map[string]string
+}
+
+// WithSourceDocuments provides the whole documents which chunks are split from, matched by document ID.
+// They are used as the context of contextual headers. Without them, the context of a chunk is all the chunks
+// sharing its ID in the input, joined in order.
+func WithSourceDocuments(docs ...*schema.Document) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		if o.sources == nil {
+			o.sources = make(map[string]string, len(docs))
+		}
+		for _, doc := range docs {
+			o.sources[doc.ID] = doc.Content
+		}
+	})
+}
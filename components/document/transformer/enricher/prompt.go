@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+const defaultContextPrompt = `You will be given a document and a chunk of it. Write a short succinct context to situate the chunk within the overall document, for the purposes of improving search retrieval of the chunk. Reply with the context only.`
+
+var fieldDescriptions = map[Field]string{
+	FieldTitle:     "a short title of the chunk",
+	FieldSummary:   "a summary of the chunk in one or two sentences",
+	FieldKeywords:  "a list of %d keywords or key phrases of the chunk",
+	FieldQuestions: "a list of %d questions which the chunk can answer",
+}
+
+// buildFieldsPrompt builds the system prompt asking for the requested fields of every chunk as a JSON array.
+func buildFieldsPrompt(conf *Config) string {
+	sb := strings.Builder{}
+	sb.WriteString("You are a document analyst. For each chunk given by the user, generate the following fields:\n")
+	for _, f := range conf.Fields {
+		desc := fieldDescriptions[f]
+		switch f {
+		case FieldKeywords:
+			desc = fmt.Sprintf(desc, conf.NumKeywords)
+		case FieldQuestions:
+			desc = fmt.Sprintf(desc, conf.NumQuestions)
+		}
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", f, desc))
+	}
+	if conf.Language != "" {
+		sb.WriteString(fmt.Sprintf("Write the fields in %s.\n", conf.Language))
+	}
+	sb.WriteString("Reply with a JSON array containing one object per chunk in the given order, each object has the fields above as keys. Reply with the JSON only.")
+	return sb.String()
+}
+
+func formatChunks(contents []string) string {
+	sb := strings.Builder{}
+	for i, content := range contents {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(fmt.Sprintf("<chunk index=\"%d\">\n%s\n</chunk>", i+1, content))
+	}
+	return sb.String()
+}
+
+func formatContext(document, chunk string) string {
+	return fmt.Sprintf("<document>\n%s\n</document>\n\n<chunk>\n%s\n</chunk>", document, chunk)
+}
+
+// parseEnrichments parses the model output into n enrichments, tolerating surrounding text like code fences.
+// A single JSON object is accepted when n is 1.
+func parseEnrichments(output string, n int) ([]*Enrichment, error) {
+	start := strings.IndexAny(output, "[{")
+	if start < 0 {
+		return nil, fmt.Errorf("no json found in model output: %s", output)
+	}
+
+	var ret []*Enrichment
+	if output[start] == '[' {
+		end := strings.LastIndex(output, "]")
+		if end < start {
+			return nil, fmt.Errorf("unclosed json in model output: %s", output)
+		}
+		if err := sonic.UnmarshalString(output[start:end+1], &ret); err != nil {
+			return nil, fmt.Errorf("unmarshal model output fail: %w, output: %s", err, output)
+		}
+	} else {
+		end := strings.LastIndex(output, "}")
+		if end < start {
+			return nil, fmt.Errorf("unclosed json in model output: %s", output)
+		}
+		e := &Enrichment{}
+		if err := sonic.UnmarshalString(output[start:end+1], e); err != nil {
+			return nil, fmt.Errorf("unmarshal model output fail: %w, output: %s", err, output)
+		}
+		ret = append(ret, e)
+	}
+
+	if len(ret) != n {
+		return nil, fmt.Errorf("invalid length of enrichments, got=%d, expected=%d", len(ret), n)
+	}
+	for i := range ret {
+		if ret[i] == nil {
+			ret[i] = &Enrichment{}
+		}
+	}
+	return ret, nil
+}
+
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+func hashKey(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redactor
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	EntityEmail      = "EMAIL"
+	EntityPhone      = "PHONE"
+	EntityIDCard     = "ID_CARD"
+	EntityCreditCard = "CREDIT_CARD"
+)
+
+// Pattern recognizes one kind of personal data.
+type Pattern struct {
+	// Entity is the name of personal data kind, used in replacement tokens like "[EMAIL_1]".
+	// It must consist of upper case letters, digits and underscores.
+	Entity string
+	// Regexp matches the candidates. Matches adjacent to ASCII letters or digits of the surrounding text are ignored.
+	Regexp *regexp.Regexp
+	// Validate checks a match further, e.g. the checksum. Optional.
+	Validate func(match string) bool
+}
+
+// DefaultPatterns returns the builtin patterns of emails, ID cards of mainland China, credit cards and phone numbers,
+// in the order of priority when matches overlap.
+func DefaultPatterns() []*Pattern {
+	return []*Pattern{
+		EmailPattern(),
+		IDCardPattern(),
+		CreditCardPattern(),
+		PhonePattern(),
+	}
+}
+
+// EmailPattern matches email addresses.
+func EmailPattern() *Pattern {
+	return &Pattern{
+		Entity: EntityEmail,
+		Regexp: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`),
+	}
+}
+
+// PhonePattern matches mobile numbers of mainland China, international numbers starting with '+',
+// and North American numbers like "(555) 123-4567".
+func PhonePattern() *Pattern {
+	return &Pattern{
+		Entity: EntityPhone,
+		Regexp: regexp.MustCompile(`(?:\+?86[\- ]?)?1[3-9]\d{9}` +
+			`|\+\d{1,3}[\- ]?\(?\d{1,4}\)?(?:[\- ]?\d{2,4}){2,4}` +
+			`|\(\d{3}\) ?\d{3}[\- .]\d{4}|\d{3}[\-.]\d{3}[\-.]\d{4}`),
+	}
+}
+
+// IDCardPattern matches 18-digit resident ID card numbers of mainland China, validated by the checksum.
+func IDCardPattern() *Pattern {
+	return &Pattern{
+		Entity:   EntityIDCard,
+		Regexp:   regexp.MustCompile(`[1-9]\d{5}(?:18|19|20)\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])\d{3}[\dXx]`),
+		Validate: validIDCard,
+	}
+}
+
+// CreditCardPattern matches card numbers of 13 to 19 digits, either contiguous or grouped by spaces or hyphens
+// as printed on cards (4-4-4-4, 4-4-4-4-3 and 4-6-5), validated by the Luhn checksum.
+// The groups are bounded so that digits following a card number, e.g. an expiry date, are not taken as part of it.
+func CreditCardPattern() *Pattern {
+	return &Pattern{
+		Entity: EntityCreditCard,
+		Regexp: regexp.MustCompile(`\d{4}(?:[\- ]\d{4}){3}(?:[\- ]\d{3})?` +
+			`|\d{4}[\- ]\d{6}[\- ]\d{4,5}` +
+			`|\d{13,19}`),
+		Validate: validLuhn,
+	}
+}
+
+var idCardWeights = []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+func validIDCard(s string) bool {
+	sum := 0
+	for i, w := range idCardWeights {
+		sum += int(s[i]-'0') * w
+	}
+	return strings.ToUpper(s[17:]) == string("10X98765432"[sum%11])
+}
+
+func validLuhn(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
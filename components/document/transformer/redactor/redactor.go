@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redactor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyRedactions is the number of redacted values of each entity in the document, value: map[string]int
+	MetaKeyRedactions = "_redactions"
+)
+
+var entityRegexp = regexp.MustCompile(`^[A-Z0-9_]+$`)
+
+type Config struct {
+	// Patterns recognize personal data. Earlier patterns take precedence when matches overlap.
+	// Optional. Default: DefaultPatterns().
+	Patterns []*Pattern
+	// Vault if set, personal data is replaced with reversible tokens like "[EMAIL_1]", whose original values are kept
+	// in the vault and can be restored by Restore. Otherwise it is replaced with the entity name like "[EMAIL]".
+	// Optional.
+	Vault Vault
+}
+
+// NewRedactor creates a transformer which replaces personal data in document content with placeholders by rules.
+// Everything runs locally.
+func NewRedactor(_ context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	conf := *config
+	if conf.Patterns == nil {
+		conf.Patterns = DefaultPatterns()
+	}
+	for i, p := range conf.Patterns {
+		if p == nil || p.Regexp == nil {
+			return nil, fmt.Errorf("[NewRedactor] regexp of pattern[%d] not provided", i)
+		}
+		if !entityRegexp.MatchString(p.Entity) {
+			return nil, fmt.Errorf("[NewRedactor] invalid entity of pattern[%d]: %q", i, p.Entity)
+		}
+	}
+
+	return &redactor{config: &conf}, nil
+}
+
+type redactor struct {
+	config *Config
+}
+
+func (r *redactor) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	ret := make([]*schema.Document, 0, len(src))
+	for _, doc := range src {
+		content, counts, err := r.redact(ctx, doc.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		nDoc := &schema.Document{
+			ID:       doc.ID,
+			Content:  content,
+			MetaData: deepCopyMap(doc.MetaData),
+		}
+		if len(counts) > 0 {
+			if nDoc.MetaData == nil {
+				nDoc.MetaData = make(map[string]any)
+			}
+			nDoc.MetaData[MetaKeyRedactions] = counts
+		}
+		ret = append(ret, nDoc)
+	}
+
+	return ret, nil
+}
+
+func (r *redactor) GetType() string {
+	return "Redactor"
+}
+
+type span struct {
+	start, end int
+	entity     string
+}
+
+func (r *redactor) redact(ctx context.Context, text string) (string, map[string]int, error) {
+	var spans []span
+	for _, p := range r.config.Patterns {
+		for _, loc := range p.Regexp.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] || !isBoundary(text, loc[0], loc[1]) {
+				continue
+			}
+			if p.Validate != nil && !p.Validate(text[loc[0]:loc[1]]) {
+				continue
+			}
+			if overlaps(spans, loc[0], loc[1]) {
+				continue
+			}
+			spans = append(spans, span{start: loc[0], end: loc[1], entity: p.Entity})
+		}
+	}
+	if len(spans) == 0 {
+		return text, nil, nil
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	sb := strings.Builder{}
+	counts := make(map[string]int)
+	last := 0
+	for _, s := range spans {
+		replacement := "[" + s.entity + "]"
+		if r.config.Vault != nil {
+			token, err := r.config.Vault.Token(ctx, s.entity, text[s.start:s.end])
+			if err != nil {
+				return "", nil, fmt.Errorf("[Redactor] get token fail: %w", err)
+			}
+			replacement = token
+		}
+		sb.WriteString(text[last:s.start])
+		sb.WriteString(replacement)
+		last = s.end
+		counts[s.entity]++
+	}
+	sb.WriteString(text[last:])
+
+	return sb.String(), counts, nil
+}
+
+// isBoundary reports whether text[start:end] is not glued to ASCII letters or digits around it.
+func isBoundary(text string, start, end int) bool {
+	if start > 0 && isAlnum(text[start-1]) && isAlnum(text[start]) {
+		return false
+	}
+	if end < len(text) && isAlnum(text[end]) && isAlnum(text[end-1]) {
+		return false
+	}
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func overlaps(spans []span, start, end int) bool {
+	for _, s := range spans {
+		if start < s.end && s.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redactor
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const text = "联系 alice@example.com 或 13800138000，身份证 11010519491231002X，卡号 4111 1111 1111 1111, " +
+	"call (555) 123-4567 or +44 20 7946 0958. Invalid: 110105194912310021, order 12345678901234567, abc13800138000."
+
+func TestRedactor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("irreversible", func(t *testing.T) {
+		r, err := NewRedactor(ctx, nil)
+		assert.NoError(t, err)
+		docs, err := r.Transform(ctx, []*schema.Document{
+			{ID: "1", Content: text, MetaData: map[string]any{"k": "v"}},
+			{ID: "2", Content: "nothing here"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "联系 [EMAIL] 或 [PHONE]，身份证 [ID_CARD]，卡号 [CREDIT_CARD], "+
+			"call [PHONE] or [PHONE]. Invalid: 110105194912310021, order 12345678901234567, abc13800138000.", docs[0].Content)
+		assert.Equal(t, map[string]any{
+			"k":               "v",
+			MetaKeyRedactions: map[string]int{EntityEmail: 1, EntityPhone: 3, EntityIDCard: 1, EntityCreditCard: 1},
+		}, docs[0].MetaData)
+		assert.Equal(t, "nothing here", docs[1].Content)
+		assert.Nil(t, docs[1].MetaData)
+	})
+
+	t.Run("credit card followed by digits", func(t *testing.T) {
+		r, err := NewRedactor(ctx, &Config{Patterns: []*Pattern{CreditCardPattern()}})
+		assert.NoError(t, err)
+		docs, err := r.Transform(ctx, []*schema.Document{
+			{Content: "4111 1111 1111 1111 12/25"},
+			{Content: "4111111111111111 2 items"},
+			{Content: "3782-822463-10005 exp 0926"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "[CREDIT_CARD] 12/25", docs[0].Content)
+		assert.Equal(t, "[CREDIT_CARD] 2 items", docs[1].Content)
+		assert.Equal(t, "[CREDIT_CARD] exp 0926", docs[2].Content)
+	})
+
+	t.Run("reversible", func(t *testing.T) {
+		vault := NewMemoryVault()
+		r, err := NewRedactor(ctx, &Config{Vault: vault})
+		assert.NoError(t, err)
+		docs, err := r.Transform(ctx, []*schema.Document{
+			{Content: "a@b.io and 13800138000"},
+			{Content: "c@d.io, 13800138000 and a@b.io"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "[EMAIL_1] and [PHONE_1]", docs[0].Content)
+		assert.Equal(t, "[EMAIL_2], [PHONE_1] and [EMAIL_1]", docs[1].Content)
+
+		restored, err := Restore(ctx, vault, docs[1].Content+" [EMAIL_9]")
+		assert.NoError(t, err)
+		assert.Equal(t, "c@d.io, 13800138000 and a@b.io [EMAIL_9]", restored)
+	})
+
+	t.Run("custom pattern", func(t *testing.T) {
+		r, err := NewRedactor(ctx, &Config{Patterns: []*Pattern{{
+			Entity: "IP",
+			Regexp: regexp.MustCompile(`\d{1,3}(?:\.\d{1,3}){3}`),
+		}}})
+		assert.NoError(t, err)
+		docs, err := r.Transform(ctx, []*schema.Document{{Content: "host 10.0.0.1, mail a@b.io"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "host [IP], mail a@b.io", docs[0].Content)
+
+		_, err = NewRedactor(ctx, &Config{Patterns: []*Pattern{{Entity: "ip", Regexp: regexp.MustCompile(`x`)}}})
+		assert.Error(t, err)
+		_, err = NewRedactor(ctx, &Config{Patterns: []*Pattern{{Entity: "IP"}}})
+		assert.Error(t, err)
+	})
+}
+
+func TestValidators(t *testing.T) {
+	assert.True(t, validIDCard("11010519491231002X"))
+	assert.True(t, validIDCard("11010519491231002x"))
+	assert.False(t, validIDCard("110105194912310021"))
+	assert.True(t, validLuhn("4111-1111-1111-1111"))
+	assert.False(t, validLuhn("4111111111111112"))
+	assert.False(t, validLuhn("0000000000"))
+}
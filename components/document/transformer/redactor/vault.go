@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redactor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// Vault keeps the mapping between replacement tokens and the original values outside of documents,
+// so that redacted text can be restored by Restore.
+type Vault interface {
+	// Token returns the token of value of entity, creating one if absent. The same value always gets the same token.
+	Token(ctx context.Context, entity, value string) (string, error)
+	// Reveal returns the original value of token.
+	Reveal(ctx context.Context, token string) (string, bool, error)
+}
+
+// NewMemoryVault creates an in-process Vault.
+func NewMemoryVault() Vault {
+	return &memoryVault{
+		tokens:   make(map[string]string),
+		values:   make(map[string]string),
+		counters: make(map[string]int),
+	}
+}
+
+type memoryVault struct {
+	mu       sync.Mutex
+	tokens   map[string]string // entity + value -> token
+	values   map[string]string // token -> value
+	counters map[string]int
+}
+
+func (m *memoryVault) Token(_ context.Context, entity, value string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := entity + "\x00" + value
+	if token, ok := m.tokens[key]; ok {
+		return token, nil
+	}
+	m.counters[entity]++
+	token := formatToken(entity, m.counters[entity])
+	m.tokens[key] = token
+	m.values[token] = value
+	return token, nil
+}
+
+func (m *memoryVault) Reveal(_ context.Context, token string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.values[token]
+	return value, ok, nil
+}
+
+func formatToken(entity string, n int) string {
+	return "[" + entity + "_" + strconv.Itoa(n) + "]"
+}
+
+var tokenRegexp = regexp.MustCompile(`\[[A-Z0-9_]+_\d+\]`)
+
+// Restore replaces the tokens in text with the original values kept in vault. Unknown tokens are kept as is.
+func Restore(ctx context.Context, vault Vault, text string) (string, error) {
+	var err error
+	ret := tokenRegexp.ReplaceAllStringFunc(text, func(token string) string {
+		if err != nil {
+			return token
+		}
+		value, ok, e := vault.Reveal(ctx, token)
+		if e != nil {
+			err = fmt.Errorf("reveal token %s fail: %w", token, e)
+			return token
+		}
+		if !ok {
+			return token
+		}
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	return ret, nil
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rescore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/volcengine/volc-sdk-golang/base"
+)
+
+// Provider is the hosted rerank service.
+type Provider string
+
+const (
+	// ProviderCohere is Cohere rerank api, ref: https://docs.cohere.com/reference/rerank
+	// Other services compatible with its request and response, like Jina or Xinference, can also use it with custom Endpoint.
+	ProviderCohere Provider = "cohere"
+	// ProviderQianfan is Baidu Qianfan rerank api, ref: https://cloud.baidu.com/doc/WENXINWORKSHOP/s/2m3fihw8s
+	ProviderQianfan Provider = "qianfan"
+	// ProviderDashScope is Aliyun DashScope text rerank api, ref: https://help.aliyun.com/zh/model-studio/text-rerank-api
+	ProviderDashScope Provider = "dashscope"
+	// ProviderVolc is Volcengine knowledge base rerank api, ref: https://www.volcengine.com/docs/84313/1254474
+	ProviderVolc Provider = "volc"
+)
+
+var defaultEndpoints = map[Provider]string{
+	ProviderCohere:    "https://api.cohere.com/v2/rerank",
+	ProviderQianfan:   "https://qianfan.baidubce.com/v2/rerankers",
+	ProviderDashScope: "https://dashscope.aliyuncs.com/api/v1/services/rerank/text-rerank/text-rerank",
+	ProviderVolc:      "https://api-knowledgebase.mlp.cn-beijing.volces.com/api/knowledge/service/rerank",
+}
+
+var defaultModels = map[Provider]string{
+	ProviderCohere:    "rerank-v3.5",
+	ProviderDashScope: "gte-rerank",
+	ProviderVolc:      "base-multilingual-rerank",
+}
+
+type APIConfig struct {
+	// Provider specifies the request and response format of rerank service.
+	// Required.
+	Provider Provider
+	// Endpoint is the full url of rerank api.
+	// Optional. Default: the public endpoint of Provider.
+	Endpoint string
+	// APIKey is sent as bearer token, required by ProviderCohere, ProviderQianfan and ProviderDashScope.
+	APIKey string
+	// AK and SK sign requests of ProviderVolc.
+	AK string
+	SK string
+	// Model is the rerank model name.
+	// Optional for ProviderCohere("rerank-v3.5"), ProviderDashScope("gte-rerank") and ProviderVolc("base-multilingual-rerank").
+	// Required for ProviderQianfan.
+	Model string
+	// Timeout specifies the http request timeout.
+	// Optional. Default: 0 (no timeout)
+	Timeout time.Duration
+	// HTTPClient overrides the http client, Timeout is ignored if provided.
+	HTTPClient *http.Client
+}
+
+// NewAPIScorer creates a Scorer backed by a hosted rerank api.
+func NewAPIScorer(_ context.Context, config *APIConfig) (Scorer, error) {
+	if config == nil {
+		return nil, errors.New("[NewAPIScorer] config is nil")
+	}
+
+	conf := *config
+	if _, ok := defaultEndpoints[conf.Provider]; !ok {
+		return nil, fmt.Errorf("[NewAPIScorer] unknown provider: %s", conf.Provider)
+	}
+	if conf.Endpoint == "" {
+		conf.Endpoint = defaultEndpoints[conf.Provider]
+	}
+	if conf.Model == "" {
+		conf.Model = defaultModels[conf.Provider]
+	}
+	if conf.Model == "" {
+		return nil, fmt.Errorf("[NewAPIScorer] model not provided for provider %s", conf.Provider)
+	}
+	if conf.Provider == ProviderVolc {
+		if conf.AK == "" || conf.SK == "" {
+			return nil, errors.New("[NewAPIScorer] ak and sk are required for volc")
+		}
+	} else if conf.APIKey == "" {
+		return nil, fmt.Errorf("[NewAPIScorer] api key not provided for provider %s", conf.Provider)
+	}
+
+	cli := conf.HTTPClient
+	if cli == nil {
+		cli = &http.Client{Timeout: conf.Timeout}
+	}
+
+	s := &apiScorer{
+		config: &conf,
+		cli:    cli,
+	}
+	if conf.Provider == ProviderVolc {
+		s.credential = &base.Credentials{
+			AccessKeyID:     conf.AK,
+			SecretAccessKey: conf.SK,
+			Service:         "air",
+			Region:          "cn-north-1",
+		}
+	}
+
+	return s, nil
+}
+
+type apiScorer struct {
+	config     *APIConfig
+	cli        *http.Client
+	credential *base.Credentials
+}
+
+func (a *apiScorer) Score(ctx context.Context, query string, docs []string) ([]float64, error) {
+	var reqBody any
+	switch a.config.Provider {
+	case ProviderDashScope:
+		reqBody = &dashScopeRequest{
+			Model: a.config.Model,
+			Input: dashScopeInput{
+				Query:     query,
+				Documents: docs,
+			},
+			Parameters: dashScopeParameters{
+				TopN: len(docs),
+			},
+		}
+	case ProviderVolc:
+		datas := make([]volcRerankData, len(docs))
+		for i := range docs {
+			datas[i] = volcRerankData{Query: query, Content: docs[i]}
+		}
+		reqBody = &volcRequest{
+			Datas:       datas,
+			RerankModel: a.config.Model,
+		}
+	default:
+		reqBody = &cohereRequest{
+			Model:     a.config.Model,
+			Query:     query,
+			Documents: docs,
+			TopN:      len(docs),
+		}
+	}
+
+	body, err := sonic.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request fail: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request fail: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if a.credential != nil {
+		u, err := url.Parse(a.config.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("parse endpoint fail: %w", err)
+		}
+		req.Header.Set("Host", u.Host)
+		req = a.credential.Sign(req)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+a.config.APIKey)
+	}
+
+	resp, err := a.cli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request fail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response fail: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request fail, status code: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	return a.parseResponse(respBody, len(docs))
+}
+
+func (a *apiScorer) parseResponse(body []byte, n int) ([]float64, error) {
+	switch a.config.Provider {
+	case ProviderVolc:
+		resp := &volcResponse{}
+		if err := sonic.Unmarshal(body, resp); err != nil {
+			return nil, fmt.Errorf("unmarshal response fail: %w", err)
+		}
+		if resp.Code != 0 {
+			return nil, fmt.Errorf("request fail, code: %d, msg: %s, request id: %s", resp.Code, resp.Message, resp.RequestID)
+		}
+		if len(resp.Data.Scores) != n {
+			return nil, fmt.Errorf("invalid length of scores, got=%d, expected=%d", len(resp.Data.Scores), n)
+		}
+		return resp.Data.Scores, nil
+	case ProviderDashScope:
+		resp := &dashScopeResponse{}
+		if err := sonic.Unmarshal(body, resp); err != nil {
+			return nil, fmt.Errorf("unmarshal response fail: %w", err)
+		}
+		if resp.Code != "" {
+			return nil, fmt.Errorf("request fail, code: %s, msg: %s, request id: %s", resp.Code, resp.Message, resp.RequestID)
+		}
+		return indexedScores(resp.Output.Results, n)
+	default:
+		resp := &cohereResponse{}
+		if err := sonic.Unmarshal(body, resp); err != nil {
+			return nil, fmt.Errorf("unmarshal response fail: %w", err)
+		}
+		return indexedScores(resp.Results, n)
+	}
+}
+
+// indexedScores places scores of results by their index, documents missing in results get zero.
+func indexedScores(results []rerankResult, n int) ([]float64, error) {
+	scores := make([]float64, n)
+	for _, r := range results {
+		if r.Index < 0 || r.Index >= n {
+			return nil, fmt.Errorf("invalid result index %d, documents count: %d", r.Index, n)
+		}
+		scores[r.Index] = r.RelevanceScore
+	}
+	return scores, nil
+}
+
+type cohereRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n,omitempty"`
+}
+
+type rerankResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+type cohereResponse struct {
+	Results []rerankResult `json:"results"`
+}
+
+type dashScopeRequest struct {
+	Model      string              `json:"model"`
+	Input      dashScopeInput      `json:"input"`
+	Parameters dashScopeParameters `json:"parameters"`
+}
+
+type dashScopeInput struct {
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+}
+
+type dashScopeParameters struct {
+	ReturnDocuments bool `json:"return_documents"`
+	TopN            int  `json:"top_n,omitempty"`
+}
+
+type dashScopeResponse struct {
+	Output struct {
+		Results []rerankResult `json:"results"`
+	} `json:"output"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+type volcRerankData struct {
+	Query   string `json:"query"`
+	Content string `json:"content"`
+	Title   string `json:"title,omitempty"`
+}
+
+type volcRequest struct {
+	Datas       []volcRerankData `json:"datas"`
+	RerankModel string           `json:"rerank_model,omitempty"`
+}
+
+type volcResponse struct {
+	Code      int64  `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+	Data      struct {
+		Scores []float64 `json:"scores"`
+	} `json:"data"`
+}
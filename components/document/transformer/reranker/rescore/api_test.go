@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rescore
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIScorer(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewAPIScorer(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = NewAPIScorer(ctx, &APIConfig{Provider: "unknown"})
+	assert.Error(t, err)
+
+	_, err = NewAPIScorer(ctx, &APIConfig{Provider: ProviderCohere})
+	assert.Error(t, err)
+
+	_, err = NewAPIScorer(ctx, &APIConfig{Provider: ProviderQianfan, APIKey: "key"})
+	assert.Error(t, err)
+
+	_, err = NewAPIScorer(ctx, &APIConfig{Provider: ProviderVolc, AK: "ak"})
+	assert.Error(t, err)
+
+	s, err := NewAPIScorer(ctx, &APIConfig{Provider: ProviderDashScope, APIKey: "key"})
+	assert.NoError(t, err)
+	assert.Equal(t, "gte-rerank", s.(*apiScorer).config.Model)
+	assert.Equal(t, defaultEndpoints[ProviderDashScope], s.(*apiScorer).config.Endpoint)
+}
+
+func TestAPIScorer(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		config   *APIConfig
+		response string
+		check    func(t *testing.T, r *http.Request, body map[string]any)
+	}{
+		{
+			name:     "cohere",
+			config:   &APIConfig{Provider: ProviderCohere, APIKey: "key"},
+			response: `{"results":[{"index":1,"relevance_score":0.9},{"index":0,"relevance_score":0.1}]}`,
+			check: func(t *testing.T, r *http.Request, body map[string]any) {
+				assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+				assert.Equal(t, "rerank-v3.5", body["model"])
+				assert.Equal(t, "query", body["query"])
+				assert.Equal(t, []any{"doc0", "doc1"}, body["documents"])
+			},
+		},
+		{
+			name:     "qianfan",
+			config:   &APIConfig{Provider: ProviderQianfan, APIKey: "key", Model: "bce-reranker-base"},
+			response: `{"results":[{"index":0,"relevance_score":0.1,"document":"doc0"},{"index":1,"relevance_score":0.9,"document":"doc1"}]}`,
+			check: func(t *testing.T, r *http.Request, body map[string]any) {
+				assert.Equal(t, "bce-reranker-base", body["model"])
+			},
+		},
+		{
+			name:     "dashscope",
+			config:   &APIConfig{Provider: ProviderDashScope, APIKey: "key"},
+			response: `{"output":{"results":[{"index":1,"relevance_score":0.9},{"index":0,"relevance_score":0.1}]},"request_id":"id"}`,
+			check: func(t *testing.T, r *http.Request, body map[string]any) {
+				input := body["input"].(map[string]any)
+				assert.Equal(t, "query", input["query"])
+				assert.Equal(t, []any{"doc0", "doc1"}, input["documents"])
+			},
+		},
+		{
+			name:     "volc",
+			config:   &APIConfig{Provider: ProviderVolc, AK: "ak", SK: "sk"},
+			response: `{"code":0,"data":{"scores":[0.1,0.9]}}`,
+			check: func(t *testing.T, r *http.Request, body map[string]any) {
+				assert.NotEmpty(t, r.Header.Get("Authorization"))
+				assert.Equal(t, "base-multilingual-rerank", body["rerank_model"])
+				datas := body["datas"].([]any)
+				assert.Len(t, datas, 2)
+				assert.Equal(t, "doc1", datas[1].(map[string]any)["content"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				body := map[string]any{}
+				assert.NoError(t, json.Unmarshal(b, &body))
+				tt.check(t, r, body)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			tt.config.Endpoint = srv.URL
+			s, err := NewAPIScorer(ctx, tt.config)
+			assert.NoError(t, err)
+
+			scores, err := s.Score(ctx, "query", []string{"doc0", "doc1"})
+			assert.NoError(t, err)
+			assert.Equal(t, []float64{0.1, 0.9}, scores)
+		})
+	}
+
+	t.Run("error response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"invalid api key"}`))
+		}))
+		defer srv.Close()
+
+		s, err := NewAPIScorer(ctx, &APIConfig{Provider: ProviderCohere, APIKey: "key", Endpoint: srv.URL})
+		assert.NoError(t, err)
+		_, err = s.Score(ctx, "query", []string{"doc0"})
+		assert.ErrorContains(t, err, "401")
+	})
+
+	t.Run("invalid index", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"results":[{"index":3,"relevance_score":0.9}]}`))
+		}))
+		defer srv.Close()
+
+		s, err := NewAPIScorer(ctx, &APIConfig{Provider: ProviderCohere, APIKey: "key", Endpoint: srv.URL})
+		assert.NoError(t, err)
+		_, err = s.Score(ctx, "query", []string{"doc0"})
+		assert.Error(t, err)
+	})
+}
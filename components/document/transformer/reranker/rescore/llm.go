@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rescore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultJudgePrompt = `You are a search relevance judge. Given a query and a document, rate how well the document answers the query on a scale from 0 to 10, where 0 means completely irrelevant and 10 means perfectly relevant. Reply with the number only.`
+
+type LLMConfig struct {
+	// ChatModel judges the relevance of each (query, document) pair.
+	// Required.
+	ChatModel model.ChatModel
+	// SystemPrompt overrides the default judge prompt.
+	// The model is expected to reply a number in [0, MaxScore].
+	// Optional.
+	SystemPrompt string
+	// MaxScore is the upper bound of the scale in prompt, used to normalize scores into [0, 1].
+	// Optional. Default: 10.
+	MaxScore float64
+	// Concurrency is the max number of concurrent model calls.
+	// Optional. Default: 5.
+	Concurrency int
+	// MaxContentLength truncates document content in runes before judging. Zero means no truncation.
+	MaxContentLength int
+}
+
+// NewLLMScorer creates a Scorer which uses a ChatModel as a pointwise relevance judge.
+func NewLLMScorer(_ context.Context, config *LLMConfig) (Scorer, error) {
+	if config == nil || config.ChatModel == nil {
+		return nil, errors.New("[NewLLMScorer] chat model not provided")
+	}
+
+	conf := *config
+	if conf.SystemPrompt == "" {
+		conf.SystemPrompt = defaultJudgePrompt
+	}
+	if conf.MaxScore <= 0 {
+		conf.MaxScore = defaultMaxScore
+	}
+	if conf.Concurrency <= 0 {
+		conf.Concurrency = defaultLLMConcurrency
+	}
+
+	return &llmScorer{config: &conf}, nil
+}
+
+type llmScorer struct {
+	config *LLMConfig
+}
+
+func (l *llmScorer) Score(ctx context.Context, query string, docs []string) ([]float64, error) {
+	scores := make([]float64, len(docs))
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errOnce error
+		sem     = make(chan struct{}, l.config.Concurrency)
+	)
+
+	for i := range docs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			score, err := l.judge(ctx, query, docs[i])
+			if err != nil {
+				mu.Lock()
+				if errOnce == nil {
+					errOnce = fmt.Errorf("judge document[%d] fail: %w", i, err)
+				}
+				mu.Unlock()
+				return
+			}
+			scores[i] = score
+		}(i)
+	}
+	wg.Wait()
+
+	if errOnce != nil {
+		return nil, errOnce
+	}
+
+	return scores, nil
+}
+
+func (l *llmScorer) judge(ctx context.Context, query, content string) (float64, error) {
+	if l.config.MaxContentLength > 0 {
+		if runes := []rune(content); len(runes) > l.config.MaxContentLength {
+			content = string(runes[:l.config.MaxContentLength])
+		}
+	}
+
+	msg, err := l.config.ChatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(l.config.SystemPrompt),
+		schema.UserMessage(fmt.Sprintf("Query: %s\n\nDocument: %s", query, content)),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	score, err := parseScore(msg.Content)
+	if err != nil {
+		return 0, err
+	}
+
+	score /= l.config.MaxScore
+	if score < 0 {
+		score = 0
+	} else if score > 1 {
+		score = 1
+	}
+
+	return score, nil
+}
+
+var numberRegexp = regexp.MustCompile(`-?\d+(\.\d+)?`)
+
+// parseScore extracts the first number in model output.
+func parseScore(output string) (float64, error) {
+	num := numberRegexp.FindString(strings.TrimSpace(output))
+	if num == "" {
+		return 0, fmt.Errorf("no score found in model output: %s", output)
+	}
+	return strconv.ParseFloat(num, 64)
+}
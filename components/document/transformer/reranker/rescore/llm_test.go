@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rescore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChatModel struct {
+	reply func(input []*schema.Message) (string, error)
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	content, err := m.reply(input)
+	if err != nil {
+		return nil, err
+	}
+	return schema.AssistantMessage(content, nil), nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockChatModel) BindTools(tools []*schema.ToolInfo) error {
+	return nil
+}
+
+func TestLLMScorer(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewLLMScorer(ctx, nil)
+	assert.Error(t, err)
+
+	t.Run("score", func(t *testing.T) {
+		cm := &mockChatModel{reply: func(input []*schema.Message) (string, error) {
+			assert.Equal(t, defaultJudgePrompt, input[0].Content)
+			switch {
+			case strings.Contains(input[1].Content, "doc0"):
+				return "Score: 8", nil
+			case strings.Contains(input[1].Content, "doc1"):
+				return "2.5", nil
+			default:
+				return "11", nil
+			}
+		}}
+		s, err := NewLLMScorer(ctx, &LLMConfig{ChatModel: cm, Concurrency: 2})
+		assert.NoError(t, err)
+
+		scores, err := s.Score(ctx, "query", []string{"doc0", "doc1", "doc2"})
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.8, scores[0], 1e-9)
+		assert.InDelta(t, 0.25, scores[1], 1e-9)
+		assert.InDelta(t, 1.0, scores[2], 1e-9)
+	})
+
+	t.Run("truncate content", func(t *testing.T) {
+		cm := &mockChatModel{reply: func(input []*schema.Message) (string, error) {
+			assert.True(t, strings.HasSuffix(input[1].Content, "Document: 你好"))
+			return "5", nil
+		}}
+		s, err := NewLLMScorer(ctx, &LLMConfig{ChatModel: cm, MaxContentLength: 2})
+		assert.NoError(t, err)
+
+		_, err = s.Score(ctx, "query", []string{"你好世界"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		cm := &mockChatModel{reply: func(input []*schema.Message) (string, error) {
+			return "relevant", nil
+		}}
+		s, err := NewLLMScorer(ctx, &LLMConfig{ChatModel: cm})
+		assert.NoError(t, err)
+
+		_, err = s.Score(ctx, "query", []string{"doc0"})
+		assert.Error(t, err)
+	})
+
+	t.Run("model error", func(t *testing.T) {
+		cm := &mockChatModel{reply: func(input []*schema.Message) (string, error) {
+			return "", errors.New("mock err")
+		}}
+		s, err := NewLLMScorer(ctx, &LLMConfig{ChatModel: cm})
+		assert.NoError(t, err)
+
+		_, err = s.Score(ctx, "query", []string{"doc0"})
+		assert.ErrorContains(t, err, "mock err")
+	})
+}
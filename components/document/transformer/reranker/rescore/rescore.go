@@ -0,0 +1,228 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rescore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Scorer rates the relevance of each document content to the query.
+// The returned scores must be in the same order as docs, higher is more relevant.
+type Scorer interface {
+	Score(ctx context.Context, query string, docs []string) ([]float64, error)
+}
+
+type Config struct {
+	// Scorer computes the relevance scores, see NewAPIScorer and NewLLMScorer.
+	// Required.
+	Scorer Scorer
+	// TopN keeps only the N most relevant documents after rescoring. Zero means keeping all.
+	TopN int
+	// ScoreThreshold drops documents whose new score is lower than it.
+	ScoreThreshold *float64
+	// BatchSize is the max number of documents sent to Scorer at once. Zero means all documents in one batch.
+	BatchSize int
+	// Concurrency is the max number of batches scored concurrently. Default is 1.
+	Concurrency int
+	// ScoreFieldKey specifies the metadata key to write the new score.
+	// If empty, the new score is written by Document.WithScore, and the previous score is kept in MetaKeyOriginalScore,
+	// so that score.NewReranker with default config can run after this reranker.
+	ScoreFieldKey string
+}
+
+// NewReranker creates a reranker which rescores (query, document) pairs with Scorer, and sorts documents by the new scores descending.
+// Query must be provided with WithQuery when calling Transform.
+func NewReranker(_ context.Context, config *Config) (document.Transformer, error) {
+	if config == nil || config.Scorer == nil {
+		return nil, errors.New("[NewReranker] scorer not provided")
+	}
+	if config.TopN < 0 {
+		return nil, errors.New("[NewReranker] top n must be greater than or equal to zero")
+	}
+
+	conf := *config
+	if conf.Concurrency <= 0 {
+		conf.Concurrency = 1
+	}
+
+	return &reranker{config: &conf}, nil
+}
+
+type reranker struct {
+	config *Config
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	options := document.GetTransformerImplSpecificOptions(&options{
+		topN: &r.config.TopN,
+	}, opts...)
+	if options.query == "" {
+		return nil, errors.New("[RescoreReranker] query not provided, use rescore.WithQuery")
+	}
+	if len(src) == 0 {
+		return src, nil
+	}
+
+	contents := make([]string, len(src))
+	for i, doc := range src {
+		contents[i] = doc.Content
+	}
+
+	scores, err := r.score(ctx, options.query, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*schema.Document, 0, len(src))
+	for i, doc := range src {
+		if r.config.ScoreThreshold != nil && scores[i] < *r.config.ScoreThreshold {
+			continue
+		}
+
+		nDoc := &schema.Document{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			MetaData: deepCopyMap(doc.MetaData),
+		}
+		if nDoc.MetaData == nil {
+			nDoc.MetaData = make(map[string]any)
+		}
+		if r.config.ScoreFieldKey == "" {
+			nDoc.MetaData[MetaKeyOriginalScore] = doc.Score()
+			nDoc.WithScore(scores[i])
+		} else {
+			nDoc.MetaData[r.config.ScoreFieldKey] = scores[i]
+		}
+		ret = append(ret, nDoc)
+	}
+
+	getter := r.scoreGetter()
+	sort.SliceStable(ret, func(i, j int) bool {
+		return getter(ret[i]) > getter(ret[j])
+	})
+
+	if topN := dereferenceOrZero(options.topN); topN > 0 && len(ret) > topN {
+		ret = ret[:topN]
+	}
+
+	return ret, nil
+}
+
+func (r *reranker) GetType() string {
+	return typ
+}
+
+func (r *reranker) scoreGetter() func(doc *schema.Document) float64 {
+	if r.config.ScoreFieldKey == "" {
+		return func(doc *schema.Document) float64 {
+			return doc.Score()
+		}
+	}
+
+	key := r.config.ScoreFieldKey
+	return func(doc *schema.Document) float64 {
+		v, _ := doc.MetaData[key].(float64)
+		return v
+	}
+}
+
+// score splits contents into batches and scores them with bounded concurrency.
+func (r *reranker) score(ctx context.Context, query string, contents []string) ([]float64, error) {
+	batchSize := r.config.BatchSize
+	if batchSize <= 0 || batchSize > len(contents) {
+		batchSize = len(contents)
+	}
+
+	scores := make([]float64, len(contents))
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errOnce error
+		sem     = make(chan struct{}, r.config.Concurrency)
+	)
+
+	for start := 0; start < len(contents); start += batchSize {
+		end := start + batchSize
+		if end > len(contents) {
+			end = len(contents)
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(start, end int) {
+			defer func() {
+				if e := recover(); e != nil {
+					mu.Lock()
+					if errOnce == nil {
+						errOnce = fmt.Errorf("[RescoreReranker] panic in scorer: %v", e)
+					}
+					mu.Unlock()
+				}
+				<-sem
+				wg.Done()
+			}()
+
+			batch, err := r.config.Scorer.Score(ctx, query, contents[start:end])
+			if err == nil && len(batch) != end-start {
+				err = fmt.Errorf("invalid length of scores, got=%d, expected=%d", len(batch), end-start)
+			}
+			if err != nil {
+				mu.Lock()
+				if errOnce == nil {
+					errOnce = fmt.Errorf("[RescoreReranker] score documents[%d:%d] fail: %w", start, end, err)
+				}
+				mu.Unlock()
+				return
+			}
+			copy(scores[start:end], batch)
+		}(start, end)
+	}
+	wg.Wait()
+
+	if errOnce != nil {
+		return nil, errOnce
+	}
+
+	return scores, nil
+}
+
+func deepCopyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func dereferenceOrZero[T any](v *T) T {
+	if v == nil {
+		var t T
+		return t
+	}
+
+	return *v
+}
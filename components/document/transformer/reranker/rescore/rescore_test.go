@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rescore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// lengthScorer scores documents by content length, so the result order is easy to predict.
+type lengthScorer struct {
+	mu      sync.Mutex
+	batches [][]string
+	err     error
+}
+
+func (l *lengthScorer) Score(ctx context.Context, query string, docs []string) ([]float64, error) {
+	l.mu.Lock()
+	l.batches = append(l.batches, docs)
+	l.mu.Unlock()
+	if l.err != nil {
+		return nil, l.err
+	}
+	scores := make([]float64, len(docs))
+	for i, d := range docs {
+		scores[i] = float64(len(d))
+		if strings.Contains(d, query) {
+			scores[i] += 100
+		}
+	}
+	return scores, nil
+}
+
+func TestNewReranker(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewReranker(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = NewReranker(ctx, &Config{Scorer: &lengthScorer{}, TopN: -1})
+	assert.Error(t, err)
+
+	r, err := NewReranker(ctx, &Config{Scorer: &lengthScorer{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, r.(*reranker).config.Concurrency)
+}
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+	src := []*schema.Document{
+		(&schema.Document{ID: "1", Content: "a"}).WithScore(0.9),
+		(&schema.Document{ID: "2", Content: "bbb"}).WithScore(0.8),
+		(&schema.Document{ID: "3", Content: "cc eino"}).WithScore(0.7),
+		{ID: "4", Content: "dd"},
+	}
+
+	t.Run("query required", func(t *testing.T) {
+		r, err := NewReranker(ctx, &Config{Scorer: &lengthScorer{}})
+		assert.NoError(t, err)
+		_, err = r.Transform(ctx, src)
+		assert.Error(t, err)
+	})
+
+	t.Run("rescore and top n", func(t *testing.T) {
+		scorer := &lengthScorer{}
+		r, err := NewReranker(ctx, &Config{Scorer: scorer, TopN: 3, BatchSize: 3, Concurrency: 2})
+		assert.NoError(t, err)
+
+		docs, err := r.Transform(ctx, src, WithQuery("eino"))
+		assert.NoError(t, err)
+		assert.Len(t, scorer.batches, 2)
+		assert.Equal(t, []string{"3", "2", "4"}, ids(docs))
+		assert.Equal(t, 107.0, docs[0].Score())
+		assert.Equal(t, 0.7, docs[0].MetaData[MetaKeyOriginalScore])
+		// source documents are not modified
+		assert.Equal(t, 0.7, src[2].Score())
+
+		docs, err = r.Transform(ctx, src, WithQuery("eino"), WithTopN(1))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"3"}, ids(docs))
+	})
+
+	t.Run("score field key and threshold", func(t *testing.T) {
+		threshold := 2.0
+		r, err := NewReranker(ctx, &Config{Scorer: &lengthScorer{}, ScoreFieldKey: "rerank", ScoreThreshold: &threshold})
+		assert.NoError(t, err)
+
+		docs, err := r.Transform(ctx, src, WithQuery("eino"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"3", "2", "4"}, ids(docs))
+		assert.Equal(t, 107.0, docs[0].MetaData["rerank"])
+		assert.Equal(t, 0.7, docs[0].Score())
+	})
+
+	t.Run("scorer error", func(t *testing.T) {
+		r, err := NewReranker(ctx, &Config{Scorer: &lengthScorer{err: errors.New("mock err")}})
+		assert.NoError(t, err)
+		_, err = r.Transform(ctx, src, WithQuery("eino"))
+		assert.ErrorContains(t, err, "mock err")
+	})
+}
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, 0, len(docs))
+	for _, d := range docs {
+		ret = append(ret, d.ID)
+	}
+	return ret
+}
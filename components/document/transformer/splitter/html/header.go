@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/andybalholm/cascadia"
 	"golang.org/x/net/html"
 
 	"github.com/cloudwego/eino/components/document"
@@ -34,10 +35,31 @@ type HeaderConfig struct {
 	// Header must be in the format of starting with 'h' followed by a number.
 	// Example: {"h1": "Title", "h2": "Section"} will track h1 and h2 headers
 	Headers map[string]string
+
+	// SectionSelectors are CSS selectors of elements which are section boundaries, e.g. "section", "article", "div.chapter".
+	// Content inside such an element is never merged into the same chunk with content outside of it.
+	SectionSelectors []string
+	// MaxChunkSize if positive, the content under a header is split into chunks no longer than it, at the boundaries of
+	// text and structural units. Tables, lists and code blocks are atomic units that are never cut, even if longer.
+	MaxChunkSize int
+	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	LenFunc func(string) int
+	// SourcePath if true, records the XPath and CSS path of the element containing each chunk in metadata,
+	// see MetaKeyXPath and MetaKeyCSSPath.
+	SourcePath bool
 }
 
+const (
+	// MetaKeyXPath is the XPath of the innermost element containing the whole chunk, e.g. "/html/body/div[2]/p".
+	MetaKeyXPath = "_xpath"
+	// MetaKeyCSSPath is the CSS path of the innermost element containing the whole chunk, e.g. "html > body > div:nth-of-type(2) > p".
+	MetaKeyCSSPath = "_css_path"
+)
+
 // NewHeaderSplitter creates a transformer that splits HTML content based on header tags.
 // It tracks header hierarchy and attaches header text as metadata to the resulting chunks.
+// Tables, lists and <pre> blocks are rendered as Markdown units separated from the surrounding text by blank lines,
+// and are never cut into different chunks.
 //
 // Example:
 //
@@ -62,13 +84,37 @@ type HeaderConfig struct {
 //	     }
 //	   }
 func NewHeaderSplitter(ctx context.Context, config *HeaderConfig) (document.Transformer, error) {
+	sections := make([]cascadia.Matcher, 0, len(config.SectionSelectors))
+	for _, selector := range config.SectionSelectors {
+		m, err := cascadia.Compile(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid section selector %q: %w", selector, err)
+		}
+		sections = append(sections, m)
+	}
+	if config.MaxChunkSize < 0 {
+		return nil, fmt.Errorf("max chunk size must be greater than or equal to zero")
+	}
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
+
 	return &headerSplitter{
-		headers: config.Headers,
+		headers:      config.Headers,
+		sections:     sections,
+		maxChunkSize: config.MaxChunkSize,
+		lenFunc:      lenFunc,
+		sourcePath:   config.SourcePath,
 	}, nil
 }
 
 type headerSplitter struct {
-	headers map[string]string
+	headers      map[string]string
+	sections     []cascadia.Matcher
+	maxChunkSize int
+	lenFunc      func(string) int
+	sourcePath   bool
 }
 
 func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
@@ -90,6 +136,10 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 			for k, v := range result[i].meta {
 				nDoc.MetaData[k] = v
 			}
+			if h.sourcePath && result[i].node != nil {
+				nDoc.MetaData[MetaKeyXPath] = xPath(result[i].node)
+				nDoc.MetaData[MetaKeyCSSPath] = cssPath(result[i].node)
+			}
 			ret = append(ret, nDoc)
 		}
 	}
@@ -103,6 +153,74 @@ func (h *headerSplitter) GetType() string {
 type splitResult struct {
 	chunk string
 	meta  map[string]string
+	// node is the innermost element containing the chunk.
+	node *html.Node
+}
+
+// segment is a piece of content under a header, either raw text or a structural unit rendered as Markdown.
+type segment struct {
+	text string
+	unit bool
+	node *html.Node
+}
+
+// section collects the segments of the current chunk.
+type section struct {
+	segments []segment
+}
+
+func (s *section) add(seg segment) {
+	s.segments = append(s.segments, seg)
+}
+
+// flush appends the collected segments to ret as chunks with meta, and resets the section.
+func (h *headerSplitter) flush(s *section, meta map[string]string, ret *[]splitResult) {
+	if len(s.segments) == 0 {
+		return
+	}
+
+	var group []segment
+	size := 0
+	for _, seg := range s.segments {
+		if h.maxChunkSize > 0 && len(group) > 0 {
+			if size+h.lenFunc(joinSeparator(group[len(group)-1], seg)+seg.text) > h.maxChunkSize {
+				*ret = append(*ret, newSplitResult(group, meta))
+				group, size = nil, 0
+			}
+		}
+		if len(group) > 0 {
+			size += h.lenFunc(joinSeparator(group[len(group)-1], seg))
+		}
+		group = append(group, seg)
+		size += h.lenFunc(seg.text)
+	}
+	*ret = append(*ret, newSplitResult(group, meta))
+	s.segments = nil
+}
+
+func newSplitResult(segments []segment, meta map[string]string) splitResult {
+	sb := strings.Builder{}
+	var node *html.Node
+	for i, seg := range segments {
+		if i > 0 {
+			sb.WriteString(joinSeparator(segments[i-1], seg))
+		}
+		sb.WriteString(seg.text)
+		node = commonAncestor(node, seg.node)
+	}
+	return splitResult{
+		chunk: sb.String(),
+		meta:  deepCopyMap(meta),
+		node:  node,
+	}
+}
+
+// joinSeparator returns the separator between two segments, text is joined as is and units are separated by blank lines.
+func joinSeparator(prev, next segment) string {
+	if prev.unit || next.unit {
+		return "\n\n"
+	}
+	return ""
 }
 
 type metaRecord struct {
@@ -114,7 +232,7 @@ type metaRecord struct {
 func (h *headerSplitter) splitText(ctx context.Context, text string) ([]splitResult, error) {
 	var recordedMetaList []metaRecord
 	recordedMetaMap := make(map[string]string)
-	currentText := &strings.Builder{}
+	current := &section{}
 	var ret []splitResult
 
 	tree, err := html.Parse(strings.NewReader(text))
@@ -122,32 +240,21 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) ([]splitRes
 		return nil, err
 	}
 
-	err = h.dfs(tree, recordedMetaList, recordedMetaMap, currentText, &ret)
+	err = h.dfs(tree, recordedMetaList, recordedMetaMap, current, &ret)
 	if err != nil {
 		return nil, err
 	}
-	if currentText.Len() > 0 {
-		ret = append(ret, splitResult{
-			chunk: currentText.String(),
-			meta:  map[string]string{},
-		})
-	}
+	h.flush(current, map[string]string{}, &ret)
 	return ret, nil
 }
 
-func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, recordedMetaMap map[string]string, currentText *strings.Builder, ret *[]splitResult) error {
+func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, recordedMetaMap map[string]string, current *section, ret *[]splitResult) error {
 	hasHeader := false
 	for ; node != nil; node = node.NextSibling {
 		if _, ok := h.headers[node.Data]; ok && node.Type == html.ElementNode {
 			hasHeader = true
 
-			if currentText.Len() > 0 {
-				*ret = append(*ret, splitResult{
-					chunk: currentText.String(),
-					meta:  deepCopyMap(recordedMetaMap),
-				})
-				currentText.Reset()
-			}
+			h.flush(current, recordedMetaMap, ret)
 
 			newLevel, success := calHLevel(node.Data)
 			if !success {
@@ -178,25 +285,48 @@ func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, rec
 			recordedMetaMap[record.name] = record.data
 			continue
 		}
+		if node.Type == html.ElementNode {
+			if unit, ok := renderUnit(node); ok {
+				if unit != "" {
+					current.add(segment{text: unit, unit: true, node: node})
+				}
+				continue
+			}
+		}
 		if node.Type == html.TextNode && len(strings.TrimSpace(node.Data)) != 0 {
-			currentText.WriteString(node.Data)
+			current.add(segment{text: node.Data, node: node.Parent})
 		}
 
-		err := h.dfs(node.FirstChild, deepCopySlice(recordedMetaList), deepCopyMap(recordedMetaMap), currentText, ret)
+		isSection := h.isSection(node)
+		if isSection {
+			h.flush(current, recordedMetaMap, ret)
+		}
+		err := h.dfs(node.FirstChild, deepCopySlice(recordedMetaList), deepCopyMap(recordedMetaMap), current, ret)
 		if err != nil {
 			return err
 		}
+		if isSection {
+			h.flush(current, recordedMetaMap, ret)
+		}
 	}
-	if hasHeader && currentText.Len() > 0 {
-		*ret = append(*ret, splitResult{
-			chunk: currentText.String(),
-			meta:  deepCopyMap(recordedMetaMap),
-		})
-		currentText.Reset()
+	if hasHeader {
+		h.flush(current, recordedMetaMap, ret)
 	}
 	return nil
 }
 
+func (h *headerSplitter) isSection(node *html.Node) bool {
+	if node.Type != html.ElementNode {
+		return false
+	}
+	for _, m := range h.sections {
+		if m.Match(node) {
+			return true
+		}
+	}
+	return false
+}
+
 func extractText(node *html.Node) (string, error) {
 	sb := strings.Builder{}
 
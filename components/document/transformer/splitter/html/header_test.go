@@ -60,6 +60,37 @@ var commonSuccessHTML = `<!DOCTYPE html>
 </body>
 </html>`
 
+var unitsHTML = `<h1>Data</h1>
+<p>intro</p>
+<table>
+  <thead><tr><th>Name</th><th>Age</th></tr></thead>
+  <tbody>
+    <tr><td>a|b</td><td>1</td></tr>
+    <tr><td>c</td></tr>
+  </tbody>
+</table>
+<ul>
+  <li>one</li>
+  <li>two
+    <ol><li>x</li><li>y</li></ol>
+  </li>
+</ul>
+<h1>Code</h1>
+<pre><code class="language-go">func main() {
+	println("` + "```" + `")
+}
+</code></pre>
+<p>outro</p>`
+
+var sectionsHTML = `<html><body>
+<h1>Title</h1>
+<p>before</p>
+<section><p>s1</p><p>s2</p></section>
+<div></div>
+<div class="chapter"><p>chapter</p></div>
+<p>after</p>
+</body></html>`
+
 func TestHTMLHeaderSplitter(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -143,6 +174,94 @@ func TestHTMLHeaderSplitter(t *testing.T) {
 			},
 			},
 		},
+		{
+			name: "atomic units",
+			config: &HeaderConfig{
+				Headers: map[string]string{"h1": "Header1"},
+			},
+			input: []*schema.Document{{
+				ID:      "id",
+				Content: unitsHTML,
+			}},
+			want: []*schema.Document{{
+				ID:       "id",
+				Content:  "intro\n\n| Name | Age |\n| --- | --- |\n| a\\|b | 1 |\n| c |  |\n\n- one\n- two\n  1. x\n  2. y",
+				MetaData: map[string]interface{}{"Header1": "Data"},
+			}, {
+				ID:       "id",
+				Content:  "````go\nfunc main() {\n\tprintln(\"```\")\n}\n````\n\noutro",
+				MetaData: map[string]interface{}{"Header1": "Code"},
+			}},
+		},
+		{
+			name: "sections and source path",
+			config: &HeaderConfig{
+				Headers:          map[string]string{"h1": "Header1"},
+				SectionSelectors: []string{"section", "div.chapter"},
+				SourcePath:       true,
+			},
+			input: []*schema.Document{{
+				ID:      "id",
+				Content: sectionsHTML,
+			}},
+			want: []*schema.Document{{
+				ID:      "id",
+				Content: "before",
+				MetaData: map[string]interface{}{
+					"Header1":      "Title",
+					MetaKeyXPath:   "/html/body/p[1]",
+					MetaKeyCSSPath: "html > body > p:nth-of-type(1)",
+				},
+			}, {
+				ID:      "id",
+				Content: "s1s2",
+				MetaData: map[string]interface{}{
+					"Header1":      "Title",
+					MetaKeyXPath:   "/html/body/section",
+					MetaKeyCSSPath: "html > body > section",
+				},
+			}, {
+				ID:      "id",
+				Content: "chapter",
+				MetaData: map[string]interface{}{
+					"Header1":      "Title",
+					MetaKeyXPath:   "/html/body/div[2]/p",
+					MetaKeyCSSPath: "html > body > div:nth-of-type(2) > p",
+				},
+			}, {
+				ID:      "id",
+				Content: "after",
+				MetaData: map[string]interface{}{
+					"Header1":      "Title",
+					MetaKeyXPath:   "/html/body/p[2]",
+					MetaKeyCSSPath: "html > body > p:nth-of-type(2)",
+				},
+			}},
+		},
+		{
+			name: "max chunk size",
+			config: &HeaderConfig{
+				Headers:      map[string]string{"h1": "Header1"},
+				MaxChunkSize: 12,
+			},
+			input: []*schema.Document{{
+				ID:      "id",
+				Content: "<h1>T</h1><p>aaaa</p><p>bbbb</p><p>cccc</p><ul><li>long list item</li></ul><p>dd</p>",
+			}},
+			want: []*schema.Document{{
+				ID:       "id",
+				Content:  "aaaabbbbcccc",
+				MetaData: map[string]interface{}{"Header1": "T"},
+			}, {
+				ID:       "id",
+				Content:  "- long list item",
+				MetaData: map[string]interface{}{"Header1": "T"},
+			}, {
+				ID:       "id",
+				Content:  "dd",
+				MetaData: map[string]interface{}{"Header1": "T"},
+			}},
+		},
 	}
 	ctx := context.Background()
 	for _, tt := range tests {
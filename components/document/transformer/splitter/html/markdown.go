@@ -0,0 +1,282 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// renderUnit renders structural elements which should be kept atomic as Markdown, in the same way as the html parser.
+// TODO: use RenderMarkdown of the html parser once it is in a published version.
+// ok is false if node is not such an element.
+func renderUnit(node *html.Node) (text string, ok bool) {
+	switch node.Data {
+	case "table":
+		return renderTable(node), true
+	case "ul", "ol":
+		return strings.Join(renderList(node, ""), "\n"), true
+	case "pre":
+		return renderPre(node), true
+	default:
+		return "", false
+	}
+}
+
+func renderTable(table *html.Node) string {
+	var rows [][]string
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "thead", "tbody", "tfoot":
+				collect(c)
+			case "tr":
+				var row []string
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.Data == "th" || cell.Data == "td") {
+						row = append(row, strings.ReplaceAll(inlineText(cell), "|", "\\|"))
+					}
+				}
+				if len(row) > 0 {
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	collect(table)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	sb := strings.Builder{}
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for i := 0; i < columns; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+	}
+	writeRow(rows[0])
+	sb.WriteString("\n|")
+	for i := 0; i < columns; i++ {
+		sb.WriteString(" --- |")
+	}
+	for _, row := range rows[1:] {
+		sb.WriteString("\n")
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+func renderList(list *html.Node, indent string) []string {
+	ordered := list.Data == "ol"
+	number := 1
+	if ordered {
+		if start, err := strconv.Atoi(getAttr(list, "start")); err == nil {
+			number = start
+		}
+	}
+
+	var lines []string
+	for item := list.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.Data != "li" {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		var text []string
+		var nested []string
+		for c := item.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && (c.Data == "ul" || c.Data == "ol") {
+				nested = append(nested, renderList(c, indent+strings.Repeat(" ", len(marker)))...)
+				continue
+			}
+			if t := inlineText(c); t != "" {
+				text = append(text, t)
+			}
+		}
+		lines = append(lines, indent+marker+strings.Join(text, " "))
+		lines = append(lines, nested...)
+	}
+	return lines
+}
+
+func renderPre(pre *html.Node) string {
+	content := strings.TrimSuffix(rawText(pre), "\n")
+	if strings.TrimSpace(content) == "" {
+		return ""
+	}
+
+	lang := codeLanguage(pre)
+	if lang == "" {
+		for c := pre.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.Data == "code" {
+				lang = codeLanguage(c)
+				break
+			}
+		}
+	}
+
+	// the fence must be longer than any backtick run in the content
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	fenceLen := 3
+	if longest >= fenceLen {
+		fenceLen = longest + 1
+	}
+	fence := strings.Repeat("`", fenceLen)
+	return fence + lang + "\n" + content + "\n" + fence
+}
+
+// codeLanguage reads the language from class names like "language-go" or "lang-go".
+func codeLanguage(node *html.Node) string {
+	for _, class := range strings.Fields(getAttr(node, "class")) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if strings.HasPrefix(class, prefix) {
+				return strings.TrimPrefix(class, prefix)
+			}
+		}
+	}
+	return ""
+}
+
+// inlineText returns the text of node with whitespace collapsed.
+func inlineText(node *html.Node) string {
+	return strings.Join(strings.Fields(rawText(node)), " ")
+}
+
+func rawText(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+	sb := strings.Builder{}
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "br" {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(rawText(c))
+	}
+	return sb.String()
+}
+
+func getAttr(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// commonAncestor returns the innermost node containing both a and b.
+func commonAncestor(a, b *html.Node) *html.Node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	ancestors := map[*html.Node]bool{}
+	for n := a; n != nil; n = n.Parent {
+		ancestors[n] = true
+	}
+	for n := b; n != nil; n = n.Parent {
+		if ancestors[n] {
+			return n
+		}
+	}
+	return nil
+}
+
+// xPath returns the absolute XPath of an element, e.g. "/html/body/div[2]/p".
+func xPath(node *html.Node) string {
+	var steps []string
+	for n := node; n != nil && n.Type == html.ElementNode; n = n.Parent {
+		step := n.Data
+		if index, count := typeIndex(n); count > 1 {
+			step += "[" + strconv.Itoa(index) + "]"
+		}
+		steps = append(steps, step)
+	}
+	reverse(steps)
+	return "/" + strings.Join(steps, "/")
+}
+
+// cssPath returns the CSS selector path of an element, e.g. "html > body > div:nth-of-type(2) > p".
+func cssPath(node *html.Node) string {
+	var steps []string
+	for n := node; n != nil && n.Type == html.ElementNode; n = n.Parent {
+		step := n.Data
+		if index, count := typeIndex(n); count > 1 {
+			step += ":nth-of-type(" + strconv.Itoa(index) + ")"
+		}
+		steps = append(steps, step)
+	}
+	reverse(steps)
+	return strings.Join(steps, " > ")
+}
+
+// typeIndex returns the 1-based position of node among the sibling elements with the same tag, and the number of them.
+func typeIndex(node *html.Node) (index, count int) {
+	if node.Parent == nil {
+		return 1, 1
+	}
+	for c := node.Parent.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == node.Data {
+			count++
+			if c == node {
+				index = count
+			}
+		}
+	}
+	return index, count
+}
+
+func reverse(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
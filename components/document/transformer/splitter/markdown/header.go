@@ -20,14 +20,21 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
 )
 
+// MetaKeyHeaderPath is the breadcrumb of the headers of the chunk from the top level down, value: []string.
+// Only the headers specified in HeaderConfig.Headers are recorded.
+const MetaKeyHeaderPath = "_header_path"
+
 type HeaderConfig struct {
 	// Headers specify the headers to be identified and their names in document metadata.
-	// Headers can only consist of '#'.
+	// Headers can only consist of '#'. As in CommonMark, the '#'s of a header line must be followed by
+	// a space or a tab, "##Title 2" is not a header. Setext headers underlined by "===" and "---" are
+	// identified as "#" and "##".
 	// e.g.
 	// 	// the Header Config:
 	// 	config := &HeaderConfig{
@@ -37,48 +44,90 @@ type HeaderConfig struct {
 	//
 	// 	// the original document:
 	// 	originDoc := &schema.Document{
-	// 		Content: "hell\n##Title 2\n hello world",
+	// 		Content: "hell\n## Title 2\n hello world",
 	// 	}
 	//
 	// 	// one of the split documents:
 	// 	splitDoc := &schema.Document{
-	// 		Content: "##Title 2\n hello world",
+	// 		Content: "## Title 2\nhello world",
 	// 		Metadata: map[string]any{
 	// 			// other fields
 	// 			"headerNameOfLevel2": "Title 2",
+	// 			"_header_path": []string{"Title 2"},
 	// 		},
 	// 	}
 	Headers map[string]string
 	// TrimHeaders specify if results contain header lines.
 	TrimHeaders bool
+
+	// MaxChunkSize if positive, sections longer than it are split further, every piece keeps the metadata of the section.
+	MaxChunkSize int
+	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	LenFunc func(string) int
+	// SubSplitter is used to split the sections longer than MaxChunkSize, e.g. a recursive splitter.
+	// Optional, sections are split by lines by default, fenced code blocks are kept whole unless they alone exceed MaxChunkSize.
+	SubSplitter document.Transformer
 }
 
 func NewHeaderSplitter(ctx context.Context, config *HeaderConfig) (document.Transformer, error) {
 	if len(config.Headers) == 0 {
 		return nil, fmt.Errorf("no headers specified")
 	}
-	for k := range config.Headers {
+	levels := make(map[int]string, len(config.Headers))
+	for k, name := range config.Headers {
 		for _, c := range k {
 			if c != '#' {
 				return nil, fmt.Errorf("header can only consist of '#': %s", k)
 			}
 		}
+		if len(k) > 6 {
+			return nil, fmt.Errorf("header level must not be greater than 6: %s", k)
+		}
+		levels[len(k)] = name
+	}
+	if config.MaxChunkSize < 0 {
+		return nil, fmt.Errorf("max chunk size must be greater than or equal to zero")
+	}
+	if config.SubSplitter != nil && config.MaxChunkSize == 0 {
+		return nil, fmt.Errorf("max chunk size must be specified with sub splitter")
+	}
+
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
 	}
 
 	return &headerSplitter{
-		headers:     config.Headers,
-		trimHeaders: config.TrimHeaders,
+		levels:       levels,
+		trimHeaders:  config.TrimHeaders,
+		maxChunkSize: config.MaxChunkSize,
+		lenFunc:      lenFunc,
+		subSplitter:  config.SubSplitter,
 	}, nil
 }
 
 type headerSplitter struct {
-	headers     map[string]string
-	trimHeaders bool
+	// levels maps the levels of headers to their names in metadata.
+	levels       map[int]string
+	trimHeaders  bool
+	maxChunkSize int
+	lenFunc      func(string) int
+	subSplitter  document.Transformer
 }
 
 type splitResult struct {
-	chunk string
-	meta  map[string]string
+	// blocks are the lines of the chunk, a fenced code block is a single block of multiple lines.
+	blocks [][]string
+	meta   map[string]string
+	path   []string
+}
+
+func (r *splitResult) chunk() string {
+	lines := make([]string, 0, len(r.blocks))
+	for _, b := range r.blocks {
+		lines = append(lines, b...)
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
@@ -86,18 +135,26 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 	for _, doc := range docs {
 		result := h.splitText(ctx, doc.Content)
 		for i := range result {
-			nDoc := &schema.Document{
-				ID:       doc.ID,
-				Content:  result[i].chunk,
-				MetaData: deepCopyAnyMap(doc.MetaData),
-			}
-			if nDoc.MetaData == nil {
-				nDoc.MetaData = make(map[string]any, len(result[i].meta))
+			pieces, err := h.subSplit(ctx, &result[i])
+			if err != nil {
+				return nil, fmt.Errorf("split section of document[%s] fail: %w", doc.ID, err)
 			}
-			for k, v := range result[i].meta {
-				nDoc.MetaData[k] = v
+
+			for _, piece := range pieces {
+				nDoc := &schema.Document{
+					ID:       doc.ID,
+					Content:  piece,
+					MetaData: deepCopyAnyMap(doc.MetaData),
+				}
+				if nDoc.MetaData == nil {
+					nDoc.MetaData = make(map[string]any, len(result[i].meta)+1)
+				}
+				for k, v := range result[i].meta {
+					nDoc.MetaData[k] = v
+				}
+				nDoc.MetaData[MetaKeyHeaderPath] = append([]string{}, result[i].path...)
+				ret = append(ret, nDoc)
 			}
-			ret = append(ret, nDoc)
 		}
 	}
 	return ret, nil
@@ -107,10 +164,77 @@ func (h *headerSplitter) GetType() string {
 	return "MarkdownHeaderSplitter"
 }
 
-const (
-	codeSep1 = "```"
-	codeSep2 = "~~~"
-)
+// subSplit splits the section if it is longer than maxChunkSize.
+func (h *headerSplitter) subSplit(ctx context.Context, r *splitResult) ([]string, error) {
+	chunk := r.chunk()
+	if h.maxChunkSize <= 0 || h.lenFunc(chunk) <= h.maxChunkSize {
+		return []string{chunk}, nil
+	}
+
+	if h.subSplitter != nil {
+		docs, err := h.subSplitter.Transform(ctx, []*schema.Document{{Content: chunk}})
+		if err != nil {
+			return nil, err
+		}
+		pieces := make([]string, 0, len(docs))
+		for _, d := range docs {
+			pieces = append(pieces, d.Content)
+		}
+		return pieces, nil
+	}
+
+	var (
+		pieces  []string
+		current []string
+	)
+	flush := func() {
+		if len(current) > 0 {
+			pieces = append(pieces, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+	add := func(lines []string) {
+		if len(current) > 0 && h.lenFunc(strings.Join(append(current[:len(current):len(current)], lines...), "\n")) > h.maxChunkSize {
+			flush()
+		}
+		current = append(current, lines...)
+	}
+
+	for _, b := range r.blocks {
+		if h.lenFunc(strings.Join(b, "\n")) <= h.maxChunkSize {
+			add(b)
+			continue
+		}
+		// the block alone is too long, split it by lines, and by characters if a line is too long.
+		for _, line := range b {
+			if h.lenFunc(line) <= h.maxChunkSize {
+				add([]string{line})
+				continue
+			}
+			flush()
+			pieces = append(pieces, h.splitByChars(line)...)
+		}
+	}
+	flush()
+
+	return pieces, nil
+}
+
+func (h *headerSplitter) splitByChars(line string) []string {
+	var (
+		pieces []string
+		start  int
+	)
+	for end := 0; end < len(line); {
+		_, size := utf8.DecodeRuneInString(line[end:])
+		if end > start && h.lenFunc(line[start:end+size]) > h.maxChunkSize {
+			pieces = append(pieces, line[start:end])
+			start = end
+		}
+		end += size
+	}
+	return append(pieces, line[start:])
+}
 
 type metaRecord struct {
 	name  string
@@ -118,95 +242,253 @@ type metaRecord struct {
 	data  string
 }
 
+// splitText splits text at the ATX and setext headings specified in levels, following CommonMark:
+// headings must not be indented by more than three spaces, and lines in fenced code blocks are never headings.
+// Lines outside code blocks are trimmed and blank ones are dropped, lines inside code blocks are kept as is
+// except for the indentation of the opening fence.
 func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResult {
-	var recordedMetaList []metaRecord
-	recordedMetaMap := make(map[string]string)
-	var currentLines []string
-	var bInCodeBlock bool
-	var openingFence string
-	var ret []splitResult
-	lines := strings.Split(text, "\n")
-	for _, line := range lines {
+	var (
+		recordedMetaList []metaRecord
+		currentBlocks    [][]string
+		// paragraph is the number of trailing blocks of currentBlocks that form a paragraph, which may be a setext heading.
+		paragraph int
+		fence     *codeFence
+		ret       []splitResult
+	)
+
+	startSection := func(level int, title string, headerLines []string) {
+		if len(currentBlocks) > 0 {
+			ret = append(ret, newSplitResult(currentBlocks, recordedMetaList))
+			currentBlocks = nil
+		}
+		if !h.trimHeaders {
+			currentBlocks = append(currentBlocks, headerLines)
+		}
+
+		for i := len(recordedMetaList) - 1; i >= 0; i-- {
+			if recordedMetaList[i].level >= level {
+				recordedMetaList = recordedMetaList[:i]
+			} else {
+				break
+			}
+		}
+		recordedMetaList = append(recordedMetaList, metaRecord{
+			name:  h.levels[level],
+			level: level,
+			data:  title,
+		})
+		paragraph = 0
+	}
+
+	for _, rawLine := range strings.Split(text, "\n") {
+		rawLine = strings.TrimSuffix(rawLine, "\r")
+
+		if fence != nil {
+			block := currentBlocks[len(currentBlocks)-1]
+			if fence.closedBy(rawLine) {
+				currentBlocks[len(currentBlocks)-1] = append(block, strings.TrimSpace(rawLine))
+				fence = nil
+			} else {
+				currentBlocks[len(currentBlocks)-1] = append(block, fence.content(rawLine))
+			}
+			continue
+		}
+
+		line := strings.TrimSpace(rawLine)
 		if len(line) == 0 {
+			paragraph = 0
 			continue
 		}
-		line = strings.TrimSpace(line)
-		if !bInCodeBlock {
-			if strings.HasPrefix(line, codeSep1) && strings.Count(line, codeSep1) == 1 {
-				bInCodeBlock = true
-				openingFence = codeSep1
-			} else if strings.HasPrefix(line, codeSep2) {
-				bInCodeBlock = true
-				openingFence = codeSep2
-			}
-		} else {
-			if strings.HasPrefix(line, openingFence) {
-				bInCodeBlock = false
-				openingFence = ""
+		indent := indentation(rawLine)
+		if indent >= 4 {
+			// indented code or lazy continuation, never a heading.
+			currentBlocks = append(currentBlocks, []string{line})
+			if paragraph > 0 {
+				paragraph++
 			}
+			continue
 		}
-		if bInCodeBlock {
-			currentLines = append(currentLines, line)
+
+		if f := openFence(rawLine, indent); f != nil {
+			fence = f
+			currentBlocks = append(currentBlocks, []string{line})
+			paragraph = 0
 			continue
 		}
-		// check if the line starts with headers
-		bNewHeader := false
-		for header, name := range h.headers {
-			if strings.HasPrefix(line, header) && (len(line) == len(header) || line[len(header)] == ' ') {
-				if len(currentLines) > 0 {
-					ret = append(ret, splitResult{
-						chunk: strings.Join(currentLines, "\n"),
-						meta:  deepCopyMap(recordedMetaMap),
-					})
-					currentLines = currentLines[:0]
-				}
 
-				if !h.trimHeaders {
-					currentLines = append(currentLines, line)
-				}
+		if level, title, ok := atxHeading(line); ok {
+			if _, tracked := h.levels[level]; tracked {
+				startSection(level, title, []string{line})
+				continue
+			}
+			currentBlocks = append(currentBlocks, []string{line})
+			paragraph = 0
+			continue
+		}
 
-				newLevel := len(header)
-				for i := len(recordedMetaList) - 1; i >= 0; i-- {
-					if recordedMetaList[i].level >= newLevel {
-						delete(recordedMetaMap, recordedMetaList[i].name)
-						recordedMetaList = recordedMetaList[:i]
-					} else {
-						break
-					}
+		if level, ok := setextUnderline(line); ok && paragraph > 0 {
+			if _, tracked := h.levels[level]; tracked {
+				var titleLines []string
+				for _, b := range currentBlocks[len(currentBlocks)-paragraph:] {
+					titleLines = append(titleLines, b...)
 				}
-
-				data := strings.TrimSpace(line[len(header):])
-				recordedMetaList = append(recordedMetaList, metaRecord{
-					name:  name,
-					level: newLevel,
-					data:  data,
-				})
-				recordedMetaMap[name] = data
-
-				bNewHeader = true
-				break
+				currentBlocks = currentBlocks[:len(currentBlocks)-paragraph]
+				startSection(level, strings.Join(titleLines, " "), append(titleLines, line))
+				continue
 			}
+			currentBlocks = append(currentBlocks, []string{line})
+			paragraph = 0
+			continue
 		}
-		if !bNewHeader {
-			currentLines = append(currentLines, line)
+
+		currentBlocks = append(currentBlocks, []string{line})
+		if startsBlock(line) {
+			paragraph = 0
+		} else {
+			paragraph++
 		}
 	}
-	ret = append(ret, splitResult{
-		chunk: strings.Join(currentLines, "\n"),
-		meta:  deepCopyMap(recordedMetaMap),
-	})
+	ret = append(ret, newSplitResult(currentBlocks, recordedMetaList))
 	return ret
 }
 
-func deepCopyMap(m map[string]string) map[string]string {
-	if m == nil {
+func newSplitResult(blocks [][]string, records []metaRecord) splitResult {
+	r := splitResult{
+		blocks: blocks,
+		meta:   make(map[string]string, len(records)),
+		path:   make([]string, 0, len(records)),
+	}
+	for _, record := range records {
+		r.meta[record.name] = record.data
+		r.path = append(r.path, record.data)
+	}
+	return r
+}
+
+type codeFence struct {
+	char   byte
+	length int
+	indent int
+}
+
+// openFence returns the fence if the line opens a fenced code block, e.g. "```go" or "~~~~".
+func openFence(rawLine string, indent int) *codeFence {
+	line := rawLine[indent:]
+	if len(line) < 3 || (line[0] != '`' && line[0] != '~') {
 		return nil
 	}
-	ret := make(map[string]string)
-	for k, v := range m {
-		ret[k] = v
+
+	n := countPrefix(line, line[0])
+	if n < 3 {
+		return nil
 	}
-	return ret
+	// the info string of a backtick fence must not contain backticks, or it is inline code.
+	if line[0] == '`' && strings.Contains(line[n:], "`") {
+		return nil
+	}
+
+	return &codeFence{char: line[0], length: n, indent: indent}
+}
+
+// closedBy returns if the line closes the fence, which is a run of the same char at least as long with nothing after it.
+func (f *codeFence) closedBy(rawLine string) bool {
+	indent := indentation(rawLine)
+	if indent >= 4 {
+		return false
+	}
+	line := rawLine[indent:]
+	n := countPrefix(line, f.char)
+
+	return n >= f.length && strings.TrimSpace(line[n:]) == ""
+}
+
+// content removes the indentation of the opening fence from the line.
+func (f *codeFence) content(rawLine string) string {
+	n := countPrefix(rawLine, ' ')
+	if n > f.indent {
+		n = f.indent
+	}
+	return rawLine[n:]
+}
+
+// atxHeading parses headings like "## Title ##".
+func atxHeading(line string) (int, string, bool) {
+	level := countPrefix(line, '#')
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+	rest := line[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "", false
+	}
+
+	title := strings.TrimSpace(rest)
+	// remove the optional closing sequence, which must be preceded by a space.
+	trimmed := strings.TrimRight(title, "#")
+	if trimmed == "" {
+		title = ""
+	} else if len(trimmed) < len(title) && (trimmed[len(trimmed)-1] == ' ' || trimmed[len(trimmed)-1] == '\t') {
+		title = strings.TrimSpace(trimmed)
+	}
+
+	return level, title, true
+}
+
+// setextUnderline returns the level of a setext heading underline, "===" for level 1 and "---" for level 2.
+func setextUnderline(line string) (int, bool) {
+	switch line[0] {
+	case '=':
+		if countPrefix(line, '=') == len(line) {
+			return 1, true
+		}
+	case '-':
+		if countPrefix(line, '-') == len(line) {
+			return 2, true
+		}
+	}
+	return 0, false
+}
+
+// startsBlock returns if the line starts a block that cannot be the content of a setext heading.
+func startsBlock(line string) bool {
+	switch line[0] {
+	case '>', '|', '<':
+		return true
+	case '-', '*', '+':
+		return len(line) == 1 || line[1] == ' ' || line[1] == '\t'
+	}
+
+	digits := 0
+	for digits < len(line) && digits < 9 && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	return digits > 0 && digits < len(line) && (line[digits] == '.' || line[digits] == ')') &&
+		(digits+1 == len(line) || line[digits+1] == ' ')
+}
+
+// indentation returns the width of leading white spaces, a tab counts to the next multiple of 4.
+// The indentation is made of spaces only if it is less than 4.
+func indentation(line string) int {
+	n := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			n++
+		case '\t':
+			n += 4 - n%4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+func countPrefix(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
 }
 
 func deepCopyAnyMap(anyMap map[string]any) map[string]any {
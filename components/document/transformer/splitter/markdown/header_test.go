@@ -19,8 +19,10 @@ package markdown
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
 )
 
@@ -50,29 +52,136 @@ func TestMarkdownHeaderSplitter(t *testing.T) {
 				ID:      "id",
 				Content: "```code1\ncode2\ncode3\n```",
 				MetaData: map[string]interface{}{
-					"Header1": "Header1",
+					"Header1":         "Header1",
+					MetaKeyHeaderPath: []string{"Header1"},
 				},
 			}, {
 				ID:      "id",
 				Content: "Content1",
 				MetaData: map[string]interface{}{
-					"Header1": "Header1",
-					"Header2": "Header2",
+					"Header1":         "Header1",
+					"Header2":         "Header2",
+					MetaKeyHeaderPath: []string{"Header1", "Header2"},
 				},
 			}, {
 				ID:      "id",
 				Content: "Content2",
 				MetaData: map[string]interface{}{
-					"Header1": "Header1",
-					"Header2": "Header2",
-					"Header3": "Header3",
+					"Header1":         "Header1",
+					"Header2":         "Header2",
+					"Header3":         "Header3",
+					MetaKeyHeaderPath: []string{"Header1", "Header2", "Header3"},
 				},
 			}, {
 				ID:      "id",
 				Content: "Content3",
 				MetaData: map[string]interface{}{
-					"Header1": "Header1",
-					"Header2": "Header4",
+					"Header1":         "Header1",
+					"Header2":         "Header4",
+					MetaKeyHeaderPath: []string{"Header1", "Header4"},
+				},
+			}},
+		},
+		{
+			name: "code fences, setext and closing sequences",
+			config: &HeaderConfig{
+				Headers: map[string]string{
+					"#":  "h1",
+					"##": "h2",
+				},
+			},
+			input: []*schema.Document{{
+				Content: "Guide\n=====\nintro\n````md\n# not a header\n\n  indented\n```\n````\n" +
+					"    # indented code\nPart One\nstill title\n---\n## Part Two ##\n#hashtag\n\n- item\n---\n~~~\n## in tilde\n~~~",
+			}},
+			want: []*schema.Document{{
+				Content: "Guide\n=====\nintro\n````md\n# not a header\n\n  indented\n```\n````\n# indented code",
+				MetaData: map[string]interface{}{
+					"h1":              "Guide",
+					MetaKeyHeaderPath: []string{"Guide"},
+				},
+			}, {
+				Content: "Part One\nstill title\n---",
+				MetaData: map[string]interface{}{
+					"h1":              "Guide",
+					"h2":              "Part One still title",
+					MetaKeyHeaderPath: []string{"Guide", "Part One still title"},
+				},
+			}, {
+				Content: "## Part Two ##\n#hashtag\n- item\n---\n~~~\n## in tilde\n~~~",
+				MetaData: map[string]interface{}{
+					"h1":              "Guide",
+					"h2":              "Part Two",
+					MetaKeyHeaderPath: []string{"Guide", "Part Two"},
+				},
+			}},
+		},
+		{
+			name: "header needs a space after the hashes",
+			config: &HeaderConfig{
+				Headers: map[string]string{"##": "headerNameOfLevel2"},
+			},
+			input: []*schema.Document{{
+				Content: "hell\n## Title 2\n hello world\n##Title 3\nnot a header",
+			}},
+			want: []*schema.Document{{
+				Content: "hell",
+				MetaData: map[string]interface{}{
+					MetaKeyHeaderPath: []string{},
+				},
+			}, {
+				Content: "## Title 2\nhello world\n##Title 3\nnot a header",
+				MetaData: map[string]interface{}{
+					"headerNameOfLevel2": "Title 2",
+					MetaKeyHeaderPath:    []string{"Title 2"},
+				},
+			}},
+		},
+		{
+			name: "sub split oversized sections",
+			config: &HeaderConfig{
+				Headers:      map[string]string{"#": "h1"},
+				TrimHeaders:  true,
+				MaxChunkSize: 20,
+			},
+			input: []*schema.Document{{
+				ID:      "id",
+				Content: "# Title\nline one\nline two\nline three\n```\na\nb\n```\n" + strings.Repeat("x", 30),
+			}},
+			want: []*schema.Document{{
+				ID:      "id",
+				Content: "line one\nline two",
+				MetaData: map[string]interface{}{
+					"h1":              "Title",
+					MetaKeyHeaderPath: []string{"Title"},
+				},
+			}, {
+				ID:      "id",
+				Content: "line three",
+				MetaData: map[string]interface{}{
+					"h1":              "Title",
+					MetaKeyHeaderPath: []string{"Title"},
+				},
+			}, {
+				ID:      "id",
+				Content: "```\na\nb\n```",
+				MetaData: map[string]interface{}{
+					"h1":              "Title",
+					MetaKeyHeaderPath: []string{"Title"},
+				},
+			}, {
+				ID:      "id",
+				Content: strings.Repeat("x", 20),
+				MetaData: map[string]interface{}{
+					"h1":              "Title",
+					MetaKeyHeaderPath: []string{"Title"},
+				},
+			}, {
+				ID:      "id",
+				Content: strings.Repeat("x", 10),
+				MetaData: map[string]interface{}{
+					"h1":              "Title",
+					MetaKeyHeaderPath: []string{"Title"},
 				},
 			}},
 		},
@@ -94,3 +203,46 @@ func TestMarkdownHeaderSplitter(t *testing.T) {
 		})
 	}
 }
+
+type halfSplitter struct{}
+
+func (halfSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	content := docs[0].Content
+	return []*schema.Document{{Content: content[:len(content)/2]}, {Content: content[len(content)/2:]}}, nil
+}
+
+func TestMarkdownHeaderSplitterSubSplitter(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewHeaderSplitter(ctx, &HeaderConfig{Headers: map[string]string{"#": "h1"}, SubSplitter: halfSplitter{}})
+	if err == nil {
+		t.Fatal("expect error without max chunk size")
+	}
+
+	splitter, err := NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:      map[string]string{"#": "h1", "##": "h2"},
+		TrimHeaders:  true,
+		MaxChunkSize: 4,
+		SubSplitter:  halfSplitter{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: "# A\n## B\nabcdef\n## C\nxy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var contents []string
+	for _, doc := range docs {
+		contents = append(contents, doc.Content)
+	}
+	if !reflect.DeepEqual(contents, []string{"abc", "def", "xy"}) {
+		t.Fatalf("got contents %v", contents)
+	}
+	for _, doc := range docs[:2] {
+		if !reflect.DeepEqual(doc.MetaData[MetaKeyHeaderPath], []string{"A", "B"}) {
+			t.Errorf("got header path %v", doc.MetaData[MetaKeyHeaderPath])
+		}
+	}
+}
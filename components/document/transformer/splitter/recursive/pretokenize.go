@@ -0,0 +1,233 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recursive
+
+import (
+	"unicode"
+)
+
+// The pre-tokenizers below split text into pieces the same way as the regular expressions of tiktoken,
+// which rely on look-ahead that regexp does not support. Each alternative of the pattern is tried in order
+// at the current position, the first one that matches makes a piece.
+
+// splitCL100K splits text by the pattern of cl100k_base:
+//
+//	(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+
+func splitCL100K(text string) []string {
+	return pretokenize(text, []matcher{
+		matchContraction,
+		func(rs []rune, i int) int {
+			return withOptionalPrefix(rs, i, isPrefix, func(rs []rune, j int) int {
+				return runOf(rs, j, unicode.IsLetter)
+			})
+		},
+		matchNumbers,
+		func(rs []rune, i int) int {
+			return matchPunctuation(rs, i, isNewline)
+		},
+		matchNewlines,
+		matchTrailingSpaces,
+		matchSpaces,
+	})
+}
+
+// splitO200K splits text by the pattern of o200k_base:
+//
+//	[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?|
+//	[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?|
+//	\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+(?!\S)|\s+
+func splitO200K(text string) []string {
+	return pretokenize(text, []matcher{
+		func(rs []rune, i int) int {
+			return withOptionalPrefix(rs, i, isPrefix, func(rs []rune, j int) int {
+				// [upper]*[lower]+ with backtracking: the lower run starts at the last lower rune
+				// within or right after the upper run.
+				upper := runOf(rs, j, isUpper)
+				for k := j + upper; k >= j; k-- {
+					if k < len(rs) && isLower(rs[k]) {
+						n := k - j + runOf(rs, k, isLower)
+						return n + matchContraction(rs, j+n)
+					}
+				}
+				return 0
+			})
+		},
+		func(rs []rune, i int) int {
+			return withOptionalPrefix(rs, i, isPrefix, func(rs []rune, j int) int {
+				upper := runOf(rs, j, isUpper)
+				if upper == 0 {
+					return 0
+				}
+				n := upper + runOf(rs, j+upper, isLower)
+				return n + matchContraction(rs, j+n)
+			})
+		},
+		matchNumbers,
+		func(rs []rune, i int) int {
+			return matchPunctuation(rs, i, func(r rune) bool {
+				return isNewline(r) || r == '/'
+			})
+		},
+		matchNewlines,
+		matchTrailingSpaces,
+		matchSpaces,
+	})
+}
+
+// matcher returns the number of runes matched at rs[i:], zero if not matched.
+type matcher func(rs []rune, i int) int
+
+func pretokenize(text string, matchers []matcher) []string {
+	rs := []rune(text)
+	pieces := make([]string, 0, len(rs)/4+1)
+	for i := 0; i < len(rs); {
+		n := 0
+		for _, m := range matchers {
+			if n = m(rs, i); n > 0 {
+				break
+			}
+		}
+		if n == 0 {
+			n = 1
+		}
+		pieces = append(pieces, string(rs[i:i+n]))
+		i += n
+	}
+
+	return pieces
+}
+
+var contractions = []string{"s", "t", "re", "ve", "m", "ll", "d"}
+
+// matchContraction matches (?i:'s|'t|'re|'ve|'m|'ll|'d).
+func matchContraction(rs []rune, i int) int {
+	if i >= len(rs) || rs[i] != '\'' {
+		return 0
+	}
+
+	for _, c := range contractions {
+		if i+1+len(c) > len(rs) {
+			continue
+		}
+		matched := true
+		for k, r := range c {
+			if unicode.ToLower(rs[i+1+k]) != r {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return 1 + len(c)
+		}
+	}
+
+	return 0
+}
+
+// withOptionalPrefix matches prefix? followed by body, the prefix is tried first as the quantifier is greedy.
+func withOptionalPrefix(rs []rune, i int, prefix func(rune) bool, body matcher) int {
+	if i < len(rs) && prefix(rs[i]) {
+		if n := body(rs, i+1); n > 0 {
+			return 1 + n
+		}
+	}
+
+	return body(rs, i)
+}
+
+// matchNumbers matches \p{N}{1,3}.
+func matchNumbers(rs []rune, i int) int {
+	n := runOf(rs, i, unicode.IsNumber)
+	if n > 3 {
+		n = 3
+	}
+
+	return n
+}
+
+// matchPunctuation matches ` ?[^\s\p{L}\p{N}]+` followed by a run of tail runes.
+func matchPunctuation(rs []rune, i int, tail func(rune) bool) int {
+	j := i
+	if j < len(rs) && rs[j] == ' ' {
+		j++
+	}
+
+	n := runOf(rs, j, func(r rune) bool {
+		return !unicode.IsSpace(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if n == 0 {
+		return 0
+	}
+	j += n
+
+	return j + runOf(rs, j, tail) - i
+}
+
+// matchNewlines matches \s*[\r\n]+, which ends at the last newline of the white space run.
+func matchNewlines(rs []rune, i int) int {
+	n := runOf(rs, i, unicode.IsSpace)
+	for k := i + n - 1; k >= i; k-- {
+		if isNewline(rs[k]) {
+			return k + 1 - i
+		}
+	}
+
+	return 0
+}
+
+// matchTrailingSpaces matches \s+(?!\S), the last white space before a non white space is left to the next piece.
+func matchTrailingSpaces(rs []rune, i int) int {
+	n := runOf(rs, i, unicode.IsSpace)
+	if n == 0 || i+n == len(rs) {
+		return n
+	}
+
+	return n - 1
+}
+
+// matchSpaces matches \s+.
+func matchSpaces(rs []rune, i int) int {
+	return runOf(rs, i, unicode.IsSpace)
+}
+
+func runOf(rs []rune, i int, f func(rune) bool) int {
+	n := 0
+	for i+n < len(rs) && f(rs[i+n]) {
+		n++
+	}
+
+	return n
+}
+
+// isPrefix matches [^\r\n\p{L}\p{N}].
+func isPrefix(r rune) bool {
+	return !isNewline(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+func isNewline(r rune) bool {
+	return r == '\r' || r == '\n'
+}
+
+// isUpper matches [\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}].
+func isUpper(r rune) bool {
+	return unicode.In(r, unicode.Lu, unicode.Lt, unicode.Lm, unicode.Lo, unicode.M)
+}
+
+// isLower matches [\p{Ll}\p{Lm}\p{Lo}\p{M}].
+func isLower(r rune) bool {
+	return unicode.In(r, unicode.Ll, unicode.Lm, unicode.Lo, unicode.M)
+}
@@ -25,6 +25,16 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+// Metadata keys recorded on every chunk, compatible with the context expansion retriever.
+const (
+	// MetaKeyChunkIndex index of the chunk in its document, value: int
+	MetaKeyChunkIndex = "_chunk_index"
+	// MetaKeyStartOffset byte offset in document content where the chunk starts, value: int
+	MetaKeyStartOffset = "_start_offset"
+	// MetaKeyEndOffset byte offset in document content where the chunk ends (exclusive), value: int
+	MetaKeyEndOffset = "_end_offset"
+)
+
 type KeepType uint8
 
 const (
@@ -43,8 +53,10 @@ type Config struct {
 	// Separators are sequentially used to split text.
 	// When the current separator cannot split the text into a size smaller than ChunkSize, the next separator will be used to attempt to split until the chunk size is smaller than ChunkSize or there are no separator available.
 	// ["\n", ".", "?", "!"] by default.
+	// See SeparatorsMarkdown, SeparatorsGo, SeparatorsPython and SeparatorsChinese for presets.
 	Separators []string
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	// Use CharLen to count characters, or Tiktoken.Count to count model tokens.
 	LenFunc func(string) int
 	// KeepType specifies if separator will be kept in split chunks. Discard separator by default.
 	KeepType KeepType
@@ -89,11 +101,25 @@ func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts
 	ret := make([]*schema.Document, 0, len(docs))
 	for _, doc := range docs {
 		splits := s.splitText(ctx, doc.Content, s.separators)
-		for _, split := range splits {
+		cursor := 0
+		for i, split := range splits {
+			meta := deepCopyMap(doc.MetaData)
+			if meta == nil {
+				meta = make(map[string]interface{}, 3)
+			}
+			meta[MetaKeyChunkIndex] = i
+			// chunks are substrings of the content in order, overlapping chunks start after the previous one.
+			// the offsets of a chunk which can not be located are unknown and omitted.
+			if start := indexFrom(doc.Content, split, cursor); start >= 0 {
+				meta[MetaKeyStartOffset] = start
+				meta[MetaKeyEndOffset] = start + len(split)
+				cursor = start + 1
+			}
+
 			ret = append(ret, &schema.Document{
 				ID:       doc.ID,
 				Content:  split,
-				MetaData: deepCopyMap(doc.MetaData),
+				MetaData: meta,
 			})
 		}
 	}
@@ -228,6 +254,18 @@ func joinDocs(docs []string, separator string, t KeepType) string {
 	return strings.TrimSpace(strings.Join(docs, ""))
 }
 
+// indexFrom returns the index of the first substr in s at or after from, or -1 if there is none.
+func indexFrom(s, substr string, from int) int {
+	if from > len(s) {
+		return -1
+	}
+	if i := strings.Index(s[from:], substr); i >= 0 {
+		return from + i
+	}
+
+	return -1
+}
+
 func deepCopyMap(m map[string]interface{}) map[string]interface{} {
 	if m == nil {
 		return nil
@@ -52,13 +52,13 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{Content: "1a23"},
-				{Content: "23a45"},
-				{Content: "67890"},
-				{Content: "1"},
-				{Content: "234"},
-				{Content: "5678"},
-				{Content: "90"},
+				chunk("1a23", 0, 0),
+				chunk("23a45", 1, 2),
+				chunk("67890", 2, 8),
+				chunk("1", 3, 14),
+				chunk("234", 4, 16),
+				chunk("5678", 5, 20),
+				chunk("90", 6, 25),
 			},
 		},
 		{
@@ -74,13 +74,13 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{Content: "1a23"},
-				{Content: "a45"},
-				{Content: "a67890"},
-				{Content: "c1"},
-				{Content: "a234"},
-				{Content: "b5678"},
-				{Content: "a90"},
+				chunk("1a23", 0, 0),
+				chunk("a45", 1, 4),
+				chunk("a67890", 2, 7),
+				chunk("c1", 3, 13),
+				chunk("a234", 4, 15),
+				chunk("b5678", 5, 19),
+				chunk("a90", 6, 24),
 			},
 		},
 		{
@@ -96,13 +96,13 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{Content: "1a23a"},
-				{Content: "45a"},
-				{Content: "67890c"},
-				{Content: "1a"},
-				{Content: "234b"},
-				{Content: "5678a"},
-				{Content: "90"},
+				chunk("1a23a", 0, 0),
+				chunk("45a", 1, 5),
+				chunk("67890c", 2, 8),
+				chunk("1a", 3, 14),
+				chunk("234b", 4, 16),
+				chunk("5678a", 5, 20),
+				chunk("90", 6, 25),
 			},
 		},
 	}
@@ -123,3 +123,75 @@ func TestRecursiveSplitter(t *testing.T) {
 		})
 	}
 }
+
+func chunk(content string, index, start int) *schema.Document {
+	return &schema.Document{
+		Content: content,
+		MetaData: map[string]interface{}{
+			MetaKeyChunkIndex:  index,
+			MetaKeyStartOffset: start,
+			MetaKeyEndOffset:   start + len(content),
+		},
+	}
+}
+
+func TestRecursiveSplitterOffsets(t *testing.T) {
+	ctx := context.Background()
+	content := "第一段。这是第二句！第三句？\n\n第二段，很长很长很长很长的一句话。结束。"
+
+	s, err := NewSplitter(ctx, &Config{
+		ChunkSize:   12,
+		OverlapSize: 4,
+		Separators:  SeparatorsChinese,
+		LenFunc:     CharLen,
+		KeepType:    KeepTypeEnd,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := s.Transform(ctx, []*schema.Document{
+		{ID: "doc", Content: content, MetaData: map[string]interface{}{"k": "v"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) < 2 {
+		t.Fatalf("expect multiple chunks, got %d", len(docs))
+	}
+
+	for i, doc := range docs {
+		if CharLen(doc.Content) > 12 {
+			t.Errorf("chunk %d is too long: %q", i, doc.Content)
+		}
+		if doc.ID != "doc" || doc.MetaData["k"] != "v" {
+			t.Errorf("chunk %d lost id or meta data", i)
+		}
+		if doc.MetaData[MetaKeyChunkIndex] != i {
+			t.Errorf("chunk index of %d = %v", i, doc.MetaData[MetaKeyChunkIndex])
+		}
+		start, end := doc.MetaData[MetaKeyStartOffset].(int), doc.MetaData[MetaKeyEndOffset].(int)
+		if content[start:end] != doc.Content {
+			t.Errorf("chunk %d = %q, but content[%d:%d] = %q", i, doc.Content, start, end, content[start:end])
+		}
+	}
+}
+
+func TestIndexFrom(t *testing.T) {
+	tests := []struct {
+		s, substr string
+		from      int
+		want      int
+	}{
+		{"abcabc", "abc", 0, 0},
+		{"abcabc", "abc", 1, 3},
+		{"abcabc", "abc", 4, -1},
+		{"abcabc", "x", 0, -1},
+		{"abc", "c", 4, -1},
+	}
+	for _, tt := range tests {
+		if got := indexFrom(tt.s, tt.substr, tt.from); got != tt.want {
+			t.Errorf("indexFrom(%q, %q, %d) = %d, want %d", tt.s, tt.substr, tt.from, got, tt.want)
+		}
+	}
+}
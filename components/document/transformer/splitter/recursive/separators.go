@@ -0,0 +1,31 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recursive
+
+// Separator presets for Config.Separators, from the coarsest to the finest. The trailing "" splits text into characters.
+var (
+	// SeparatorsMarkdown splits by headings, code fences, paragraphs and lines, use it with KeepTypeStart
+	// so that headings and fences stay at the start of chunks.
+	SeparatorsMarkdown = []string{"\n# ", "\n## ", "\n### ", "\n#### ", "\n##### ", "\n###### ", "\n```", "\n\n", "\n", " ", ""}
+	// SeparatorsGo splits by top level declarations, statements and lines, use it with KeepTypeStart.
+	SeparatorsGo = []string{"\nfunc ", "\ntype ", "\nvar ", "\nconst ", "\n\tif ", "\n\tfor ", "\n\tswitch ", "\n\treturn ", "\n\n", "\n", " ", ""}
+	// SeparatorsPython splits by classes, functions, statements and lines, use it with KeepTypeStart.
+	SeparatorsPython = []string{"\nclass ", "\ndef ", "\n\tdef ", "\n    def ", "\n    if ", "\n    for ", "\n    return ", "\n\n", "\n", " ", ""}
+	// SeparatorsChinese splits by paragraphs, sentences and clauses at Chinese punctuation,
+	// use it with KeepTypeEnd so that punctuation stays with its sentence.
+	SeparatorsChinese = []string{"\n\n", "\n", "。", "！", "？", "；", "…", "，", "、", " ", ""}
+)
@@ -0,0 +1,280 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recursive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Encoding is the name of a tiktoken encoding.
+type Encoding string
+
+const (
+	// EncodingCL100KBase is used by gpt-4, gpt-3.5-turbo and text-embedding-3 models.
+	EncodingCL100KBase Encoding = "cl100k_base"
+	// EncodingO200KBase is used by gpt-4o and later models.
+	EncodingO200KBase Encoding = "o200k_base"
+)
+
+// rankFileURLs are the official locations of the rank files of the encodings.
+var rankFileURLs = map[Encoding]string{
+	EncodingCL100KBase: "https://openaipublic.blob.core.windows.net/encodings/cl100k_base.tiktoken",
+	EncodingO200KBase:  "https://openaipublic.blob.core.windows.net/encodings/o200k_base.tiktoken",
+}
+
+// CharLen returns the number of unicode characters of s, it can be used as Config.LenFunc
+// so that CJK text is measured by characters rather than bytes.
+func CharLen(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// Tiktoken is a byte pair encoding tokenizer compatible with tiktoken, it encodes text to the same tokens as
+// tiktoken's encode_ordinary, special tokens are treated as plain text.
+// Use Tiktoken.Count as Config.LenFunc to measure chunks by model tokens.
+type Tiktoken struct {
+	encoding Encoding
+	ranks    map[string]int
+	split    func(text string) []string
+}
+
+// TiktokenConfig is the config to load a Tiktoken.
+type TiktokenConfig struct {
+	// RankFile is the path of the .tiktoken rank file of the encoding.
+	// If the file does not exist, the rank file is downloaded from the official location and saved to it,
+	// if empty, the rank file is downloaded on every load.
+	RankFile string
+	// Client is used to download the rank file, http.DefaultClient by default.
+	Client *http.Client
+}
+
+// LoadTiktoken loads the tokenizer of encoding, see TiktokenConfig for where the ranks come from.
+func LoadTiktoken(ctx context.Context, encoding Encoding, config *TiktokenConfig) (*Tiktoken, error) {
+	if config == nil {
+		config = &TiktokenConfig{}
+	}
+
+	if config.RankFile != "" {
+		f, err := os.Open(config.RankFile)
+		if err == nil {
+			defer f.Close()
+			return NewTiktoken(encoding, f)
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("open rank file failed: %w", err)
+		}
+	}
+
+	data, err := downloadRankFile(ctx, encoding, config.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := NewTiktoken(encoding, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.RankFile != "" {
+		if err = os.MkdirAll(filepath.Dir(config.RankFile), 0o755); err != nil {
+			return nil, fmt.Errorf("create rank file dir failed: %w", err)
+		}
+		if err = os.WriteFile(config.RankFile, data, 0o644); err != nil {
+			return nil, fmt.Errorf("save rank file failed: %w", err)
+		}
+	}
+
+	return t, nil
+}
+
+func downloadRankFile(ctx context.Context, encoding Encoding, client *http.Client) ([]byte, error) {
+	u, ok := rankFileURLs[encoding]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding: %s", encoding)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download rank file of %s failed: %w", encoding, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download rank file of %s failed, status: %s", encoding, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// NewTiktoken creates the tokenizer of encoding from a .tiktoken rank file,
+// whose lines are base64 encoded tokens followed by their ranks.
+func NewTiktoken(encoding Encoding, ranks io.Reader) (*Tiktoken, error) {
+	var split func(string) []string
+	switch encoding {
+	case EncodingCL100KBase:
+		split = splitCL100K
+	case EncodingO200KBase:
+		split = splitO200K
+	default:
+		return nil, fmt.Errorf("unknown encoding: %s", encoding)
+	}
+
+	m := make(map[string]int)
+	scanner := bufio.NewScanner(ranks)
+	for line := 1; scanner.Scan(); line++ {
+		fields := bytes.Fields(scanner.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid rank file at line %d", line)
+		}
+
+		token, err := base64.StdEncoding.DecodeString(string(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid token at line %d: %w", line, err)
+		}
+		rank, err := strconv.Atoi(string(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rank at line %d: %w", line, err)
+		}
+		m[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read rank file failed: %w", err)
+	}
+
+	for b := 0; b < 256; b++ {
+		if _, ok := m[string([]byte{byte(b)})]; !ok {
+			return nil, fmt.Errorf("rank of byte %#x not found", b)
+		}
+	}
+
+	return &Tiktoken{
+		encoding: encoding,
+		ranks:    m,
+		split:    split,
+	}, nil
+}
+
+// Encoding returns the name of the encoding.
+func (t *Tiktoken) Encoding() Encoding {
+	return t.encoding
+}
+
+// Encode returns the tokens of text.
+func (t *Tiktoken) Encode(text string) []int {
+	var tokens []int
+	for _, piece := range t.split(text) {
+		tokens = t.encodePiece([]byte(piece), tokens)
+	}
+
+	return tokens
+}
+
+// Count returns the number of tokens of text.
+func (t *Tiktoken) Count(text string) int {
+	n := 0
+	for _, piece := range t.split(text) {
+		if _, ok := t.ranks[piece]; ok {
+			n++
+			continue
+		}
+		n += len(t.bytePairMerge([]byte(piece)))
+	}
+
+	return n
+}
+
+func (t *Tiktoken) encodePiece(piece []byte, tokens []int) []int {
+	if rank, ok := t.ranks[string(piece)]; ok {
+		return append(tokens, rank)
+	}
+
+	for _, part := range t.bytePairMerge(piece) {
+		tokens = append(tokens, t.ranks[string(part)])
+	}
+
+	return tokens
+}
+
+// bytePairMerge merges the adjacent parts of piece with the lowest rank repeatedly, starting from single bytes.
+func (t *Tiktoken) bytePairMerge(piece []byte) [][]byte {
+	// bounds[i] is the start of the i-th part.
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+
+	rankOf := func(i int) int {
+		if i+2 >= len(bounds) {
+			return math.MaxInt
+		}
+		if rank, ok := t.ranks[string(piece[bounds[i]:bounds[i+2]])]; ok {
+			return rank
+		}
+		return math.MaxInt
+	}
+
+	ranks := make([]int, len(bounds))
+	for i := range ranks {
+		ranks[i] = rankOf(i)
+	}
+
+	for len(bounds) > 2 {
+		minIdx, minRank := -1, math.MaxInt
+		for i := 0; i < len(bounds)-2; i++ {
+			if ranks[i] < minRank {
+				minIdx, minRank = i, ranks[i]
+			}
+		}
+		if minIdx < 0 {
+			break
+		}
+
+		bounds = append(bounds[:minIdx+1], bounds[minIdx+2:]...)
+		ranks = append(ranks[:minIdx+1], ranks[minIdx+2:]...)
+		ranks[minIdx] = rankOf(minIdx)
+		if minIdx > 0 {
+			ranks[minIdx-1] = rankOf(minIdx - 1)
+		}
+	}
+
+	parts := make([][]byte, 0, len(bounds)-1)
+	for i := 0; i < len(bounds)-1; i++ {
+		parts = append(parts, piece[bounds[i]:bounds[i+1]])
+	}
+
+	return parts
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recursive
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCL100K(t *testing.T) {
+	cases := map[string][]string{
+		"Hello world":    {"Hello", " world"},
+		"I'm HERE'LL":    {"I", "'m", " HERE", "'LL"},
+		"12345":          {"123", "45"},
+		"a  b":           {"a", " ", " b"},
+		"foo!!\n\nbar":   {"foo", "!!\n\n", "bar"},
+		"x \n y":         {"x", " \n", " y"},
+		"end   ":         {"end", "   "},
+		"你好，世界":          {"你好", "，世界"},
+		"$100 (approx.)": {"$", "100", " (", "approx", ".)"},
+		"tab\tsep\r\nok": {"tab", "\tsep", "\r\n", "ok"},
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, splitCL100K(input), input)
+	}
+}
+
+func TestSplitO200K(t *testing.T) {
+	cases := map[string][]string{
+		"Hello World": {"Hello", " World"},
+		"HTTPServer":  {"HTTPServer"},
+		"don't":       {"don't"},
+		"CamelCase":   {"Camel", "Case"},
+		"path/to\n":   {"path", "/to", "\n"},
+		"1234":        {"123", "4"},
+		"a+b=c\n/":    {"a", "+b", "=c", "\n", "/"},
+		"中文，测试。":      {"中文", "，测试", "。"},
+		"  leading":   {" ", " leading"},
+		"ABC's":       {"ABC's"},
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, splitO200K(input), input)
+	}
+}
+
+func newTestRanks(merges ...string) string {
+	sb := strings.Builder{}
+	for b := 0; b < 256; b++ {
+		sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(b)}), b))
+	}
+	for i, m := range merges {
+		sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte(m)), 256+i))
+	}
+	return sb.String()
+}
+
+func TestTiktoken(t *testing.T) {
+	tk, err := NewTiktoken(EncodingCL100KBase, strings.NewReader(newTestRanks("he", "ll", "hell", " hello")))
+	assert.NoError(t, err)
+	assert.Equal(t, EncodingCL100KBase, tk.Encoding())
+
+	// "hello" merges he, ll then hell, " hello" is a whole token.
+	assert.Equal(t, []int{258, 'o', 259, '!'}, tk.Encode("hello hello!"))
+	assert.Equal(t, 4, tk.Count("hello hello!"))
+	assert.Equal(t, 0, tk.Count(""))
+	// multi-byte characters fall back to bytes.
+	assert.Equal(t, 6, tk.Count("你好"))
+
+	_, err = NewTiktoken("p50k_base", strings.NewReader(newTestRanks()))
+	assert.Error(t, err)
+	_, err = NewTiktoken(EncodingO200KBase, strings.NewReader("aGk= 1\n"))
+	assert.ErrorContains(t, err, "rank of byte")
+	_, err = NewTiktoken(EncodingO200KBase, strings.NewReader("aGk=\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadTiktoken(t *testing.T) {
+	ranks := newTestRanks("ab")
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(ranks))
+	}))
+	defer server.Close()
+
+	origin := rankFileURLs[EncodingO200KBase]
+	rankFileURLs[EncodingO200KBase] = server.URL + "/o200k_base.tiktoken"
+	defer func() { rankFileURLs[EncodingO200KBase] = origin }()
+
+	ctx := context.Background()
+	conf := &TiktokenConfig{RankFile: filepath.Join(t.TempDir(), "cache", "o200k_base.tiktoken")}
+	for i := 0; i < 2; i++ {
+		tk, err := LoadTiktoken(ctx, EncodingO200KBase, conf)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{256}, tk.Encode("ab"))
+	}
+	assert.Equal(t, 1, requests)
+
+	_, err := LoadTiktoken(ctx, "unknown", nil)
+	assert.Error(t, err)
+}
@@ -22,12 +22,49 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/schema"
 )
 
+// Metadata keys recorded on every chunk.
+const (
+	// MetaKeyChunkIndex index of the chunk in its document, value: int
+	MetaKeyChunkIndex = "_chunk_index"
+	// MetaKeyStartOffset byte offset in document content where the chunk starts, value: int
+	MetaKeyStartOffset = "_start_offset"
+	// MetaKeyEndOffset byte offset in document content where the chunk ends (exclusive), value: int
+	MetaKeyEndOffset = "_end_offset"
+	// MetaKeyBreakpointDistance cosine distance between the chunk and the text before it, value: float64.
+	// Only set on chunks which start at a semantic breakpoint.
+	MetaKeyBreakpointDistance = "_breakpoint_distance"
+)
+
+// BreakpointType specifies how the distance threshold of breakpoints is calculated.
+type BreakpointType string
+
+const (
+	// BreakpointPercentile splits at the distances above the Percentile of all distances.
+	BreakpointPercentile BreakpointType = "percentile"
+	// BreakpointStandardDeviation splits at the distances above mean + BreakpointAmount * standard deviation.
+	BreakpointStandardDeviation BreakpointType = "standard_deviation"
+	// BreakpointInterquartile splits at the distances above mean + BreakpointAmount * interquartile range.
+	BreakpointInterquartile BreakpointType = "interquartile"
+	// BreakpointGradient splits at the gradients of distances above the Percentile of all gradients,
+	// which suits text whose topics drift gradually, such as legal or medical documents.
+	BreakpointGradient BreakpointType = "gradient"
+)
+
+const (
+	defaultPercentile          = 0.9
+	defaultStdDevAmount        = 3
+	defaultInterquartileAmount = 1.5
+	defaultConcurrency         = 4
+)
+
 type Config struct {
 	// Embedding is used to generate vectors for calculating difference between chunks.
 	Embedding embedding.Embedder
@@ -35,18 +72,34 @@ type Config struct {
 	BufferSize int
 	// MinChunkSize specifies the minimum chunk's size. Chunks with size smaller than MinChunkSize will be concatenated to their adjacent chunks.
 	MinChunkSize int
+	// MaxChunkSize specifies the maximum chunk's size. Chunks larger than it are split recursively by Separators,
+	// and by characters if still too large. No limit if zero.
+	MaxChunkSize int
 	// Separators are sequentially used to split text. ["\n", ".", "?", "!"] by default.
 	Separators []string
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
 	LenFunc func(s string) int
+	// BreakpointType specifies how to find the breakpoints between chunks. BreakpointPercentile by default.
+	BreakpointType BreakpointType
 	// Percentile specifies the number of splitting. If the difference between two chunks is greater than X percentile, these two chunks will be split.
+	// Used by BreakpointPercentile and BreakpointGradient, 0.9 by default.
 	Percentile float64
+	// BreakpointAmount is the multiple of standard deviation or interquartile range above the mean distance to split at.
+	// 3 for BreakpointStandardDeviation and 1.5 for BreakpointInterquartile by default.
+	BreakpointAmount float64
+	// BatchSize is the max number of texts in one EmbedStrings call. All texts of a document are embedded in one call if zero.
+	BatchSize int
+	// Concurrency is the max number of concurrent EmbedStrings calls when texts are batched. 4 by default.
+	Concurrency int
 }
 
 func NewSplitter(ctx context.Context, config *Config) (document.Transformer, error) {
 	if config.Embedding == nil {
 		return nil, fmt.Errorf("embedding should not be nil")
 	}
+	if config.MaxChunkSize < 0 || config.BatchSize < 0 || config.Concurrency < 0 {
+		return nil, fmt.Errorf("max chunk size, batch size and concurrency must be greater than or equal to zero")
+	}
 	lenFunc := config.LenFunc
 	if lenFunc == nil {
 		lenFunc = func(s string) int { return len(s) }
@@ -57,109 +110,349 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 	}
 	percentile := config.Percentile
 	if percentile == 0 {
-		percentile = 0.9
+		percentile = defaultPercentile
+	}
+	breakpointType := config.BreakpointType
+	amount := config.BreakpointAmount
+	switch breakpointType {
+	case "":
+		breakpointType = BreakpointPercentile
+	case BreakpointPercentile, BreakpointGradient:
+	case BreakpointStandardDeviation:
+		if amount == 0 {
+			amount = defaultStdDevAmount
+		}
+	case BreakpointInterquartile:
+		if amount == 0 {
+			amount = defaultInterquartileAmount
+		}
+	default:
+		return nil, fmt.Errorf("unknown breakpoint type: %s", breakpointType)
+	}
+	concurrency := config.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultConcurrency
 	}
 	return &splitter{
-		embedding:    config.Embedding,
-		bufferSize:   config.BufferSize,
-		minChunkSize: config.MinChunkSize,
-		separators:   seps,
-		lenFunc:      lenFunc,
-		percentile:   percentile,
+		embedding:      config.Embedding,
+		bufferSize:     config.BufferSize,
+		minChunkSize:   config.MinChunkSize,
+		maxChunkSize:   config.MaxChunkSize,
+		separators:     seps,
+		lenFunc:        lenFunc,
+		breakpointType: breakpointType,
+		percentile:     percentile,
+		amount:         amount,
+		batchSize:      config.BatchSize,
+		concurrency:    concurrency,
 	}, nil
 }
 
 type splitter struct {
-	embedding    embedding.Embedder
-	bufferSize   int
-	minChunkSize int
-	separators   []string
-	lenFunc      func(s string) int
-	percentile   float64
+	embedding      embedding.Embedder
+	bufferSize     int
+	minChunkSize   int
+	maxChunkSize   int
+	separators     []string
+	lenFunc        func(s string) int
+	breakpointType BreakpointType
+	percentile     float64
+	amount         float64
+	batchSize      int
+	concurrency    int
+}
+
+// chunk is the byte range [start, end) of a chunk in the text.
+type chunk struct {
+	start, end int
+	// distance is the breakpoint distance before the chunk, nil if the chunk does not start at a breakpoint.
+	distance *float64
 }
 
 func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
 	ret := make([]*schema.Document, 0, len(docs))
 	for _, doc := range docs {
-		splits, err := s.splitText(ctx, doc.Content, s.separators)
+		chunks, err := s.splitText(ctx, doc.Content, s.separators)
 		if err != nil {
 			return nil, fmt.Errorf("split document[%s] fail: %w", doc.ID, err)
 		}
-		for _, split := range splits {
+		for i, c := range chunks {
+			meta := deepCopyMap(doc.MetaData)
+			if meta == nil {
+				meta = make(map[string]interface{}, 4)
+			}
+			meta[MetaKeyChunkIndex] = i
+			meta[MetaKeyStartOffset] = c.start
+			meta[MetaKeyEndOffset] = c.end
+			if c.distance != nil {
+				meta[MetaKeyBreakpointDistance] = *c.distance
+			}
+
 			ret = append(ret, &schema.Document{
 				ID:       doc.ID,
-				Content:  split,
-				MetaData: deepCopyMap(doc.MetaData),
+				Content:  doc.Content[c.start:c.end],
+				MetaData: meta,
 			})
 		}
 	}
 	return ret, nil
 }
 
-func (s *splitter) splitText(ctx context.Context, text string, separators []string) ([]string, error) {
+func (s *splitter) splitText(ctx context.Context, text string, separators []string) ([]*chunk, error) {
 	texts := []string{text}
 	// split
-	for i := range s.separators {
+	for i := range separators {
 		texts = splitTexts(texts, separators[i])
 	}
 
-	sentencesLength := make([]int, len(texts))
+	// offsets of sentences, the sentences are contiguous as separators are kept.
+	offsets := make([]int, len(texts)+1)
 	for i := range texts {
-		sentencesLength[i] = s.lenFunc(texts[i])
+		offsets[i+1] = offsets[i] + len(texts[i])
 	}
 
-	if len(texts) == 1 {
-		return texts, nil
+	if len(texts) <= 1 {
+		return s.limitSize(text, []*chunk{{start: 0, end: len(text)}}), nil
 	}
 
 	// combine
 	combinedSentences := make([]string, len(texts))
 	for i := range texts {
-		combinedSentence := texts[i]
-		for j := 1; j <= s.bufferSize && i+j < len(texts); j++ {
-			combinedSentence = combinedSentence + texts[i+j]
+		start, end := i-s.bufferSize, i+s.bufferSize+1
+		if start < 0 {
+			start = 0
 		}
-		for j := 1; j <= s.bufferSize && i-j >= 0; j++ {
-			combinedSentence = texts[i-j] + combinedSentence
+		if end > len(texts) {
+			end = len(texts)
 		}
-		combinedSentences[i] = combinedSentence
+		combinedSentences[i] = text[offsets[start]:offsets[end]]
 	}
 
 	// embedding
-	var vectors [][]float64
-	v, err := s.embedding.EmbedStrings(ctx, combinedSentences)
+	vectors, err := s.embed(ctx, combinedSentences)
 	if err != nil {
 		return nil, err
 	}
-	for i := range v {
-		vectors = append(vectors, v[i])
-	}
 
-	// cosine distances
+	// cosine distances, distances[i] is the distance between sentence i-1 and i.
 	distances := make([]float64, len(texts))
 	for i := 1; i < len(texts); i++ {
 		distances[i] = 1 - cosine(vectors[i-1], vectors[i])
 	}
 
-	threshold := calThreshold(distances, s.percentile)
-	var splitIndexes []int
-	for i := 1; i < len(distances); i++ {
-		if distances[i] <= threshold {
-			splitIndexes = append(splitIndexes, i)
+	var chunks []*chunk
+	var startIndex int
+	for _, idx := range s.breakpoints(distances[1:]) {
+		splitIndex := idx + 1
+		if s.lenFunc(text[offsets[startIndex]:offsets[splitIndex]]) < s.minChunkSize {
+			continue
 		}
+		chunks = append(chunks, s.newChunk(offsets[startIndex], offsets[splitIndex], distances, startIndex))
+		startIndex = splitIndex
 	}
-	var ret []string
-	var startIndex int
-	for i := range splitIndexes {
-		chunk := strings.Join(texts[startIndex:splitIndexes[i]], "")
-		if len(chunk) < s.minChunkSize {
+	chunks = append(chunks, s.newChunk(offsets[startIndex], offsets[len(texts)], distances, startIndex))
+
+	return s.limitSize(text, chunks), nil
+}
+
+func (s *splitter) newChunk(start, end int, distances []float64, index int) *chunk {
+	c := &chunk{start: start, end: end}
+	if index > 0 {
+		d := distances[index]
+		c.distance = &d
+	}
+	return c
+}
+
+// embed embeds texts in batches of batchSize with at most concurrency calls in flight.
+func (s *splitter) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	if s.batchSize <= 0 || len(texts) <= s.batchSize {
+		vectors, err := s.embedding.EmbedStrings(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != len(texts) {
+			return nil, fmt.Errorf("embedding returns %d vectors for %d texts", len(vectors), len(texts))
+		}
+		return vectors, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		vectors  = make([][]float64, len(texts))
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		sem      = make(chan struct{}, s.concurrency)
+	)
+	for start := 0; start < len(texts); start += s.batchSize {
+		end := start + s.batchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			batch, err := s.embedding.EmbedStrings(ctx, texts[start:end])
+			if err == nil && len(batch) != end-start {
+				err = fmt.Errorf("embedding returns %d vectors for %d texts", len(batch), end-start)
+			}
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("embed texts[%d:%d] fail: %w", start, end, err)
+					cancel()
+				})
+				return
+			}
+			copy(vectors[start:end], batch)
+		}(start, end)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return vectors, nil
+}
+
+// breakpoints returns the indexes of distances to split at, in ascending order.
+func (s *splitter) breakpoints(distances []float64) []int {
+	if len(distances) == 0 {
+		return nil
+	}
+
+	var (
+		values    = distances
+		threshold float64
+	)
+	switch s.breakpointType {
+	case BreakpointStandardDeviation:
+		mean, std := meanStd(distances)
+		threshold = mean + s.amount*std
+	case BreakpointInterquartile:
+		mean, _ := meanStd(distances)
+		threshold = mean + s.amount*(quantile(distances, 0.75)-quantile(distances, 0.25))
+	case BreakpointGradient:
+		values = gradient(distances)
+		threshold = topThreshold(values, s.percentile)
+	default:
+		threshold = topThreshold(values, s.percentile)
+	}
+
+	var ret []int
+	for i, v := range values {
+		switch s.breakpointType {
+		case BreakpointStandardDeviation, BreakpointInterquartile:
+			if v > threshold {
+				ret = append(ret, i)
+			}
+		default:
+			if v >= threshold {
+				ret = append(ret, i)
+			}
+		}
+	}
+	return ret
+}
+
+// limitSize splits the chunks larger than maxChunkSize.
+func (s *splitter) limitSize(text string, chunks []*chunk) []*chunk {
+	if s.maxChunkSize <= 0 {
+		return chunks
+	}
+
+	ret := make([]*chunk, 0, len(chunks))
+	for _, c := range chunks {
+		if s.lenFunc(text[c.start:c.end]) <= s.maxChunkSize {
+			ret = append(ret, c)
 			continue
 		}
-		ret = append(ret, chunk)
-		startIndex = splitIndexes[i]
+		for i, span := range s.splitBySize(text[c.start:c.end], s.separators) {
+			sub := &chunk{start: c.start + span[0], end: c.start + span[1]}
+			if i == 0 {
+				sub.distance = c.distance
+			}
+			ret = append(ret, sub)
+		}
 	}
-	ret = append(ret, strings.Join(texts[startIndex:], ""))
-	return ret, nil
+	return ret
+}
+
+// splitBySize splits text recursively by separators into contiguous spans no larger than maxChunkSize,
+// adjacent pieces are merged as long as they fit.
+func (s *splitter) splitBySize(text string, separators []string) [][2]int {
+	if s.lenFunc(text) <= s.maxChunkSize {
+		return [][2]int{{0, len(text)}}
+	}
+
+	for i, sep := range separators {
+		if sep == "" || !strings.Contains(text, sep) {
+			continue
+		}
+
+		var (
+			spans      [][2]int
+			start, end int
+		)
+		for _, part := range strings.SplitAfter(text, sep) {
+			if part == "" {
+				continue
+			}
+			if end > start && s.lenFunc(text[start:end+len(part)]) <= s.maxChunkSize {
+				end += len(part)
+				continue
+			}
+			if end > start {
+				spans = append(spans, [2]int{start, end})
+			}
+			start = end
+			end += len(part)
+			if s.lenFunc(part) > s.maxChunkSize {
+				for _, span := range s.splitBySize(part, separators[i+1:]) {
+					spans = append(spans, [2]int{start + span[0], start + span[1]})
+				}
+				start = end
+			}
+		}
+		if end > start {
+			spans = append(spans, [2]int{start, end})
+		}
+		return spans
+	}
+
+	// no separator left, split by characters.
+	var (
+		spans [][2]int
+		start int
+	)
+	for end := 0; end < len(text); {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		if end > start && s.lenFunc(text[start:end+size]) > s.maxChunkSize {
+			spans = append(spans, [2]int{start, end})
+			start = end
+		}
+		end += size
+	}
+	return append(spans, [2]int{start, len(text)})
 }
 
 func (s *splitter) GetType() string {
@@ -184,20 +477,72 @@ func dot(x, y []float64) float64 {
 func splitTexts(texts []string, sep string) []string {
 	var ret []string
 	for i := range texts {
-		ret = append(ret, strings.SplitAfter(texts[i], sep)...)
+		for _, t := range strings.SplitAfter(texts[i], sep) {
+			if t != "" {
+				ret = append(ret, t)
+			}
+		}
 	}
 	return ret
 }
 
-func calThreshold(distances []float64, percentile float64) float64 {
-	sorted := make([]float64, len(distances))
-	copy(sorted, distances)
+// topThreshold returns the threshold that the top (1-percentile) of values are above or equal to,
+// at least one value is selected.
+func topThreshold(values []float64, percentile float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+	// the count is relative to the number of sentences, which is one more than the number of distances.
+	k := int((1 - percentile) * float64(len(sorted)+1))
+	if k < 1 {
+		k = 1
+	}
+	if k > len(sorted) {
+		k = len(sorted)
+	}
+	return sorted[k-1]
+}
+
+func meanStd(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// quantile returns the q quantile of values with linear interpolation.
+func quantile(values []float64, q float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
 	sort.Float64s(sorted)
-	idx := int((1 - percentile) * float64(len(sorted)))
-	if idx == 0 {
-		idx = 1
+
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+// gradient returns the gradient of values, central differences in the interior and one-sided differences at the ends.
+func gradient(values []float64) []float64 {
+	n := len(values)
+	ret := make([]float64, n)
+	if n < 2 {
+		return ret
 	}
-	return sorted[idx]
+
+	ret[0] = values[1] - values[0]
+	ret[n-1] = values[n-1] - values[n-2]
+	for i := 1; i < n-1; i++ {
+		ret[i] = (values[i+1] - values[i-1]) / 2
+	}
+	return ret
 }
 
 func deepCopyMap(m map[string]interface{}) map[string]interface{} {
@@ -17,13 +17,19 @@
 package semantic
 
 import (
-	"github.com/cloudwego/eino/components/document"
-	"github.com/cloudwego/eino/components/embedding"
-	"github.com/cloudwego/eino/schema"
 	"context"
+	"errors"
 	"math/rand/v2"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
 )
 
 type randomEmbedding struct {
@@ -134,3 +140,231 @@ func TestSemanticSplitter(t *testing.T) {
 		})
 	}
 }
+
+// topicEmbedding embeds texts by counting the topic words in them.
+type topicEmbedding struct {
+	topics []string
+
+	mu       sync.Mutex
+	batches  []int
+	running  int32
+	maxInUse int32
+	fail     bool
+}
+
+func (e *topicEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	n := atomic.AddInt32(&e.running, 1)
+	defer atomic.AddInt32(&e.running, -1)
+	for {
+		m := atomic.LoadInt32(&e.maxInUse)
+		if n <= m || atomic.CompareAndSwapInt32(&e.maxInUse, m, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	e.mu.Lock()
+	e.batches = append(e.batches, len(texts))
+	e.mu.Unlock()
+	if e.fail {
+		return nil, errors.New("embedding unavailable")
+	}
+
+	ret := make([][]float64, len(texts))
+	for i, text := range texts {
+		vec := make([]float64, len(e.topics)+1)
+		for j, topic := range e.topics {
+			vec[j] = float64(strings.Count(text, topic))
+		}
+		vec[len(e.topics)] = 0.01
+		ret[i] = vec
+	}
+	return ret, nil
+}
+
+func checkChunks(t *testing.T, content string, docs []*schema.Document) {
+	end := 0
+	for i, doc := range docs {
+		start := doc.MetaData[MetaKeyStartOffset].(int)
+		if start != end {
+			t.Errorf("chunk %d starts at %d, previous chunk ends at %d", i, start, end)
+		}
+		end = doc.MetaData[MetaKeyEndOffset].(int)
+		if content[start:end] != doc.Content {
+			t.Errorf("chunk %d = %q, content[%d:%d] = %q", i, doc.Content, start, end, content[start:end])
+		}
+		if doc.MetaData[MetaKeyChunkIndex] != i {
+			t.Errorf("chunk index of %d = %v", i, doc.MetaData[MetaKeyChunkIndex])
+		}
+	}
+	if end != len(content) {
+		t.Errorf("chunks end at %d, content length %d", end, len(content))
+	}
+}
+
+func TestSemanticSplitterStrategies(t *testing.T) {
+	ctx := context.Background()
+	content := "cat one. cat two. cat three. car one. car two. car three."
+
+	for _, bt := range []BreakpointType{BreakpointPercentile, BreakpointStandardDeviation, BreakpointInterquartile} {
+		t.Run(string(bt), func(t *testing.T) {
+			s, err := NewSplitter(ctx, &Config{
+				Embedding:        &topicEmbedding{topics: []string{"cat", "car"}},
+				Separators:       []string{"."},
+				BreakpointType:   bt,
+				BreakpointAmount: 1,
+				Percentile:       0.9,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(docs) != 2 || docs[0].Content != "cat one. cat two. cat three." {
+				t.Fatalf("unexpected chunks: %v", docs)
+			}
+			if _, ok := docs[0].MetaData[MetaKeyBreakpointDistance]; ok {
+				t.Errorf("first chunk should not have breakpoint distance")
+			}
+			if d := docs[1].MetaData[MetaKeyBreakpointDistance].(float64); d < 0.9 {
+				t.Errorf("breakpoint distance = %v", d)
+			}
+			checkChunks(t, content, docs)
+		})
+	}
+
+	_, err := NewSplitter(ctx, &Config{Embedding: &topicEmbedding{}, BreakpointType: "unknown"})
+	if err == nil {
+		t.Error("expect error of unknown breakpoint type")
+	}
+}
+
+func TestBreakpoints(t *testing.T) {
+	distances := []float64{0.1, 0.1, 0.2, 0.6, 0.7, 0.1, 0.1, 0.9}
+	tests := []struct {
+		breakpointType BreakpointType
+		percentile     float64
+		amount         float64
+		want           []int
+	}{
+		{breakpointType: BreakpointPercentile, percentile: 0.7, want: []int{4, 7}},
+		{breakpointType: BreakpointStandardDeviation, amount: 1, want: []int{4, 7}},
+		{breakpointType: BreakpointInterquartile, amount: 1, want: []int{7}},
+		{breakpointType: BreakpointGradient, percentile: 0.7, want: []int{6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.breakpointType), func(t *testing.T) {
+			s := &splitter{breakpointType: tt.breakpointType, percentile: tt.percentile, amount: tt.amount}
+			if got := s.breakpoints(distances); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("breakpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentileBreakpoints(t *testing.T) {
+	// the top (1 - Percentile) of distances are breakpoints, the number of splits is the same as with
+	// the smallest distances of earlier versions.
+	s := &splitter{breakpointType: BreakpointPercentile, percentile: 0.7}
+	distances := []float64{0.05, 0.8, 0.1, 0.02, 0.9, 0.1}
+	if got := s.breakpoints(distances); !reflect.DeepEqual(got, []int{1, 4}) {
+		t.Errorf("breakpoints() = %v, want [1 4]", got)
+	}
+
+	// at least one breakpoint is selected.
+	s.percentile = 0.9999
+	if got := s.breakpoints(distances); !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("breakpoints() = %v, want [4]", got)
+	}
+}
+
+func TestSemanticSplitterBatch(t *testing.T) {
+	ctx := context.Background()
+	content := "cat a. cat b. cat c. car d. car e. car f. car g."
+
+	single := &topicEmbedding{topics: []string{"cat", "car"}}
+	s, err := NewSplitter(ctx, &Config{Embedding: single, Separators: []string{"."}, BufferSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := s.Transform(ctx, []*schema.Document{{Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batched := &topicEmbedding{topics: []string{"cat", "car"}}
+	s, err = NewSplitter(ctx, &Config{Embedding: batched, Separators: []string{"."}, BufferSize: 1, BatchSize: 2, Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Transform(ctx, []*schema.Document{{Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("batched chunks = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(single.batches, []int{7}) {
+		t.Errorf("single batches = %v", single.batches)
+	}
+	if len(batched.batches) != 4 || batched.maxInUse > 2 {
+		t.Errorf("batches = %v, max concurrency = %d", batched.batches, batched.maxInUse)
+	}
+
+	failed := &topicEmbedding{topics: []string{"cat"}, fail: true}
+	s, err = NewSplitter(ctx, &Config{Embedding: failed, Separators: []string{"."}, BatchSize: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Transform(ctx, []*schema.Document{{Content: content}}); err == nil {
+		t.Error("expect embedding error")
+	}
+}
+
+func TestSemanticSplitterMaxChunkSize(t *testing.T) {
+	ctx := context.Background()
+	content := "cat sat on the mat, then it slept. cat woke up and ate, then it slept again.\n" +
+		"carcarcarcarcarcarcarcarcarcar."
+
+	s, err := NewSplitter(ctx, &Config{
+		Embedding:    &topicEmbedding{topics: []string{"cat", "car"}},
+		Separators:   []string{"\n", "."},
+		MaxChunkSize: 20,
+		Percentile:   0.99,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := s.Transform(ctx, []*schema.Document{{Content: content, MetaData: map[string]interface{}{"k": "v"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, doc := range docs {
+		if len(doc.Content) > 20 {
+			t.Errorf("chunk %q exceeds max chunk size", doc.Content)
+		}
+		if doc.MetaData["k"] != "v" {
+			t.Errorf("meta data of chunk %q is lost", doc.Content)
+		}
+	}
+	checkChunks(t, content, docs)
+
+	// the chunk split at the breakpoint keeps its distance on the first piece.
+	found := false
+	for _, doc := range docs {
+		if _, ok := doc.MetaData[MetaKeyBreakpointDistance]; ok {
+			found = true
+			if !strings.HasPrefix(doc.Content, "carcar") {
+				t.Errorf("breakpoint at %q", doc.Content)
+			}
+		}
+	}
+	if !found {
+		t.Error("breakpoint distance not found")
+	}
+}
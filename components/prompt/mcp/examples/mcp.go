@@ -78,6 +78,9 @@ func startMCPServer() {
 	svr := server.NewMCPServer("demo", mcp.LATEST_PROTOCOL_VERSION, server.WithPromptCapabilities(false))
 	svr.AddPrompt(mcp.Prompt{
 		Name: "test",
+		Arguments: []mcp.PromptArgument{
+			{Name: "persona", Description: "the persona of the user", Required: true},
+		},
 	}, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 		return &mcp.GetPromptResult{
 			Messages: []mcp.PromptMessage{
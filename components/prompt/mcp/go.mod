@@ -3,13 +3,13 @@ module github.com/cloudwego/eino-ext/components/prompt/mcp
 go 1.23
 
 require (
+	github.com/bytedance/sonic v1.12.2
 	github.com/cloudwego/eino v0.3.10
 	github.com/mark3labs/mcp-go v0.10.3
 	github.com/stretchr/testify v1.9.0
 )
 
 require (
-	github.com/bytedance/sonic v1.12.2 // indirect
 	github.com/bytedance/sonic/loader v0.2.0 // indirect
 	github.com/cloudwego/base64x v0.1.4 // indirect
 	github.com/cloudwego/iasm v0.2.0 // indirect
@@ -19,14 +19,20 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
 
+	"github.com/bytedance/sonic"
 	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/schema"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// blobPart and dataURL are shared with the mcp tool, blob_gen.go is generated from its blob.go.
+//go:generate sh -c "{ echo '// Code generated by go generate from components/tool/mcp/blob.go. DO NOT EDIT.'; echo; cat ../../tool/mcp/blob.go; } > blob_gen.go"
+
 type Config struct {
 	// Cli is the MCP (Model Control Protocol) client, ref: https://github.com/mark3labs/mcp-go
 	// Notice: should Initialize with server before use
@@ -35,24 +41,46 @@ type Config struct {
 	// Name specifies the prompt name to use from MCP service
 	// Required
 	Name string
+	// Arguments declares the arguments of the prompt, required ones are checked before getting the prompt.
+	// Optional. If nil, the arguments are fetched from the prompt list of MCP service on the first Format.
+	Arguments []mcp.PromptArgument
 }
 
 func NewPromptTemplate(_ context.Context, conf *Config) (prompt.ChatTemplate, error) {
 	return &chatTemplate{
-		cli:  conf.Cli,
-		name: conf.Name,
+		cli:       conf.Cli,
+		name:      conf.Name,
+		arguments: conf.Arguments,
+		fetched:   conf.Arguments != nil,
 	}, nil
 }
 
 type chatTemplate struct {
 	cli  client.MCPClient
 	name string
+
+	mu        sync.Mutex
+	arguments []mcp.PromptArgument
+	fetched   bool
 }
 
 func (c *chatTemplate) Format(ctx context.Context, vs map[string]any, _ ...prompt.Option) ([]*schema.Message, error) {
+	arguments, err := c.getArguments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range arguments {
+		if v, ok := vs[a.Name]; a.Required && (!ok || v == nil) {
+			return nil, fmt.Errorf("missing required argument %s of mcp prompt %s", a.Name, c.name)
+		}
+	}
+
 	arg := make(map[string]string, len(vs))
 	for k, v := range vs {
-		arg[k] = fmt.Sprint(v)
+		arg[k], err = formatArgument(v)
+		if err != nil {
+			return nil, fmt.Errorf("format argument %s fail: %w", k, err)
+		}
 	}
 
 	result, err := c.cli.GetPrompt(ctx, mcp.GetPromptRequest{
@@ -79,6 +107,69 @@ func (c *chatTemplate) Format(ctx context.Context, vs map[string]any, _ ...promp
 	return messages, nil
 }
 
+// getArguments returns the declared arguments of the prompt, which are fetched from the prompt list only once.
+func (c *chatTemplate) getArguments(ctx context.Context) ([]mcp.PromptArgument, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fetched {
+		return c.arguments, nil
+	}
+
+	var cursor mcp.Cursor
+	for {
+		req := mcp.ListPromptsRequest{}
+		req.Params.Cursor = cursor
+		result, err := c.cli.ListPrompts(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("list mcp prompts fail: %w", err)
+		}
+		for _, p := range result.Prompts {
+			if p.Name == c.name {
+				c.arguments, c.fetched = p.Arguments, true
+				return c.arguments, nil
+			}
+		}
+
+		if result.NextCursor == "" || result.NextCursor == cursor {
+			break
+		}
+		cursor = result.NextCursor
+	}
+	return nil, fmt.Errorf("mcp prompt %s not found", c.name)
+}
+
+// jsonAPI sorts map keys, so that the same variables always result in the same prompt.
+var jsonAPI = sonic.Config{SortMapKeys: true}.Froze()
+
+// formatArgument converts a variable into the string argument of MCP prompts.
+// Maps, slices, arrays and structs are serialized as JSON, the others are formatted by fmt.Sprint.
+func formatArgument(v any) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case []byte:
+		return string(t), nil
+	case fmt.Stringer:
+		return t.String(), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "", nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return jsonAPI.MarshalToString(v)
+	default:
+		return fmt.Sprint(rv.Interface()), nil
+	}
+}
+
 // GetType returns the type of the chat template (Default).
 func (c *chatTemplate) GetType() string {
 	return "MCP"
@@ -109,48 +200,18 @@ func convMessage(message mcp.PromptMessage) (*schema.Message, error) {
 		ret.MultiContent = append(ret.MultiContent, schema.ChatMessagePart{
 			Type: schema.ChatMessagePartTypeImageURL,
 			ImageURL: &schema.ChatMessageImageURL{
-				URL:      m.Data,
+				URL:      imageURL(m.MIMEType, m.Data),
 				MIMEType: m.MIMEType,
 			},
 		})
 	case mcp.EmbeddedResource:
-		var mimeType, uri string
 		switch resource := m.Resource.(type) {
-		case mcp.BlobResourceContents:
-			uri = resource.URI
-			mimeType = resource.MIMEType
 		case mcp.TextResourceContents:
-			uri = resource.URI
-			mimeType = resource.MIMEType
-		}
-		if strings.HasPrefix(mimeType, "audio") {
-			ret.MultiContent = append(ret.MultiContent, schema.ChatMessagePart{
-				Type: schema.ChatMessagePartTypeAudioURL,
-				AudioURL: &schema.ChatMessageAudioURL{
-					URL:      uri,
-					MIMEType: mimeType,
-				},
-			})
-		} else if strings.HasPrefix(mimeType, "image") {
-			ret.MultiContent = append(ret.MultiContent, schema.ChatMessagePart{
-				Type: schema.ChatMessagePartTypeImageURL,
-				ImageURL: &schema.ChatMessageImageURL{
-					URL:      uri,
-					MIMEType: mimeType,
-				},
-			})
-		} else if strings.HasPrefix(mimeType, "video") {
-			ret.MultiContent = append(ret.MultiContent, schema.ChatMessagePart{
-				Type: schema.ChatMessagePartTypeVideoURL,
-				VideoURL: &schema.ChatMessageVideoURL{
-					URL:      uri,
-					MIMEType: mimeType,
-				},
-			})
-		} else if strings.HasPrefix(mimeType, "text") {
-			ret.Content = uri
-		} else {
-			return nil, fmt.Errorf("unsupported mcp resource mime type %v", mimeType)
+			ret.Content = resource.Text
+		case mcp.BlobResourceContents:
+			ret.MultiContent = append(ret.MultiContent, blobPart(resource))
+		default:
+			return nil, fmt.Errorf("unknown mcp resource contents type: %T", m.Resource)
 		}
 	default:
 		return nil, fmt.Errorf("unknown mcp prompt content type: %T", message.Content)
@@ -158,3 +219,14 @@ func convMessage(message mcp.PromptMessage) (*schema.Message, error) {
 
 	return ret, nil
 }
+
+// imageURL returns the URL of image data, which is base64 encoded by protocol,
+// but some servers put a URL instead, which is returned as is.
+func imageURL(mimeType, data string) string {
+	for _, prefix := range []string{"http://", "https://", "data:"} {
+		if strings.HasPrefix(data, prefix) {
+			return data
+		}
+	}
+	return dataURL(mimeType, data)
+}
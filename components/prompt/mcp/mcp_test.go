@@ -18,7 +18,10 @@ package mcp
 
 import (
 	"context"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/schema"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -53,14 +56,22 @@ func TestConvMessage(t *testing.T) {
 				},
 			},
 		},
+		{
+			Role: mcp.RoleAssistant,
+			Content: mcp.ImageContent{
+				Type:     "image",
+				Data:     "https://example.com/a.jpg",
+				MIMEType: "image/jpeg",
+			},
+		},
 		{
 			Role: mcp.RoleUser,
 			Content: mcp.EmbeddedResource{
 				Type: "resource",
-				Resource: mcp.TextResourceContents{
+				Resource: mcp.BlobResourceContents{
 					URI:      "test uri",
 					MIMEType: "image/jpeg",
-					Text:     "test text",
+					Blob:     "test blob",
 				},
 			},
 		},
@@ -68,10 +79,10 @@ func TestConvMessage(t *testing.T) {
 			Role: mcp.RoleUser,
 			Content: mcp.EmbeddedResource{
 				Type: "resource",
-				Resource: mcp.TextResourceContents{
+				Resource: mcp.BlobResourceContents{
 					URI:      "test uri",
 					MIMEType: "audio/mpeg",
-					Text:     "test text",
+					Blob:     "test blob",
 				},
 			},
 		},
@@ -79,10 +90,21 @@ func TestConvMessage(t *testing.T) {
 			Role: mcp.RoleUser,
 			Content: mcp.EmbeddedResource{
 				Type: "resource",
-				Resource: mcp.TextResourceContents{
+				Resource: mcp.BlobResourceContents{
 					URI:      "test uri",
 					MIMEType: "video/mpeg",
-					Text:     "test text",
+					Blob:     "test blob",
+				},
+			},
+		},
+		{
+			Role: mcp.RoleUser,
+			Content: mcp.EmbeddedResource{
+				Type: "resource",
+				Resource: mcp.BlobResourceContents{
+					URI:      "test uri",
+					MIMEType: "application/pdf",
+					Blob:     "test blob",
 				},
 			},
 		},
@@ -95,7 +117,7 @@ func TestConvMessage(t *testing.T) {
 				{
 					Type: schema.ChatMessagePartTypeImageURL,
 					ImageURL: &schema.ChatMessageImageURL{
-						URL:      "test data",
+						URL:      "data:image/jpeg;base64,test data",
 						MIMEType: "image/jpeg",
 					},
 				},
@@ -103,7 +125,19 @@ func TestConvMessage(t *testing.T) {
 		},
 		{
 			Role:    schema.User,
-			Content: "test uri",
+			Content: "test text",
+		},
+		{
+			Role: schema.Assistant,
+			MultiContent: []schema.ChatMessagePart{
+				{
+					Type: schema.ChatMessagePartTypeImageURL,
+					ImageURL: &schema.ChatMessageImageURL{
+						URL:      "https://example.com/a.jpg",
+						MIMEType: "image/jpeg",
+					},
+				},
+			},
 		},
 		{
 			Role: schema.User,
@@ -111,7 +145,8 @@ func TestConvMessage(t *testing.T) {
 				{
 					Type: schema.ChatMessagePartTypeImageURL,
 					ImageURL: &schema.ChatMessageImageURL{
-						URL:      "test uri",
+						URL:      "data:image/jpeg;base64,test blob",
+						URI:      "test uri",
 						MIMEType: "image/jpeg",
 					},
 				},
@@ -123,7 +158,8 @@ func TestConvMessage(t *testing.T) {
 				{
 					Type: schema.ChatMessagePartTypeAudioURL,
 					AudioURL: &schema.ChatMessageAudioURL{
-						URL:      "test uri",
+						URL:      "data:audio/mpeg;base64,test blob",
+						URI:      "test uri",
 						MIMEType: "audio/mpeg",
 					},
 				},
@@ -135,12 +171,26 @@ func TestConvMessage(t *testing.T) {
 				{
 					Type: schema.ChatMessagePartTypeVideoURL,
 					VideoURL: &schema.ChatMessageVideoURL{
-						URL:      "test uri",
+						URL:      "data:video/mpeg;base64,test blob",
+						URI:      "test uri",
 						MIMEType: "video/mpeg",
 					},
 				},
 			},
 		},
+		{
+			Role: schema.User,
+			MultiContent: []schema.ChatMessagePart{
+				{
+					Type: schema.ChatMessagePartTypeFileURL,
+					FileURL: &schema.ChatMessageFileURL{
+						URL:      "data:application/pdf;base64,test blob",
+						URI:      "test uri",
+						MIMEType: "application/pdf",
+					},
+				},
+			},
+		},
 	}
 
 	var output []*schema.Message
@@ -154,17 +204,74 @@ func TestConvMessage(t *testing.T) {
 
 func TestFormat(t *testing.T) {
 	ctx := context.Background()
+	cli := &mockMCPClient{}
 	tpl, err := NewPromptTemplate(ctx, &Config{
-		Cli: &mockMCPClient{},
+		Cli:  cli,
+		Name: "test",
 	})
 	assert.NoError(t, err)
-	result, err := tpl.Format(ctx, map[string]interface{}{})
+
+	_, err = tpl.Format(ctx, map[string]interface{}{})
+	assert.EqualError(t, err, "missing required argument persona of mcp prompt test")
+
+	result, err := tpl.Format(ctx, map[string]interface{}{
+		"persona": "assistant",
+		"tags":    []string{"a", "b"},
+		"meta":    map[string]any{"k2": 2, "k1": true},
+		"n":       1.5,
+	})
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(result))
 	assert.Equal(t, "hello world", result[0].Content)
+	assert.Equal(t, map[string]string{
+		"persona": "assistant",
+		"tags":    `["a","b"]`,
+		"meta":    `{"k1":true,"k2":2}`,
+		"n":       "1.5",
+	}, cli.arguments)
+	// arguments are fetched once, following the cursor
+	assert.Equal(t, 2, cli.listCalls)
+
+	tpl, err = NewPromptTemplate(ctx, &Config{Cli: cli, Name: "unknown"})
+	assert.NoError(t, err)
+	_, err = tpl.Format(ctx, map[string]interface{}{})
+	assert.EqualError(t, err, "mcp prompt unknown not found")
+
+	// declared arguments skip the listing
+	tpl, err = NewPromptTemplate(ctx, &Config{Cli: cli, Name: "unknown", Arguments: []mcp.PromptArgument{}})
+	assert.NoError(t, err)
+	_, err = tpl.Format(ctx, map[string]interface{}{})
+	assert.NoError(t, err)
 }
 
-type mockMCPClient struct{}
+func TestFormatArgument(t *testing.T) {
+	s := "pointer"
+	for _, c := range []struct {
+		v        any
+		expected string
+	}{
+		{nil, ""},
+		{"text", "text"},
+		{[]byte("bytes"), "bytes"},
+		{42, "42"},
+		{true, "true"},
+		{&s, "pointer"},
+		{[]int{1, 2}, "[1,2]"},
+		{struct {
+			Name string `json:"name"`
+		}{Name: "eino"}, `{"name":"eino"}`},
+		{time.Second, "1s"},
+	} {
+		got, err := formatArgument(c.v)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, got)
+	}
+}
+
+type mockMCPClient struct {
+	listCalls int
+	arguments map[string]string
+}
 
 func (m *mockMCPClient) Initialize(ctx context.Context, request mcp.InitializeRequest) (*mcp.InitializeResult, error) {
 	panic("implement me")
@@ -195,10 +302,23 @@ func (m *mockMCPClient) Unsubscribe(ctx context.Context, request mcp.Unsubscribe
 }
 
 func (m *mockMCPClient) ListPrompts(ctx context.Context, request mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
-	panic("implement me")
+	m.listCalls++
+	if request.Params.Cursor == "" {
+		result := &mcp.ListPromptsResult{Prompts: []mcp.Prompt{{Name: "other"}}}
+		result.NextCursor = "page2"
+		return result, nil
+	}
+	return &mcp.ListPromptsResult{Prompts: []mcp.Prompt{{
+		Name: "test",
+		Arguments: []mcp.PromptArgument{
+			{Name: "persona", Required: true},
+			{Name: "tags"},
+		},
+	}}}, nil
 }
 
 func (m *mockMCPClient) GetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	m.arguments = request.Params.Arguments
 	return &mcp.GetPromptResult{
 		Messages: []mcp.PromptMessage{
 			{
@@ -235,3 +355,14 @@ func (m *mockMCPClient) Close() error {
 func (m *mockMCPClient) OnNotification(handler func(notification mcp.JSONRPCNotification)) {
 	panic("implement me")
 }
+
+func TestBlobGenUpToDate(t *testing.T) {
+	src, err := os.ReadFile("../../tool/mcp/blob.go")
+	if os.IsNotExist(err) {
+		t.Skip("not in the eino-ext repository")
+	}
+	assert.NoError(t, err)
+	gen, err := os.ReadFile("blob_gen.go")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(gen), string(src)), "blob_gen.go is outdated, run go generate")
+}
@@ -22,6 +22,15 @@ const (
 	defaultTopK = 10
 )
 
+const (
+	// MetaKeyHighlight highlight fragments of hit, value type is map[string][]string, field name to fragments.
+	MetaKeyHighlight = "_highlight"
+	// MetaKeySort sort values of hit, value type is []types.FieldValue.
+	MetaKeySort = "_sort"
+	// MetaKeyInnerHits inner hits of collapsed hit, value type is map[string]types.InnerHitsResult.
+	MetaKeyInnerHits = "_inner_hits"
+)
+
 func GetType() string {
 	return typ
 }
@@ -26,6 +26,29 @@ import (
 type ImplOptions struct {
 	Filters      []types.Query      `json:"filters,omitempty"`
 	SparseVector map[string]float32 `json:"sparse_vector,omitempty"`
+
+	Sort         []types.SortCombinations      `json:"sort,omitempty"`
+	SearchAfter  []types.FieldValue            `json:"search_after,omitempty"`
+	PointInTime  *types.PointInTimeReference   `json:"point_in_time,omitempty"`
+	Highlight    *types.Highlight              `json:"highlight,omitempty"`
+	Aggregations map[string]types.Aggregations `json:"aggregations,omitempty"`
+	Collapse     *types.FieldCollapse          `json:"collapse,omitempty"`
+
+	// SearchResult receives response level information, such as aggregations and the cursor of next page.
+	SearchResult *SearchResult `json:"-"`
+}
+
+// SearchResult response level information of a search, which can not be carried by documents.
+type SearchResult struct {
+	// Total hits matched the query, may be a lower bound, see types.TotalHits.Relation.
+	Total *types.TotalHits
+	// Aggregations results of aggregations requested by WithAggregations.
+	Aggregations map[string]types.Aggregate
+	// PointInTimeID the latest point in time id returned by es, which should be used in the next page.
+	PointInTimeID string
+	// SearchAfter sort values of the last hit, pass it to WithSearchAfter to fetch the next page.
+	// Empty if no hits returned or sort values not provided by es.
+	SearchAfter []types.FieldValue
 }
 
 // WithFilters set filters for retrieve query.
@@ -45,3 +68,61 @@ func WithSparseVector(sparse map[string]float32) retriever.Option {
 		o.SparseVector = sparse
 	})
 }
+
+// WithSort set sort for retrieve query, which is required by search_after pagination without point in time.
+// Sort values of each hit will be stored in document metadata with key MetaKeySort.
+func WithSort(sort []types.SortCombinations) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Sort = sort
+	})
+}
+
+// WithSearchAfter set search_after to fetch the page after the hit with given sort values,
+// usually SearchResult.SearchAfter of previous page.
+func WithSearchAfter(searchAfter []types.FieldValue) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.SearchAfter = searchAfter
+	})
+}
+
+// WithPointInTime search against a point in time opened by Retriever.OpenPointInTime.
+// Index of the retriever is ignored in this case, as the point in time is bound to indices.
+// keepAlive extends the point in time, e.g. "1m", empty means not to extend.
+func WithPointInTime(id string, keepAlive string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.PointInTime = &types.PointInTimeReference{Id: id}
+		if keepAlive != "" {
+			o.PointInTime.KeepAlive = keepAlive
+		}
+	})
+}
+
+// WithHighlight set highlight for retrieve query.
+// Highlight fragments of each hit will be stored in document metadata with key MetaKeyHighlight.
+func WithHighlight(highlight *types.Highlight) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Highlight = highlight
+	})
+}
+
+// WithAggregations set aggregations for retrieve query, results can be received by WithSearchResult.
+func WithAggregations(aggregations map[string]types.Aggregations) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Aggregations = aggregations
+	})
+}
+
+// WithCollapse collapse hits by field, e.g. keep only the best chunk of each source document.
+// Inner hits of each collapsed hit will be stored in document metadata with key MetaKeyInnerHits.
+func WithCollapse(collapse *types.FieldCollapse) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Collapse = collapse
+	})
+}
+
+// WithSearchResult set a receiver of response level information, which is filled after search succeeded.
+func WithSearchResult(result *SearchResult) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.SearchResult = result
+	})
+}
@@ -21,6 +21,8 @@ import (
 	"fmt"
 
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/closepointintime"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/openpointintime"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
 
@@ -112,10 +114,16 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		return nil, err
 	}
 
-	resp, err := search.NewSearchFunc(r.client)().
-		Index(r.config.Index).
-		Request(req).
-		Do(ctx)
+	io := retriever.GetImplSpecificOptions[ImplOptions](nil, opts...)
+	applyImplOptions(req, io)
+
+	s := search.NewSearchFunc(r.client)()
+	if req.Pit == nil {
+		// index must not be specified when searching with point in time
+		s = s.Index(r.config.Index)
+	}
+
+	resp, err := s.Request(req).Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +133,10 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		return nil, err
 	}
 
+	if io.SearchResult != nil {
+		fillSearchResult(io.SearchResult, resp)
+	}
+
 	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
 
 	return docs, nil
@@ -139,12 +151,86 @@ func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response
 			return nil, err
 		}
 
+		if len(hit.Highlight) > 0 || len(hit.Sort) > 0 || len(hit.InnerHits) > 0 {
+			if doc.MetaData == nil {
+				doc.MetaData = make(map[string]any)
+			}
+			if len(hit.Highlight) > 0 {
+				doc.MetaData[MetaKeyHighlight] = hit.Highlight
+			}
+			if len(hit.Sort) > 0 {
+				doc.MetaData[MetaKeySort] = hit.Sort
+			}
+			if len(hit.InnerHits) > 0 {
+				doc.MetaData[MetaKeyInnerHits] = hit.InnerHits
+			}
+		}
+
 		docs = append(docs, doc)
 	}
 
 	return docs, nil
 }
 
+// OpenPointInTime opens a point in time on the index of retriever, for consistent search_after pagination.
+// keepAlive is required, e.g. "1m". Close it by ClosePointInTime when pagination finished.
+func (r *Retriever) OpenPointInTime(ctx context.Context, keepAlive string) (string, error) {
+	resp, err := openpointintime.NewOpenPointInTimeFunc(r.client)(r.config.Index).
+		KeepAlive(keepAlive).
+		Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("[OpenPointInTime] open point in time failed, %w", err)
+	}
+
+	return resp.Id, nil
+}
+
+// ClosePointInTime closes the point in time opened by OpenPointInTime.
+func (r *Retriever) ClosePointInTime(ctx context.Context, id string) error {
+	_, err := closepointintime.NewClosePointInTimeFunc(r.client)().
+		Request(&closepointintime.Request{Id: id}).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("[ClosePointInTime] close point in time failed, %w", err)
+	}
+
+	return nil
+}
+
+func applyImplOptions(req *search.Request, io *ImplOptions) {
+	if len(io.Sort) > 0 {
+		req.Sort = io.Sort
+	}
+	if len(io.SearchAfter) > 0 {
+		req.SearchAfter = io.SearchAfter
+	}
+	if io.PointInTime != nil {
+		req.Pit = io.PointInTime
+	}
+	if io.Highlight != nil {
+		req.Highlight = io.Highlight
+	}
+	if len(io.Aggregations) > 0 {
+		req.Aggregations = io.Aggregations
+	}
+	if io.Collapse != nil {
+		req.Collapse = io.Collapse
+	}
+}
+
+func fillSearchResult(result *SearchResult, resp *search.Response) {
+	result.Total = resp.Hits.Total
+	result.Aggregations = resp.Aggregations
+	result.PointInTimeID = ""
+	if resp.PitId != nil {
+		result.PointInTimeID = *resp.PitId
+	}
+	result.SearchAfter = nil
+	if n := len(resp.Hits.Hits); n > 0 {
+		result.SearchAfter = resp.Hits.Hits[n-1].Sort
+	}
+}
+
 func (r *Retriever) GetType() string {
 	return typ
 }
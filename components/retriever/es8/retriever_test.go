@@ -92,6 +92,103 @@ func TestNewRetriever(t *testing.T) {
 		assert.Equal(t, "i'm fine, thank you", docs[0].Content)
 	})
 
+	t.Run("retrieve_with_pagination_and_highlight", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client: &elasticsearch.Client{},
+			Index:  "eino_ut",
+			TopK:   10,
+			ResultParser: func(ctx context.Context, hit types.Hit) (doc *schema.Document, err error) {
+				return &schema.Document{ID: *hit.Id_}, nil
+			},
+			SearchMode: &mockSearchMode{},
+		})
+		assert.NoError(t, err)
+
+		mockSearch := search.NewSearchFunc(r.client)()
+
+		indexCalled := false
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Index")).
+			To(func(_ *search.Search, index string) *search.Search {
+				indexCalled = true
+				return mockSearch
+			}).Build().Patch().UnPatch()
+
+		var gotReq *search.Request
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Request")).
+			To(func(_ *search.Search, req *search.Request) *search.Search {
+				gotReq = req
+				return mockSearch
+			}).Build().Patch().UnPatch()
+
+		pitID := "pit_2"
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Do")).Return(&search.Response{
+			PitId: &pitID,
+			Aggregations: map[string]types.Aggregate{
+				"sources": &types.StringTermsAggregate{},
+			},
+			Hits: types.HitsMetadata{
+				Total: &types.TotalHits{Value: 100},
+				Hits: []types.Hit{
+					{
+						Id_:       of("1"),
+						Highlight: map[string][]string{"content": {"<em>fine</em>"}},
+						Sort:      []types.FieldValue{1.5, 10},
+					},
+					{
+						Id_:  of("2"),
+						Sort: []types.FieldValue{1.2, 11},
+						InnerHits: map[string]types.InnerHitsResult{
+							"chunks": {},
+						},
+					},
+				},
+			},
+		}, nil).Build().Patch().UnPatch()
+
+		result := &SearchResult{}
+		docs, err := r.Retrieve(ctx, "how are you",
+			WithPointInTime("pit_1", "1m"),
+			WithSort([]types.SortCombinations{"_score"}),
+			WithSearchAfter([]types.FieldValue{2.0, 9}),
+			WithHighlight(&types.Highlight{Fields: map[string]types.HighlightField{"content": {}}}),
+			WithAggregations(map[string]types.Aggregations{"sources": {}}),
+			WithCollapse(&types.FieldCollapse{Field: "source_id"}),
+			WithSearchResult(result),
+		)
+		assert.NoError(t, err)
+
+		assert.False(t, indexCalled)
+		assert.Equal(t, "pit_1", gotReq.Pit.Id)
+		assert.Equal(t, []types.FieldValue{2.0, 9}, gotReq.SearchAfter)
+		assert.Equal(t, []types.SortCombinations{"_score"}, gotReq.Sort)
+		assert.NotNil(t, gotReq.Highlight)
+		assert.Contains(t, gotReq.Aggregations, "sources")
+		assert.Equal(t, "source_id", gotReq.Collapse.Field)
+
+		assert.Len(t, docs, 2)
+		assert.Equal(t, map[string][]string{"content": {"<em>fine</em>"}}, docs[0].MetaData[MetaKeyHighlight])
+		assert.Equal(t, []types.FieldValue{1.5, 10}, docs[0].MetaData[MetaKeySort])
+		assert.NotContains(t, docs[1].MetaData, MetaKeyHighlight)
+		assert.Contains(t, docs[1].MetaData, MetaKeyInnerHits)
+
+		assert.Equal(t, "pit_2", result.PointInTimeID)
+		assert.Equal(t, []types.FieldValue{1.2, 11}, result.SearchAfter)
+		assert.Equal(t, int64(100), result.Total.Value)
+		assert.Contains(t, result.Aggregations, "sources")
+	})
+}
+
+func TestApplyImplOptions(t *testing.T) {
+	req := &search.Request{Sort: []types.SortCombinations{"_doc"}}
+	applyImplOptions(req, &ImplOptions{})
+	assert.Equal(t, &search.Request{Sort: []types.SortCombinations{"_doc"}}, req)
+
+	applyImplOptions(req, &ImplOptions{Sort: []types.SortCombinations{"_score"}})
+	assert.Equal(t, []types.SortCombinations{"_score"}, req.Sort)
+}
+
+func of[T any](v T) *T {
+	return &v
 }
 
 type mockSearchMode struct{}
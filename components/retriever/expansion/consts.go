@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expansion
+
+const typ = "ContextExpansion"
+
+const (
+	defaultWindowSize = 1
+	defaultSeparator  = "\n"
+)
+
+// Metadata keys of chunks, which are recorded by splitters and read by the retriever.
+const (
+	// MetaKeyParentID id of the document which the chunk is split from, value: string.
+	// Document ID is used as parent id if absent, as splitters keep the id of the original document on chunks.
+	MetaKeyParentID = "_parent_id"
+	// MetaKeyChunkIndex index of the chunk in its parent, value: int
+	MetaKeyChunkIndex = "_chunk_index"
+	// MetaKeyStartOffset byte offset in parent content where the chunk starts, value: int
+	MetaKeyStartOffset = "_start_offset"
+	// MetaKeyEndOffset byte offset in parent content where the chunk ends (exclusive), value: int
+	MetaKeyEndOffset = "_end_offset"
+)
+
+const (
+	// MetaKeyMatchedChunks indexes of retrieved chunks covered by the expanded document, value: []int
+	MetaKeyMatchedChunks = "_matched_chunks"
+)
+
+// ExpandMode specifies how retrieved chunks are expanded.
+type ExpandMode string
+
+const (
+	// ExpandModeParent replaces chunks with their parent document, chunks of the same parent are merged into one.
+	ExpandModeParent ExpandMode = "parent"
+	// ExpandModeWindow expands each chunk with its neighbouring chunks, overlapping or adjacent windows
+	// of the same parent are merged into one.
+	ExpandModeWindow ExpandMode = "window"
+)
+
+func GetType() string {
+	return typ
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/expansion"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// chunks are usually produced by splitters and written to both the vector store and the chunk store
+	chunks := []*schema.Document{
+		{ID: "doc_1", Content: "Eino is a LLM application framework.", MetaData: map[string]any{expansion.MetaKeyChunkIndex: 0}},
+		{ID: "doc_1", Content: "It provides component abstractions.", MetaData: map[string]any{expansion.MetaKeyChunkIndex: 1}},
+		{ID: "doc_1", Content: "Eino-ext contains component implementations.", MetaData: map[string]any{expansion.MetaKeyChunkIndex: 2}},
+	}
+
+	store := expansion.NewMemoryStore(nil)
+	if err := store.AddChunks(chunks...); err != nil {
+		log.Fatalf("AddChunks failed, err=%v", err)
+	}
+
+	// replace with a real retriever, e.g. es8.NewRetriever
+	var base retriever.Retriever = &staticRetriever{docs: []*schema.Document{chunks[1]}}
+
+	r, err := expansion.NewRetriever(ctx, &expansion.RetrieverConfig{
+		Retriever:    base,
+		Store:        store,
+		Mode:         expansion.ExpandModeWindow,
+		WindowBefore: 1,
+		WindowAfter:  1,
+		Separator:    " ",
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "what is eino")
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		log.Printf("id=%s, matched=%v, content=%s", doc.ID, doc.MetaData[expansion.MetaKeyMatchedChunks], doc.Content)
+	}
+}
+
+type staticRetriever struct {
+	docs []*schema.Document
+}
+
+func (s *staticRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	return s.docs, nil
+}
@@ -0,0 +1,401 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expansion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+var _ retriever.Retriever = (*Retriever)(nil)
+
+type RetrieverConfig struct {
+	// Retriever retrieves chunks from any backend, required.
+	Retriever retriever.Retriever
+	// Store loads parents or neighbouring chunks of retrieved chunks, required.
+	Store Store
+
+	// Mode specifies how chunks are expanded, ExpandModeParent by default.
+	Mode ExpandMode
+	// ParentID resolves the parent of a chunk, e.g. HeaderParentID for section level parents by the headers recorded by header splitters.
+	// Default is DefaultParentID.
+	ParentID func(doc *schema.Document) string
+
+	// WindowBefore number of chunks before the retrieved chunk to include when using ExpandModeWindow.
+	// Default is 1, set to negative value for none.
+	WindowBefore int
+	// WindowAfter number of chunks after the retrieved chunk to include when using ExpandModeWindow.
+	// Default is 1, set to negative value for none.
+	WindowAfter int
+	// Separator joins neighbouring chunks which are not contiguous according to their offsets.
+	// Default is "\n".
+	Separator string
+
+	// TopK number of expanded documents to return, zero means no limit.
+	TopK int
+}
+
+// Retriever expands chunks retrieved by the underlying retriever with their parents or neighbouring chunks.
+// Chunks without the required metadata, or whose parent/neighbours are not found in Store, are returned as is.
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+// NewRetriever creates a context expansion retriever.
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil {
+		return nil, errors.New("[NewRetriever] config is nil")
+	}
+	if config.Retriever == nil {
+		return nil, errors.New("[NewRetriever] retriever not provided")
+	}
+	if config.Store == nil {
+		return nil, errors.New("[NewRetriever] store not provided")
+	}
+
+	conf := *config
+	if conf.Mode == "" {
+		conf.Mode = ExpandModeParent
+	}
+	if conf.Mode != ExpandModeParent && conf.Mode != ExpandModeWindow {
+		return nil, fmt.Errorf("[NewRetriever] unknown expand mode: %s", conf.Mode)
+	}
+	if conf.ParentID == nil {
+		conf.ParentID = DefaultParentID
+	}
+	conf.WindowBefore = windowSize(conf.WindowBefore)
+	conf.WindowAfter = windowSize(conf.WindowAfter)
+	if conf.Separator == "" {
+		conf.Separator = defaultSeparator
+	}
+
+	return &Retriever{config: &conf}, nil
+}
+
+func windowSize(size int) int {
+	if size == 0 {
+		return defaultWindowSize
+	}
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query: query,
+		TopK:  r.config.TopK,
+	})
+
+	chunks, err := r.config.Retriever.Retrieve(ctx, query, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("[ContextExpansion] retrieve chunks fail: %w", err)
+	}
+
+	groups := r.groupByParent(chunks)
+
+	var expanded []*expandedDoc
+	switch r.config.Mode {
+	case ExpandModeWindow:
+		expanded, err = r.expandWindows(ctx, groups)
+	default:
+		expanded, err = r.expandParents(ctx, groups)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(expanded, func(i, j int) bool {
+		return expanded[i].rank < expanded[j].rank
+	})
+
+	docs = make([]*schema.Document, 0, len(expanded))
+	for _, e := range expanded {
+		docs = append(docs, e.doc)
+	}
+	if r.config.TopK > 0 && len(docs) > r.config.TopK {
+		docs = docs[:r.config.TopK]
+	}
+
+	_ = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+type hit struct {
+	doc      *schema.Document
+	rank     int
+	index    int
+	hasIndex bool
+}
+
+type group struct {
+	parentID string
+	hits     []*hit
+}
+
+// expandedDoc is ranked by the best rank of the chunks it covers.
+type expandedDoc struct {
+	doc  *schema.Document
+	rank int
+}
+
+// groupByParent groups chunks by parent in the order of first appearance.
+func (r *Retriever) groupByParent(chunks []*schema.Document) []*group {
+	groups := make([]*group, 0, len(chunks))
+	byParent := make(map[string]*group, len(chunks))
+	for rank, doc := range chunks {
+		if doc == nil {
+			continue
+		}
+
+		h := &hit{doc: doc, rank: rank}
+		h.index, h.hasIndex = chunkIndex(doc)
+
+		pid := r.config.ParentID(doc)
+		g, ok := byParent[pid]
+		if !ok {
+			g = &group{parentID: pid}
+			byParent[pid] = g
+			groups = append(groups, g)
+		}
+		g.hits = append(g.hits, h)
+	}
+
+	return groups
+}
+
+func (r *Retriever) expandParents(ctx context.Context, groups []*group) ([]*expandedDoc, error) {
+	ids := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g.parentID != "" {
+			ids = append(ids, g.parentID)
+		}
+	}
+
+	parents, err := r.config.Store.GetParents(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("[ContextExpansion] get parents fail: %w", err)
+	}
+
+	ret := make([]*expandedDoc, 0, len(groups))
+	for _, g := range groups {
+		parent, ok := parents[g.parentID]
+		if !ok || parent == nil {
+			ret = append(ret, passThrough(g.hits)...)
+			continue
+		}
+
+		doc := &schema.Document{
+			ID:       parent.ID,
+			Content:  parent.Content,
+			MetaData: deepCopyMap(parent.MetaData),
+		}
+		ret = append(ret, newExpandedDoc(doc, g.hits))
+	}
+
+	return ret, nil
+}
+
+func (r *Retriever) expandWindows(ctx context.Context, groups []*group) ([]*expandedDoc, error) {
+	ret := make([]*expandedDoc, 0, len(groups))
+	for _, g := range groups {
+		var indexed []*hit
+		for _, h := range g.hits {
+			if h.hasIndex && g.parentID != "" {
+				indexed = append(indexed, h)
+			} else {
+				ret = append(ret, passThrough([]*hit{h})...)
+			}
+		}
+
+		for _, w := range mergeWindows(indexed, r.config.WindowBefore, r.config.WindowAfter) {
+			chunks, err := r.config.Store.GetChunks(ctx, g.parentID, w.from, w.to)
+			if err != nil {
+				return nil, fmt.Errorf("[ContextExpansion] get chunks fail, parent=%s: %w", g.parentID, err)
+			}
+			if len(chunks) == 0 {
+				ret = append(ret, passThrough(w.hits)...)
+				continue
+			}
+
+			doc := &schema.Document{
+				ID:       fmt.Sprintf("%s_%d-%d", g.parentID, w.from, w.to),
+				Content:  joinChunks(chunks, r.config.Separator),
+				MetaData: deepCopyMap(chunks[0].MetaData),
+			}
+			if doc.MetaData == nil {
+				doc.MetaData = make(map[string]any)
+			}
+			delete(doc.MetaData, MetaKeyChunkIndex)
+			delete(doc.MetaData, MetaKeyEndOffset)
+			doc.MetaData[MetaKeyParentID] = g.parentID
+			if end, ok := intFromMeta(chunks[len(chunks)-1].MetaData, MetaKeyEndOffset); ok {
+				doc.MetaData[MetaKeyEndOffset] = end
+			}
+
+			ret = append(ret, newExpandedDoc(doc, w.hits))
+		}
+	}
+
+	return ret, nil
+}
+
+type window struct {
+	from, to int
+	hits     []*hit
+}
+
+// mergeWindows computes [index-before, index+after] of each hit, and merges overlapping or adjacent windows.
+func mergeWindows(hits []*hit, before, after int) []*window {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	sorted := make([]*hit, len(hits))
+	copy(sorted, hits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].index < sorted[j].index
+	})
+
+	var windows []*window
+	for _, h := range sorted {
+		from, to := h.index-before, h.index+after
+		if from < 0 {
+			from = 0
+		}
+
+		if n := len(windows); n > 0 && from <= windows[n-1].to+1 {
+			last := windows[n-1]
+			if to > last.to {
+				last.to = to
+			}
+			last.hits = append(last.hits, h)
+			continue
+		}
+
+		windows = append(windows, &window{from: from, to: to, hits: []*hit{h}})
+	}
+
+	return windows
+}
+
+// joinChunks concatenates chunks in order. Overlapping text is removed and contiguous chunks are joined directly
+// when offsets are recorded, otherwise chunks are joined with sep.
+func joinChunks(chunks []*schema.Document, sep string) string {
+	sb := strings.Builder{}
+	prevEnd := -1
+	for i, chunk := range chunks {
+		content := chunk.Content
+		start, hasStart := intFromMeta(chunk.MetaData, MetaKeyStartOffset)
+		end, hasEnd := intFromMeta(chunk.MetaData, MetaKeyEndOffset)
+
+		if i > 0 {
+			switch {
+			case hasStart && prevEnd >= 0 && start < prevEnd:
+				if skip := prevEnd - start; skip < len(content) {
+					content = content[skip:]
+				} else {
+					content = ""
+				}
+			case hasStart && prevEnd >= 0 && start == prevEnd:
+			default:
+				sb.WriteString(sep)
+			}
+		}
+		sb.WriteString(content)
+
+		switch {
+		case !hasEnd:
+			prevEnd = -1
+		case end > prevEnd:
+			prevEnd = end
+		}
+	}
+
+	return sb.String()
+}
+
+// newExpandedDoc sets the best score and matched chunk indexes of hits on doc.
+func newExpandedDoc(doc *schema.Document, hits []*hit) *expandedDoc {
+	rank := hits[0].rank
+	score := hits[0].doc.Score()
+	var matched []int
+	for _, h := range hits {
+		if h.rank < rank {
+			rank = h.rank
+		}
+		if s := h.doc.Score(); s > score {
+			score = s
+		}
+		if h.hasIndex {
+			matched = append(matched, h.index)
+		}
+	}
+
+	if len(matched) > 0 {
+		sort.Ints(matched)
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any)
+		}
+		doc.MetaData[MetaKeyMatchedChunks] = matched
+	}
+
+	return &expandedDoc{doc: doc.WithScore(score), rank: rank}
+}
+
+func passThrough(hits []*hit) []*expandedDoc {
+	ret := make([]*expandedDoc, 0, len(hits))
+	for _, h := range hits {
+		ret = append(ret, &expandedDoc{doc: h.doc, rank: h.rank})
+	}
+
+	return ret
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+func deepCopyMap(in map[string]any) map[string]any {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
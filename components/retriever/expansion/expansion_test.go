@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expansion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRetriever struct {
+	docs []*schema.Document
+	err  error
+}
+
+func (m *mockRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	return m.docs, m.err
+}
+
+func chunk(parentID string, index, start, end int, content string, score float64) *schema.Document {
+	return (&schema.Document{
+		ID:      parentID,
+		Content: content,
+		MetaData: map[string]any{
+			MetaKeyChunkIndex:  index,
+			MetaKeyStartOffset: start,
+			MetaKeyEndOffset:   end,
+		},
+	}).WithScore(score)
+}
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRetriever(ctx, nil)
+	assert.Error(t, err)
+	_, err = NewRetriever(ctx, &RetrieverConfig{Store: NewMemoryStore(nil)})
+	assert.Error(t, err)
+	_, err = NewRetriever(ctx, &RetrieverConfig{Retriever: &mockRetriever{}})
+	assert.Error(t, err)
+	_, err = NewRetriever(ctx, &RetrieverConfig{Retriever: &mockRetriever{}, Store: NewMemoryStore(nil), Mode: "unknown"})
+	assert.Error(t, err)
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{Retriever: &mockRetriever{}, Store: NewMemoryStore(nil), WindowAfter: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, ExpandModeParent, r.config.Mode)
+	assert.Equal(t, 1, r.config.WindowBefore)
+	assert.Equal(t, 0, r.config.WindowAfter)
+	assert.Equal(t, "\n", r.config.Separator)
+	assert.Equal(t, typ, r.GetType())
+}
+
+func TestRetrieveParent(t *testing.T) {
+	ctx := context.Background()
+
+	store := NewMemoryStore(nil)
+	store.AddParents(
+		&schema.Document{ID: "a", Content: "full a", MetaData: map[string]any{"title": "A"}},
+		&schema.Document{ID: "b", Content: "full b"},
+	)
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Retriever: &mockRetriever{docs: []*schema.Document{
+			chunk("b", 3, 0, 0, "b3", 0.9),
+			chunk("a", 1, 0, 0, "a1", 0.8),
+			chunk("b", 0, 0, 0, "b0", 0.95),
+			(&schema.Document{ID: "c", Content: "c without parent"}).WithScore(0.5),
+		}},
+		Store: store,
+	})
+	assert.NoError(t, err)
+
+	docs, err := r.Retrieve(ctx, "query")
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+
+	assert.Equal(t, "full b", docs[0].Content)
+	assert.Equal(t, 0.95, docs[0].Score())
+	assert.Equal(t, []int{0, 3}, docs[0].MetaData[MetaKeyMatchedChunks])
+
+	assert.Equal(t, "full a", docs[1].Content)
+	assert.Equal(t, "A", docs[1].MetaData["title"])
+	assert.Equal(t, 0.8, docs[1].Score())
+
+	assert.Equal(t, "c without parent", docs[2].Content)
+
+	// parent in store is not modified
+	parents, _ := store.GetParents(ctx, []string{"a"})
+	assert.NotContains(t, parents["a"].MetaData, MetaKeyMatchedChunks)
+
+	r.config.TopK = 1
+	docs, err = r.Retrieve(ctx, "query")
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+}
+
+func TestRetrieveWindow(t *testing.T) {
+	ctx := context.Background()
+
+	// parent content: "0123456789abcdefghij", chunks of 5 bytes with 1 byte overlap
+	chunks := []*schema.Document{
+		chunk("p", 0, 0, 5, "01234", 0),
+		chunk("p", 1, 4, 9, "45678", 0),
+		chunk("p", 2, 8, 13, "89abc", 0),
+		chunk("p", 3, 12, 17, "cdefg", 0),
+		chunk("p", 4, 16, 20, "ghij", 0),
+		chunk("p", 5, 25, 28, "xyz", 0),
+	}
+	store := NewMemoryStore(nil)
+	assert.NoError(t, store.AddChunks(chunks...))
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Retriever: &mockRetriever{docs: []*schema.Document{
+			chunk("p", 5, 25, 28, "xyz", 0.9),
+			chunk("p", 1, 4, 9, "45678", 0.8),
+			chunk("p", 2, 8, 13, "89abc", 0.7),
+		}},
+		Store:        store,
+		Mode:         ExpandModeWindow,
+		WindowBefore: 1,
+		WindowAfter:  -1,
+	})
+	assert.NoError(t, err)
+
+	docs, err := r.Retrieve(ctx, "query")
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+
+	assert.Equal(t, "p_4-5", docs[0].ID)
+	assert.Equal(t, "ghij\nxyz", docs[0].Content)
+	assert.Equal(t, 0.9, docs[0].Score())
+	assert.Equal(t, []int{5}, docs[0].MetaData[MetaKeyMatchedChunks])
+	assert.Equal(t, 16, docs[0].MetaData[MetaKeyStartOffset])
+	assert.Equal(t, 28, docs[0].MetaData[MetaKeyEndOffset])
+
+	assert.Equal(t, "p_0-2", docs[1].ID)
+	assert.Equal(t, "0123456789abc", docs[1].Content)
+	assert.Equal(t, 0.8, docs[1].Score())
+	assert.Equal(t, []int{1, 2}, docs[1].MetaData[MetaKeyMatchedChunks])
+	assert.Equal(t, "p", docs[1].MetaData[MetaKeyParentID])
+	assert.NotContains(t, docs[1].MetaData, MetaKeyChunkIndex)
+}
+
+func TestRetrieveError(t *testing.T) {
+	ctx := context.Background()
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Retriever: &mockRetriever{err: errors.New("mock err")},
+		Store:     NewMemoryStore(nil),
+	})
+	assert.NoError(t, err)
+
+	_, err = r.Retrieve(ctx, "query")
+	assert.ErrorContains(t, err, "mock err")
+}
+
+func TestMergeWindows(t *testing.T) {
+	hits := []*hit{{index: 8}, {index: 1}, {index: 3}}
+
+	windows := mergeWindows(hits, 1, 1)
+	assert.Len(t, windows, 2)
+	assert.Equal(t, 0, windows[0].from)
+	assert.Equal(t, 4, windows[0].to)
+	assert.Len(t, windows[0].hits, 2)
+	assert.Equal(t, 7, windows[1].from)
+	assert.Equal(t, 9, windows[1].to)
+	assert.Len(t, windows[1].hits, 1)
+
+	// adjacent windows are merged
+	windows = mergeWindows(hits, 0, 1)
+	assert.Len(t, windows, 2)
+	assert.Equal(t, 1, windows[0].from)
+	assert.Equal(t, 4, windows[0].to)
+
+	windows = mergeWindows(hits, 0, 0)
+	assert.Len(t, windows, 3)
+}
+
+func TestJoinChunks(t *testing.T) {
+	t.Run("without offsets", func(t *testing.T) {
+		docs := []*schema.Document{{Content: "a"}, {Content: "b"}}
+		assert.Equal(t, "a--b", joinChunks(docs, "--"))
+	})
+
+	t.Run("contiguous", func(t *testing.T) {
+		docs := []*schema.Document{
+			chunk("p", 0, 0, 2, "ab", 0),
+			chunk("p", 1, 2, 4, "cd", 0),
+		}
+		assert.Equal(t, "abcd", joinChunks(docs, "\n"))
+	})
+
+	t.Run("contained", func(t *testing.T) {
+		docs := []*schema.Document{
+			chunk("p", 0, 0, 4, "abcd", 0),
+			chunk("p", 1, 1, 3, "bc", 0),
+			chunk("p", 2, 3, 5, "de", 0),
+		}
+		assert.Equal(t, "abcde", joinChunks(docs, "\n"))
+	})
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expansion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Store loads documents that are not returned by the underlying retriever.
+type Store interface {
+	// GetParents returns parent documents by ids, ids not found should be absent from the result.
+	GetParents(ctx context.Context, ids []string) (map[string]*schema.Document, error)
+	// GetChunks returns chunks of the parent whose chunk index is within [from, to], ordered by chunk index.
+	GetChunks(ctx context.Context, parentID string, from, to int) ([]*schema.Document, error)
+}
+
+// DefaultParentID returns MetaKeyParentID of the chunk if present, otherwise the id of the chunk.
+func DefaultParentID(doc *schema.Document) string {
+	if id, ok := doc.MetaData[MetaKeyParentID].(string); ok && id != "" {
+		return id
+	}
+
+	return doc.ID
+}
+
+// HeaderParentID returns a ParentID resolving the section of a chunk by the headers recorded by header splitters,
+// the id is "<DefaultParentID>#<header path joined by " > ">", or DefaultParentID if no header is found.
+// Each key is either a header path of []string, e.g. markdown.MetaKeyHeaderPath,
+// or a header of one level, e.g. the header names configured to the html header splitter from the top level down.
+// Parents stored for parent mode should be identified in the same way.
+func HeaderParentID(keys ...string) func(doc *schema.Document) string {
+	return func(doc *schema.Document) string {
+		var path []string
+		for _, key := range keys {
+			switch v := doc.MetaData[key].(type) {
+			case string:
+				if v != "" {
+					path = append(path, v)
+				}
+			case []string:
+				path = append(path, v...)
+			case []any:
+				// decoded from json by storages
+				for _, h := range v {
+					if s, ok := h.(string); ok {
+						path = append(path, s)
+					}
+				}
+			}
+		}
+
+		id := DefaultParentID(doc)
+		if len(path) == 0 {
+			return id
+		}
+		return id + "#" + strings.Join(path, " > ")
+	}
+}
+
+// MemoryStore an in-memory Store, which is convenient when the corpus is small or for testing.
+type MemoryStore struct {
+	parentID func(doc *schema.Document) string
+
+	mu      sync.RWMutex
+	parents map[string]*schema.Document
+	chunks  map[string][]*schema.Document
+}
+
+// NewMemoryStore creates an in-memory store, parentID resolves the parent of chunks, DefaultParentID if nil.
+func NewMemoryStore(parentID func(doc *schema.Document) string) *MemoryStore {
+	if parentID == nil {
+		parentID = DefaultParentID
+	}
+
+	return &MemoryStore{
+		parentID: parentID,
+		parents:  make(map[string]*schema.Document),
+		chunks:   make(map[string][]*schema.Document),
+	}
+}
+
+// AddParents adds parent documents, which are identified by document id.
+func (m *MemoryStore) AddParents(docs ...*schema.Document) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, doc := range docs {
+		m.parents[doc.ID] = doc
+	}
+}
+
+// AddChunks adds chunks, each chunk must have MetaKeyChunkIndex in metadata.
+// Chunk with the same parent and index is replaced.
+func (m *MemoryStore) AddChunks(docs ...*schema.Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, doc := range docs {
+		idx, ok := chunkIndex(doc)
+		if !ok {
+			return fmt.Errorf("[AddChunks] chunk index not found in metadata, id=%s", doc.ID)
+		}
+
+		pid := m.parentID(doc)
+		chunks := m.chunks[pid]
+		i := sort.Search(len(chunks), func(i int) bool {
+			j, _ := chunkIndex(chunks[i])
+			return j >= idx
+		})
+		if i < len(chunks) {
+			if j, _ := chunkIndex(chunks[i]); j == idx {
+				chunks[i] = doc
+				continue
+			}
+		}
+
+		chunks = append(chunks, nil)
+		copy(chunks[i+1:], chunks[i:])
+		chunks[i] = doc
+		m.chunks[pid] = chunks
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) GetParents(_ context.Context, ids []string) (map[string]*schema.Document, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ret := make(map[string]*schema.Document, len(ids))
+	for _, id := range ids {
+		if doc, ok := m.parents[id]; ok {
+			ret[id] = doc
+		}
+	}
+
+	return ret, nil
+}
+
+func (m *MemoryStore) GetChunks(_ context.Context, parentID string, from, to int) ([]*schema.Document, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var ret []*schema.Document
+	for _, doc := range m.chunks[parentID] {
+		idx, _ := chunkIndex(doc)
+		if idx > to {
+			break
+		}
+		if idx >= from {
+			ret = append(ret, doc)
+		}
+	}
+
+	return ret, nil
+}
+
+func chunkIndex(doc *schema.Document) (int, bool) {
+	return intFromMeta(doc.MetaData, MetaKeyChunkIndex)
+}
+
+// intFromMeta reads an int from metadata, which may be decoded from json as float64 by storages.
+func intFromMeta(meta map[string]any, key string) (int, bool) {
+	switch v := meta[key].(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
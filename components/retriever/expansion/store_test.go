@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expansion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	store := NewMemoryStore(nil)
+	store.AddParents(&schema.Document{ID: "p1", Content: "parent"})
+
+	err := store.AddChunks(
+		&schema.Document{ID: "p1", Content: "c2", MetaData: map[string]any{MetaKeyChunkIndex: 2}},
+		&schema.Document{ID: "c0", Content: "c0", MetaData: map[string]any{MetaKeyChunkIndex: float64(0), MetaKeyParentID: "p1"}},
+		&schema.Document{ID: "p1", Content: "c1", MetaData: map[string]any{MetaKeyChunkIndex: int64(1)}},
+		&schema.Document{ID: "p1", Content: "c1 replaced", MetaData: map[string]any{MetaKeyChunkIndex: 1}},
+	)
+	assert.NoError(t, err)
+
+	err = store.AddChunks(&schema.Document{ID: "p1", Content: "no index"})
+	assert.Error(t, err)
+
+	parents, err := store.GetParents(ctx, []string{"p1", "p2"})
+	assert.NoError(t, err)
+	assert.Len(t, parents, 1)
+	assert.Equal(t, "parent", parents["p1"].Content)
+
+	chunks, err := store.GetChunks(ctx, "p1", 1, 5)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 2)
+	assert.Equal(t, "c1 replaced", chunks[0].Content)
+	assert.Equal(t, "c2", chunks[1].Content)
+
+	chunks, err = store.GetChunks(ctx, "p1", 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 1)
+	assert.Equal(t, "c0", chunks[0].Content)
+
+	chunks, err = store.GetChunks(ctx, "p2", 0, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, chunks)
+}
+
+func TestDefaultParentID(t *testing.T) {
+	assert.Equal(t, "doc", DefaultParentID(&schema.Document{ID: "doc"}))
+	assert.Equal(t, "parent", DefaultParentID(&schema.Document{ID: "doc", MetaData: map[string]any{MetaKeyParentID: "parent"}}))
+}
+
+func TestHeaderParentID(t *testing.T) {
+	byPath := HeaderParentID("_header_path")
+	assert.Equal(t, "doc#Intro > Install", byPath(&schema.Document{ID: "doc", MetaData: map[string]any{"_header_path": []string{"Intro", "Install"}}}))
+	assert.Equal(t, "doc#Intro", byPath(&schema.Document{ID: "doc", MetaData: map[string]any{"_header_path": []any{"Intro"}}}))
+	assert.Equal(t, "doc", byPath(&schema.Document{ID: "doc"}))
+
+	byLevels := HeaderParentID("Header 1", "Header 2", "Header 3")
+	assert.Equal(t, "parent#Intro > Install", byLevels(&schema.Document{ID: "doc", MetaData: map[string]any{
+		MetaKeyParentID: "parent",
+		"Header 1":      "Intro",
+		"Header 3":      "Install",
+	}}))
+}
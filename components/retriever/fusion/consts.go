@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+const typ = "Fusion"
+
+const (
+	defaultWeight          = 1.0
+	defaultRRFRankConstant = 60
+	defaultNumQueries      = 3
+)
+
+const (
+	// MetaKeyFusionSources records the names of the sources that returned the document, value: []string
+	MetaKeyFusionSources = "_fusion_sources"
+)
+
+// FusionMode specifies how ranked lists from different sources are merged.
+type FusionMode string
+
+const (
+	// FusionModeRRF uses Reciprocal Rank Fusion: score = sum(weight / (k + rank)).
+	// It only relies on ranks, so scores from different backends need not be comparable.
+	// see: https://plg.uwaterloo.ca/~gvcormac/cormacksigir09-rrf.pdf
+	FusionModeRRF FusionMode = "rrf"
+	// FusionModeWeighted min-max normalizes the scores of each list, then sums them with source weights.
+	FusionModeWeighted FusionMode = "weighted"
+)
+
+func GetType() string {
+	return typ
+}
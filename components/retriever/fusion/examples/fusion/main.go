@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/retriever/fusion"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with real retrievers, e.g. es8.NewRetriever and volc_vikingdb.NewRetriever
+	var (
+		esRetriever     retriever.Retriever = &staticRetriever{docs: []*schema.Document{{ID: "1", Content: "eino is a LLM application framework"}}}
+		vikingRetriever retriever.Retriever = &staticRetriever{docs: []*schema.Document{{ID: "1", Content: "eino is a LLM application framework"}, {ID: "2", Content: "eino-ext contains component implementations"}}}
+	)
+
+	r, err := fusion.NewRetriever(ctx, &fusion.RetrieverConfig{
+		Sources: []*fusion.Source{
+			{Name: "es8", Retriever: esRetriever},
+			{Name: "vikingdb", Retriever: vikingRetriever, Weight: 1.5},
+		},
+		Timeout:             3 * time.Second,
+		AllowPartialFailure: true,
+		FusionMode:          fusion.FusionModeRRF,
+		TopK:                5,
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever failed, err=%v", err)
+	}
+
+	docs, err := r.Retrieve(ctx, "what is eino")
+	if err != nil {
+		log.Fatalf("Retrieve failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		log.Printf("id=%s, score=%v, sources=%v, content=%s", doc.ID, doc.Score(), doc.MetaData[fusion.MetaKeyFusionSources], doc.Content)
+	}
+}
+
+type staticRetriever struct {
+	docs []*schema.Document
+}
+
+func (s *staticRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	return s.docs, nil
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// DedupByID treats documents with the same ID as the same document.
+// Documents without ID fall back to content hash.
+func DedupByID(doc *schema.Document) string {
+	return doc.ID
+}
+
+// DedupByContentHash treats documents with the same content as the same document.
+func DedupByContentHash(doc *schema.Document) string {
+	sum := sha256.Sum256([]byte(doc.Content))
+	return hex.EncodeToString(sum[:])
+}
+
+type rankedList struct {
+	source string
+	weight float64
+	docs   []*schema.Document
+}
+
+type fusedDoc struct {
+	doc     *schema.Document
+	score   float64
+	sources []string
+	order   int
+}
+
+func fuse(lists []*rankedList, mode FusionMode, rankConstant int, dedupKey func(doc *schema.Document) string) []*schema.Document {
+	merged := make(map[string]*fusedDoc)
+	var ordered []*fusedDoc
+
+	for _, list := range lists {
+		scores := listScores(list, mode, rankConstant)
+		seen := make(map[string]struct{}, len(list.docs))
+		for i, doc := range list.docs {
+			if doc == nil {
+				continue
+			}
+			key := dedupKey(doc)
+			if key == "" { // dedup key not available, e.g. document without ID
+				key = DedupByContentHash(doc)
+			}
+			if _, ok := seen[key]; ok { // only the best rank of a document counts within one list
+				continue
+			}
+			seen[key] = struct{}{}
+
+			fd, ok := merged[key]
+			if !ok {
+				fd = &fusedDoc{doc: doc, order: len(ordered)}
+				merged[key] = fd
+				ordered = append(ordered, fd)
+			}
+			fd.score += scores[i]
+			if !containsString(fd.sources, list.source) {
+				fd.sources = append(fd.sources, list.source)
+			}
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].score != ordered[j].score {
+			return ordered[i].score > ordered[j].score
+		}
+		return ordered[i].order < ordered[j].order
+	})
+
+	docs := make([]*schema.Document, 0, len(ordered))
+	for _, fd := range ordered {
+		doc := &schema.Document{
+			ID:       fd.doc.ID,
+			Content:  fd.doc.Content,
+			MetaData: copyMap(fd.doc.MetaData),
+		}
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any)
+		}
+		doc.MetaData[MetaKeyFusionSources] = fd.sources
+		docs = append(docs, doc.WithScore(fd.score))
+	}
+
+	return docs
+}
+
+// listScores calculates the contribution of each document in list to the fused score.
+func listScores(list *rankedList, mode FusionMode, rankConstant int) []float64 {
+	scores := make([]float64, len(list.docs))
+	switch mode {
+	case FusionModeWeighted:
+		var min, max float64
+		first := true
+		for _, doc := range list.docs {
+			if doc == nil {
+				continue
+			}
+			s := doc.Score()
+			if first || s < min {
+				min = s
+			}
+			if first || s > max {
+				max = s
+			}
+			first = false
+		}
+		for i, doc := range list.docs {
+			if doc == nil {
+				continue
+			}
+			normalized := 1.0
+			if max > min {
+				normalized = (doc.Score() - min) / (max - min)
+			}
+			scores[i] = list.weight * normalized
+		}
+	default:
+		for i := range list.docs {
+			scores[i] = list.weight / float64(rankConstant+i+1)
+		}
+	}
+
+	return scores
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// copyMap is a shallow copy, the values are shared with m.
+func copyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]any, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
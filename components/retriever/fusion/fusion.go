@@ -0,0 +1,311 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+var _ retriever.Retriever = (*Retriever)(nil)
+
+// Source is one of the retrievers that the fusion retriever fans out to.
+type Source struct {
+	// Name identifies the source in document metadata and error messages.
+	// Default is "source_<index>".
+	Name string
+	// Retriever is the underlying retriever, required.
+	Retriever retriever.Retriever
+	// Weight scales the contribution of this source to the fused score.
+	// Default is 1.
+	Weight float64
+	// Timeout limits a single retrieve call of this source, overrides RetrieverConfig.Timeout.
+	Timeout time.Duration
+	// ScoreThreshold is passed to the retriever of this source, filters documents by the score of this source.
+	// Optional, the score threshold of Retrieve applies to the fused score and is not passed to sources.
+	ScoreThreshold *float64
+}
+
+type RetrieverConfig struct {
+	// Sources are the retrievers to query in parallel, required.
+	Sources []*Source
+	// Timeout is the default timeout of each source retrieve call.
+	// No timeout if zero.
+	Timeout time.Duration
+	// AllowPartialFailure if true, sources that fail or time out are skipped as long as at least one source succeeds.
+	AllowPartialFailure bool
+
+	// FusionMode specifies how to merge ranked lists, FusionModeRRF by default.
+	FusionMode FusionMode
+	// RRFRankConstant is the k in 1/(k+rank) when using FusionModeRRF.
+	// Default is 60.
+	RRFRankConstant int
+	// DedupKey returns the key used to identify the same document across sources.
+	// Content hash is used when DedupKey returns empty string.
+	// Default is DedupByID.
+	DedupKey func(doc *schema.Document) string
+
+	// TopK number of fused documents to return, zero means no limit.
+	// The TopK option of Retrieve is passed to sources too.
+	TopK int
+	// ScoreThreshold filters fused documents with score lower than it, see Source.ScoreThreshold to filter the documents of a source.
+	// Note that RRF scores are small, the max is sum(weight) / (RRFRankConstant + 1).
+	ScoreThreshold *float64
+
+	// QueryRewriter generates query variants before fan-out, see NewQueryRewriter.
+	// Optional, only the original query is used if not provided.
+	QueryRewriter QueryRewriter
+}
+
+// Retriever fans a query out to multiple retrievers and fuses the results.
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+// NewRetriever creates a fusion retriever.
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil {
+		return nil, errors.New("[NewRetriever] config is nil")
+	}
+	if len(config.Sources) == 0 {
+		return nil, errors.New("[NewRetriever] sources not provided")
+	}
+
+	sources := make([]*Source, 0, len(config.Sources))
+	names := make(map[string]struct{}, len(config.Sources))
+	for i, src := range config.Sources {
+		if src == nil || src.Retriever == nil {
+			return nil, fmt.Errorf("[NewRetriever] retriever of source[%d] not provided", i)
+		}
+		s := *src
+		if s.Name == "" {
+			s.Name = fmt.Sprintf("source_%d", i)
+		}
+		if _, ok := names[s.Name]; ok {
+			return nil, fmt.Errorf("[NewRetriever] duplicate source name: %s", s.Name)
+		}
+		names[s.Name] = struct{}{}
+		if s.Weight == 0 {
+			s.Weight = defaultWeight
+		}
+		if s.Timeout == 0 {
+			s.Timeout = config.Timeout
+		}
+		sources = append(sources, &s)
+	}
+
+	conf := *config
+	conf.Sources = sources
+	if conf.FusionMode == "" {
+		conf.FusionMode = FusionModeRRF
+	}
+	if conf.FusionMode != FusionModeRRF && conf.FusionMode != FusionModeWeighted {
+		return nil, fmt.Errorf("[NewRetriever] unknown fusion mode: %s", conf.FusionMode)
+	}
+	if conf.RRFRankConstant <= 0 {
+		conf.RRFRankConstant = defaultRRFRankConstant
+	}
+	if conf.DedupKey == nil {
+		conf.DedupKey = DedupByID
+	}
+
+	return &Retriever{config: &conf}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+	}, opts...)
+
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           dereferenceOrZero(options.TopK),
+		ScoreThreshold: options.ScoreThreshold,
+	})
+
+	queries := []string{query}
+	if r.config.QueryRewriter != nil {
+		queries, err = r.config.QueryRewriter.Rewrite(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("[FusionRetriever] rewrite query fail: %w", err)
+		}
+		if len(queries) == 0 {
+			queries = []string{query}
+		}
+	}
+
+	lists, err := r.fanOut(ctx, queries, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	docs = fuse(lists, r.config.FusionMode, r.config.RRFRankConstant, r.config.DedupKey)
+	if options.ScoreThreshold != nil {
+		filtered := docs[:0]
+		for _, doc := range docs {
+			if doc.Score() >= *options.ScoreThreshold {
+				filtered = append(filtered, doc)
+			}
+		}
+		docs = filtered
+	}
+	if topK := dereferenceOrZero(options.TopK); topK > 0 && len(docs) > topK {
+		docs = docs[:topK]
+	}
+
+	_ = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+// fanOut runs every (query, source) pair concurrently and collects the ranked lists in a stable order.
+func (r *Retriever) fanOut(ctx context.Context, queries []string, opts ...retriever.Option) ([]*rankedList, error) {
+	type task struct {
+		query  string
+		source *Source
+	}
+
+	opts = withoutScoreThreshold(opts)
+	tasks := make([]task, 0, len(queries)*len(r.config.Sources))
+	for _, q := range queries {
+		for _, s := range r.config.Sources {
+			tasks = append(tasks, task{query: q, source: s})
+		}
+	}
+
+	lists := make([]*rankedList, len(tasks))
+	errs := make([]error, len(tasks))
+	wg := sync.WaitGroup{}
+	for i := range tasks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			t := tasks[i]
+			subCtx := ctx
+			if t.source.Timeout > 0 {
+				var cancel context.CancelFunc
+				subCtx, cancel = context.WithTimeout(ctx, t.source.Timeout)
+				defer cancel()
+			}
+
+			srcOpts := opts
+			if t.source.ScoreThreshold != nil {
+				srcOpts = append(srcOpts[:len(srcOpts):len(srcOpts)], retriever.WithScoreThreshold(*t.source.ScoreThreshold))
+			}
+			docs, err := retrieveWithContext(subCtx, t.source.Retriever, t.query, srcOpts...)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			lists[i] = &rankedList{
+				source: t.source.Name,
+				weight: t.source.Weight,
+				docs:   docs,
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	ret := make([]*rankedList, 0, len(tasks))
+	var firstErr error
+	for i := range tasks {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("[FusionRetriever] retrieve from source[%s] fail, query=%s: %w",
+					tasks[i].source.Name, tasks[i].query, errs[i])
+			}
+			continue
+		}
+		ret = append(ret, lists[i])
+	}
+
+	if firstErr != nil && (!r.config.AllowPartialFailure || len(ret) == 0) {
+		return nil, firstErr
+	}
+
+	return ret, nil
+}
+
+// withoutScoreThreshold drops the options setting the score threshold, which applies to the fused score only.
+func withoutScoreThreshold(opts []retriever.Option) []retriever.Option {
+	ret := make([]retriever.Option, 0, len(opts))
+	for _, opt := range opts {
+		if retriever.GetCommonOptions(nil, opt).ScoreThreshold != nil {
+			continue
+		}
+		ret = append(ret, opt)
+	}
+	return ret
+}
+
+// retrieveWithContext returns as soon as ctx is done, even if the underlying retriever ignores ctx.
+func retrieveWithContext(ctx context.Context, r retriever.Retriever, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	type result struct {
+		docs []*schema.Document
+		err  error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				ch <- result{err: fmt.Errorf("panic: %v", e)}
+			}
+		}()
+		docs, err := r.Retrieve(ctx, query, opts...)
+		ch <- result{docs: docs, err: err}
+	}()
+
+	select {
+	case res := <-ch:
+		return res.docs, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+func dereferenceOrZero[T any](v *T) T {
+	if v == nil {
+		var t T
+		return t
+	}
+
+	return *v
+}
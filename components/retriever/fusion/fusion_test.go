@@ -0,0 +1,231 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRetriever struct {
+	delay time.Duration
+	err   error
+	fn    func(query string) []*schema.Document
+}
+
+func (m *mockRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	if m.delay > 0 {
+		time.Sleep(m.delay)
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	docs := m.fn(query)
+	if threshold := retriever.GetCommonOptions(nil, opts...).ScoreThreshold; threshold != nil {
+		filtered := make([]*schema.Document, 0, len(docs))
+		for _, d := range docs {
+			if d.Score() >= *threshold {
+				filtered = append(filtered, d)
+			}
+		}
+		docs = filtered
+	}
+	return docs, nil
+}
+
+func staticDocs(docs ...*schema.Document) func(string) []*schema.Document {
+	return func(string) []*schema.Document { return docs }
+}
+
+func doc(id, content string, score float64) *schema.Document {
+	return (&schema.Document{ID: id, Content: content}).WithScore(score)
+}
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRetriever(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{})
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Sources: []*Source{{Name: "a"}}})
+	assert.Error(t, err)
+
+	m := &mockRetriever{fn: staticDocs()}
+	_, err = NewRetriever(ctx, &RetrieverConfig{Sources: []*Source{{Name: "a", Retriever: m}, {Name: "a", Retriever: m}}})
+	assert.Error(t, err)
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Sources: []*Source{{Retriever: m}}, FusionMode: "unknown"})
+	assert.Error(t, err)
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{Sources: []*Source{{Retriever: m}, {Retriever: m, Weight: 2}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "source_0", r.config.Sources[0].Name)
+	assert.Equal(t, 1.0, r.config.Sources[0].Weight)
+	assert.Equal(t, 2.0, r.config.Sources[1].Weight)
+	assert.Equal(t, FusionModeRRF, r.config.FusionMode)
+	assert.Equal(t, defaultRRFRankConstant, r.config.RRFRankConstant)
+}
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+
+	es := &mockRetriever{fn: staticDocs(doc("1", "a", 10), doc("2", "b", 8), doc("3", "c", 1))}
+	viking := &mockRetriever{fn: staticDocs(doc("2", "b", 0.9), doc("4", "d", 0.8), doc("1", "a", 0.1))}
+
+	t.Run("rrf", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Sources: []*Source{{Name: "es", Retriever: es}, {Name: "viking", Retriever: viking}},
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "1", "4", "3"}, ids(docs))
+		assert.InDelta(t, 1.0/62+1.0/61, docs[0].Score(), 1e-9)
+		assert.Equal(t, []string{"es", "viking"}, docs[0].MetaData[MetaKeyFusionSources])
+		assert.Equal(t, []string{"viking"}, docs[2].MetaData[MetaKeyFusionSources])
+
+		docs, err = r.Retrieve(ctx, "query", retriever.WithTopK(2))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "1"}, ids(docs))
+	})
+
+	t.Run("score threshold", func(t *testing.T) {
+		threshold := 5.0
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Sources: []*Source{{Name: "es", Retriever: es, ScoreThreshold: &threshold}, {Name: "viking", Retriever: viking}},
+		})
+		assert.NoError(t, err)
+
+		// the threshold of es filters doc 3.
+		docs, err := r.Retrieve(ctx, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "1", "4"}, ids(docs))
+
+		// the threshold of Retrieve applies to the fused score and is not passed to sources.
+		docs, err = r.Retrieve(ctx, "query", retriever.WithScoreThreshold(1.0/61+1.0/63))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "1"}, ids(docs))
+
+		opts := retriever.GetCommonOptions(nil, withoutScoreThreshold([]retriever.Option{retriever.WithTopK(1), retriever.WithScoreThreshold(0.5)})...)
+		assert.Nil(t, opts.ScoreThreshold)
+		assert.Equal(t, 1, *opts.TopK)
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Sources: []*Source{
+				{Name: "es", Retriever: es, Weight: 1},
+				{Name: "viking", Retriever: viking, Weight: 3},
+			},
+			FusionMode: FusionModeWeighted,
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "4", "1", "3"}, ids(docs))
+		assert.InDelta(t, 7.0/9+3, docs[0].Score(), 1e-9)
+	})
+
+	t.Run("dedup by content hash", func(t *testing.T) {
+		a := &mockRetriever{fn: staticDocs(doc("x", "same", 1))}
+		b := &mockRetriever{fn: staticDocs(doc("y", "same", 1))}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Sources:  []*Source{{Retriever: a}, {Retriever: b}},
+			DedupKey: DedupByContentHash,
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "query")
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "x", docs[0].ID)
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		failed := &mockRetriever{err: errors.New("mock err")}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Sources: []*Source{{Name: "es", Retriever: es}, {Name: "failed", Retriever: failed}},
+		})
+		assert.NoError(t, err)
+
+		_, err = r.Retrieve(ctx, "query")
+		assert.ErrorContains(t, err, "failed")
+
+		r.config.AllowPartialFailure = true
+		docs, err := r.Retrieve(ctx, "query")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, ids(docs))
+	})
+
+	t.Run("source timeout", func(t *testing.T) {
+		slow := &mockRetriever{delay: time.Second, fn: staticDocs(doc("5", "e", 1))}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Sources: []*Source{
+				{Name: "es", Retriever: es},
+				{Name: "slow", Retriever: slow, Timeout: 10 * time.Millisecond},
+			},
+			AllowPartialFailure: true,
+		})
+		assert.NoError(t, err)
+
+		start := time.Now()
+		docs, err := r.Retrieve(ctx, "query")
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+		assert.Equal(t, []string{"1", "2", "3"}, ids(docs))
+	})
+
+	t.Run("with query rewriter", func(t *testing.T) {
+		byQuery := &mockRetriever{fn: func(query string) []*schema.Document {
+			return []*schema.Document{doc(query, query, 1)}
+		}}
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Sources:       []*Source{{Retriever: byQuery}},
+			QueryRewriter: rewriterFunc(func(ctx context.Context, query string) ([]string, error) { return []string{query, "q2"}, nil }),
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "q1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"q1", "q2"}, ids(docs))
+	})
+}
+
+type rewriterFunc func(ctx context.Context, query string) ([]string, error)
+
+func (f rewriterFunc) Rewrite(ctx context.Context, query string) ([]string, error) {
+	return f(ctx, query)
+}
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, 0, len(docs))
+	for _, d := range docs {
+		ret = append(ret, d.ID)
+	}
+	return ret
+}
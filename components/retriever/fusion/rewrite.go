@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// QueryRewriter generates the queries to fan out from the original query.
+type QueryRewriter interface {
+	Rewrite(ctx context.Context, query string) ([]string, error)
+}
+
+// RewriteMode specifies how a ChatModel is used to rewrite the query.
+type RewriteMode string
+
+const (
+	// RewriteModeMultiQuery asks the model for several paraphrases of the query.
+	RewriteModeMultiQuery RewriteMode = "multi_query"
+	// RewriteModeHyDE asks the model for a hypothetical answer passage, which is used as query.
+	// see: https://arxiv.org/abs/2212.10496
+	RewriteModeHyDE RewriteMode = "hyde"
+)
+
+const (
+	defaultMultiQueryPrompt = `You are an AI language model assistant. Your task is to generate %d different versions of the given user question to retrieve relevant documents from a vector database. By generating multiple perspectives on the user question, your goal is to help the user overcome some of the limitations of distance-based similarity search. Provide these alternative questions separated by newlines, without numbering or any other text.`
+	defaultHyDEPrompt       = `Please write a short passage that answers the user question. Output the passage only.`
+)
+
+type QueryRewriterConfig struct {
+	// ChatModel generates the query variants, required.
+	ChatModel model.ChatModel
+	// Mode is RewriteModeMultiQuery by default.
+	Mode RewriteMode
+	// NumQueries is the number of variants to generate.
+	// For RewriteModeMultiQuery it is the number of paraphrases, for RewriteModeHyDE the number of passages.
+	// Default is 3 for RewriteModeMultiQuery and 1 for RewriteModeHyDE.
+	NumQueries int
+	// SystemPrompt overrides the default system prompt.
+	// With RewriteModeMultiQuery, every "%d" in prompt is replaced by NumQueries, other text is kept as is.
+	SystemPrompt string
+	// ExcludeOriginal if true, the original query is not sent to retrievers.
+	ExcludeOriginal bool
+}
+
+// NewQueryRewriter creates a QueryRewriter that generates multiple queries (multi-query) or hypothetical documents (HyDE) with ChatModel.
+func NewQueryRewriter(_ context.Context, config *QueryRewriterConfig) (QueryRewriter, error) {
+	if config == nil || config.ChatModel == nil {
+		return nil, errors.New("[NewQueryRewriter] chat model not provided")
+	}
+
+	mode := config.Mode
+	if mode == "" {
+		mode = RewriteModeMultiQuery
+	}
+
+	n := config.NumQueries
+	prompt := config.SystemPrompt
+	switch mode {
+	case RewriteModeMultiQuery:
+		if n <= 0 {
+			n = defaultNumQueries
+		}
+		if prompt == "" {
+			prompt = defaultMultiQueryPrompt
+		}
+		prompt = strings.ReplaceAll(prompt, "%d", strconv.Itoa(n))
+	case RewriteModeHyDE:
+		if n <= 0 {
+			n = 1
+		}
+		if prompt == "" {
+			prompt = defaultHyDEPrompt
+		}
+	default:
+		return nil, fmt.Errorf("[NewQueryRewriter] unknown rewrite mode: %s", mode)
+	}
+
+	return &chatModelRewriter{
+		cm:              config.ChatModel,
+		mode:            mode,
+		n:               n,
+		prompt:          prompt,
+		excludeOriginal: config.ExcludeOriginal,
+	}, nil
+}
+
+type chatModelRewriter struct {
+	cm              model.ChatModel
+	mode            RewriteMode
+	n               int
+	prompt          string
+	excludeOriginal bool
+}
+
+func (c *chatModelRewriter) Rewrite(ctx context.Context, query string) ([]string, error) {
+	var queries []string
+	if !c.excludeOriginal {
+		queries = append(queries, query)
+	}
+
+	input := []*schema.Message{
+		schema.SystemMessage(c.prompt),
+		schema.UserMessage(query),
+	}
+
+	switch c.mode {
+	case RewriteModeHyDE:
+		for i := 0; i < c.n; i++ {
+			msg, err := c.cm.Generate(ctx, input)
+			if err != nil {
+				return nil, fmt.Errorf("generate hypothetical document fail: %w", err)
+			}
+			if passage := strings.TrimSpace(msg.Content); passage != "" {
+				queries = appendUnique(queries, passage)
+			}
+		}
+	default:
+		msg, err := c.cm.Generate(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("generate queries fail: %w", err)
+		}
+		generated := 0
+		for _, line := range strings.Split(msg.Content, "\n") {
+			if generated >= c.n {
+				break
+			}
+			if q := cleanQueryLine(line); q != "" {
+				before := len(queries)
+				queries = appendUnique(queries, q)
+				generated += len(queries) - before
+			}
+		}
+	}
+
+	return queries, nil
+}
+
+// cleanQueryLine removes list markers like "1.", "2)", "-" the model may add in front of a query.
+func cleanQueryLine(line string) string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimLeft(line, "-*• ")
+	i := 0
+	for i < len(line) && line[i] >= '0' && line[i] <= '9' {
+		i++
+	}
+	if i > 0 && i < len(line) && (line[i] == '.' || line[i] == ')') {
+		line = line[i+1:]
+	}
+	return strings.TrimSpace(line)
+}
+
+func appendUnique(ss []string, s string) []string {
+	if containsString(ss, s) {
+		return ss
+	}
+	return append(ss, s)
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChatModel struct {
+	content string
+	err     error
+	inputs  [][]*schema.Message
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.inputs = append(m.inputs, input)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return schema.AssistantMessage(m.content, nil), nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockChatModel) BindTools(tools []*schema.ToolInfo) error {
+	return nil
+}
+
+func TestQueryRewriter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewQueryRewriter(ctx, nil)
+		assert.Error(t, err)
+
+		_, err = NewQueryRewriter(ctx, &QueryRewriterConfig{ChatModel: &mockChatModel{}, Mode: "unknown"})
+		assert.Error(t, err)
+	})
+
+	t.Run("multi query", func(t *testing.T) {
+		cm := &mockChatModel{content: "1. what is eino\n2) eino framework\n\n- what is eino\n* eino usage"}
+		rw, err := NewQueryRewriter(ctx, &QueryRewriterConfig{ChatModel: cm, NumQueries: 2})
+		assert.NoError(t, err)
+
+		queries, err := rw.Rewrite(ctx, "eino?")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"eino?", "what is eino", "eino framework"}, queries)
+		assert.Contains(t, cm.inputs[0][0].Content, "generate 2 different versions")
+		assert.Equal(t, "eino?", cm.inputs[0][1].Content)
+	})
+
+	t.Run("custom prompt", func(t *testing.T) {
+		cm := &mockChatModel{content: "what is eino"}
+		rw, err := NewQueryRewriter(ctx, &QueryRewriterConfig{ChatModel: cm, SystemPrompt: "write %d queries, keep 100% of the meaning"})
+		assert.NoError(t, err)
+
+		_, err = rw.Rewrite(ctx, "eino?")
+		assert.NoError(t, err)
+		assert.Equal(t, "write 3 queries, keep 100% of the meaning", cm.inputs[0][0].Content)
+	})
+
+	t.Run("hyde", func(t *testing.T) {
+		cm := &mockChatModel{content: " eino is a framework. "}
+		rw, err := NewQueryRewriter(ctx, &QueryRewriterConfig{ChatModel: cm, Mode: RewriteModeHyDE, ExcludeOriginal: true})
+		assert.NoError(t, err)
+
+		queries, err := rw.Rewrite(ctx, "eino?")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"eino is a framework."}, queries)
+	})
+
+	t.Run("generate error", func(t *testing.T) {
+		rw, err := NewQueryRewriter(ctx, &QueryRewriterConfig{ChatModel: &mockChatModel{err: errors.New("mock err")}})
+		assert.NoError(t, err)
+
+		_, err = rw.Rewrite(ctx, "eino?")
+		assert.Error(t, err)
+	})
+}
+
+func TestCleanQueryLine(t *testing.T) {
+	assert.Equal(t, "query", cleanQueryLine("  1. query "))
+	assert.Equal(t, "query", cleanQueryLine("12) query"))
+	assert.Equal(t, "query", cleanQueryLine("- query"))
+	assert.Equal(t, "2025 plan", cleanQueryLine("2025 plan"))
+}
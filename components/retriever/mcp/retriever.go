@@ -0,0 +1,396 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	MetaKeyName        = "_name"
+	MetaKeyDescription = "_description"
+	MetaKeyMIMEType    = "_mime_type"
+	// MetaKeyURITemplate is the uri template which the resource uri matches or is expanded from, value: string
+	MetaKeyURITemplate = "_uri_template"
+	// MetaKeyParams is the values of uri template variables, value: map[string]string
+	MetaKeyParams = "_params"
+)
+
+// methodResourceListChanged is the notification sent by MCP server when its resource list changes.
+const methodResourceListChanged = "notifications/resources/list_changed"
+
+type RetrieverConfig struct {
+	// Cli is the MCP (Model Control Protocol) client, ref: https://github.com/mark3labs/mcp-go
+	// Notice: should Initialize with server before use
+	// Required
+	Cli client.MCPClient
+	// URITemplates are RFC 6570 uri templates in addition to the resource templates listed from server,
+	// used to extract parameters from resource uris, e.g. "file:///logs/{date}/{service}.log".
+	// Simple "{var}" and reserved "{+var}" expressions are supported.
+	URITemplates []string
+	// ReadContent if true, reads each retrieved resource by resources/read, and joins its text contents as the
+	// document content. Otherwise, the content is the name and description of the resource.
+	ReadContent bool
+	// TopK number of documents to return, zero means no limit.
+	TopK int
+	// CacheListing if true, caches the listed resources and resource templates until the server sends
+	// notifications/resources/list_changed. Since a notification handler can't be removed from client.MCPClient,
+	// create one such retriever per client.
+	CacheListing bool
+}
+
+// Retriever retrieves MCP resources whose name, description or uri contains the query terms,
+// ranked by the fraction of matched terms. An empty query matches all resources.
+//
+// With WithParams, only resources whose uri matches a uri template with the given parameter values are retrieved,
+// and resource templates whose variables are all given are expanded into resource uris.
+type Retriever struct {
+	config    *RetrieverConfig
+	templates []*uriTemplate
+
+	mu      sync.Mutex
+	listing *listing
+	// generation increases on every invalidation, so that a listing fetched before it is not cached
+	generation int
+}
+
+type listing struct {
+	resources []mcp.Resource
+	templates []mcp.ResourceTemplate
+}
+
+// NewRetriever creates a MCP resource retriever.
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil || config.Cli == nil {
+		return nil, errors.New("[NewRetriever] mcp client not provided")
+	}
+
+	conf := *config
+	r := &Retriever{config: &conf}
+	for _, raw := range conf.URITemplates {
+		t, err := parseURITemplate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("[NewRetriever] %w", err)
+		}
+		r.templates = append(r.templates, t)
+	}
+
+	if conf.CacheListing {
+		conf.Cli.OnNotification(func(notification mcp.JSONRPCNotification) {
+			if notification.Method != methodResourceListChanged {
+				return
+			}
+			r.mu.Lock()
+			r.listing = nil
+			r.generation++
+			r.mu.Unlock()
+		})
+	}
+
+	return r, nil
+}
+
+type candidate struct {
+	uri         string
+	name        string
+	description string
+	mimeType    string
+	template    string
+	params      map[string]string
+	score       float64
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := retriever.GetCommonOptions(&retriever.Options{TopK: &r.config.TopK}, opts...)
+	implOptions := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	topK := 0
+	if options.TopK != nil {
+		topK = *options.TopK
+	}
+
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query: query,
+		TopK:  topK,
+	})
+
+	l, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := append([]*uriTemplate{}, r.templates...)
+	serverTemplates := make(map[*uriTemplate]mcp.ResourceTemplate, len(l.templates))
+	for _, rt := range l.templates {
+		t, parseErr := parseURITemplate(rt.URITemplate)
+		if parseErr != nil {
+			continue
+		}
+		templates = append(templates, t)
+		serverTemplates[t] = rt
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	params := implOptions.params
+	var candidates []*candidate
+	seen := make(map[string]bool)
+
+	for _, res := range l.resources {
+		c := &candidate{
+			uri:         res.URI,
+			name:        res.Name,
+			description: res.Description,
+			mimeType:    res.MIMEType,
+		}
+		if !matchTemplates(c, templates, params) {
+			continue
+		}
+		if c.score = matchScore(terms, c.name, c.description, c.uri); c.score == 0 {
+			continue
+		}
+		seen[c.uri] = true
+		candidates = append(candidates, c)
+	}
+
+	if len(params) > 0 {
+		for t, rt := range serverTemplates {
+			uri, ok := t.expand(params)
+			if !ok || seen[uri] {
+				continue
+			}
+			c := &candidate{
+				uri:         uri,
+				name:        rt.Name,
+				description: rt.Description,
+				mimeType:    rt.MIMEType,
+				template:    t.raw,
+				params:      subset(params, t.vars),
+			}
+			if c.score = matchScore(terms, c.name, c.description, c.uri); c.score == 0 {
+				continue
+			}
+			seen[uri] = true
+			candidates = append(candidates, c)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].uri < candidates[j].uri
+	})
+	if topK > 0 && len(candidates) > topK {
+		candidates = candidates[:topK]
+	}
+
+	docs = make([]*schema.Document, 0, len(candidates))
+	for _, c := range candidates {
+		doc, convErr := r.toDocument(ctx, c)
+		if convErr != nil {
+			return nil, convErr
+		}
+		docs = append(docs, doc)
+	}
+
+	_ = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+// matchTemplates records the first template matching the uri of c, and reports whether the parameters extracted
+// are consistent with params. Without params, resources not matching any template are kept.
+func matchTemplates(c *candidate, templates []*uriTemplate, params map[string]string) bool {
+	for _, t := range templates {
+		extracted, ok := t.match(c.uri)
+		if !ok {
+			continue
+		}
+		consistent := true
+		for k, v := range params {
+			if extracted[k] != v {
+				consistent = false
+				break
+			}
+		}
+		if !consistent {
+			continue
+		}
+		c.template, c.params = t.raw, extracted
+		return true
+	}
+	return len(params) == 0
+}
+
+// matchScore returns the fraction of terms contained in any of texts, 1 if there is no term.
+func matchScore(terms []string, texts ...string) float64 {
+	if len(terms) == 0 {
+		return 1
+	}
+	text := strings.ToLower(strings.Join(texts, "\n"))
+	matched := 0
+	for _, term := range terms {
+		if strings.Contains(text, term) {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(terms))
+}
+
+func subset(params map[string]string, keys []string) map[string]string {
+	ret := make(map[string]string, len(keys))
+	for _, k := range keys {
+		ret[k] = params[k]
+	}
+	return ret
+}
+
+func (r *Retriever) toDocument(ctx context.Context, c *candidate) (*schema.Document, error) {
+	doc := &schema.Document{
+		ID:       c.uri,
+		MetaData: map[string]any{MetaKeyName: c.name},
+	}
+	if c.description != "" {
+		doc.MetaData[MetaKeyDescription] = c.description
+	}
+	if c.mimeType != "" {
+		doc.MetaData[MetaKeyMIMEType] = c.mimeType
+	}
+	if c.template != "" {
+		doc.MetaData[MetaKeyURITemplate] = c.template
+		doc.MetaData[MetaKeyParams] = c.params
+	}
+	doc.WithScore(c.score)
+
+	if r.config.ReadContent {
+		req := mcp.ReadResourceRequest{}
+		req.Params.URI = c.uri
+		result, err := r.config.Cli.ReadResource(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("[MCPRetriever] read mcp resource fail: %w, uri: %s", err, c.uri)
+		}
+		var texts []string
+		for _, content := range result.Contents {
+			if text, ok := content.(mcp.TextResourceContents); ok {
+				texts = append(texts, text.Text)
+			}
+		}
+		doc.Content = strings.Join(texts, "\n")
+	}
+	if doc.Content == "" {
+		doc.Content = strings.TrimSpace(c.name + "\n" + c.description)
+	}
+
+	return doc, nil
+}
+
+// list lists all resources and resource templates, following the pagination cursors.
+func (r *Retriever) list(ctx context.Context) (*listing, error) {
+	r.mu.Lock()
+	cached, generation := r.listing, r.generation
+	r.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	l := &listing{}
+	var cursor mcp.Cursor
+	for {
+		req := mcp.ListResourcesRequest{}
+		req.Params.Cursor = cursor
+		result, err := r.config.Cli.ListResources(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("[MCPRetriever] list mcp resources fail: %w", err)
+		}
+		l.resources = append(l.resources, result.Resources...)
+		if result.NextCursor == "" || result.NextCursor == cursor {
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	cursor = ""
+	for {
+		req := mcp.ListResourceTemplatesRequest{}
+		req.Params.Cursor = cursor
+		result, err := r.config.Cli.ListResourceTemplates(ctx, req)
+		if err != nil && cursor == "" && isMethodNotFound(err) {
+			// resource templates are optional, servers without them may not implement the method
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("[MCPRetriever] list mcp resource templates fail: %w", err)
+		}
+		l.templates = append(l.templates, result.ResourceTemplates...)
+		if result.NextCursor == "" || result.NextCursor == cursor {
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	if r.config.CacheListing {
+		r.mu.Lock()
+		if r.generation == generation {
+			r.listing = l
+		}
+		r.mu.Unlock()
+	}
+	return l, nil
+}
+
+// isMethodNotFound reports whether err is the JSON-RPC "Method not found" error (code -32601).
+// The client of mcp-go only keeps the message of error responses, so the message is checked.
+func isMethodNotFound(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "method not found") || strings.Contains(msg, "-32601")
+}
+
+func (r *Retriever) GetType() string {
+	return "MCPRetriever"
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
+
+type implOptions struct {
+	params map[string]string
+}
+
+// WithParams retrieves only the resources matching uri templates with the given parameter values,
+// and expands the resource templates whose variables are all given.
+func WithParams(params map[string]string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.params = params
+	})
+}
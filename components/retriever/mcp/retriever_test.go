@@ -0,0 +1,244 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURITemplate(t *testing.T) {
+	tpl, err := parseURITemplate("file:///logs/{date}/{service}.log")
+	assert.NoError(t, err)
+	params, ok := tpl.match("file:///logs/2025-01-01/api%20gw.log")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"date": "2025-01-01", "service": "api gw"}, params)
+	_, ok = tpl.match("file:///logs/2025-01-01/a/b.log")
+	assert.False(t, ok)
+
+	uri, ok := tpl.expand(map[string]string{"date": "2025-01-02", "service": "a/b"})
+	assert.True(t, ok)
+	assert.Equal(t, "file:///logs/2025-01-02/a%2Fb.log", uri)
+	_, ok = tpl.expand(map[string]string{"date": "2025-01-02"})
+	assert.False(t, ok)
+
+	tpl, err = parseURITemplate("repo://{+path}")
+	assert.NoError(t, err)
+	uri, _ = tpl.expand(map[string]string{"path": "a/b c"})
+	assert.Equal(t, "repo://a/b%20c", uri)
+	params, ok = tpl.match("repo://a/b%20c")
+	assert.True(t, ok)
+	assert.Equal(t, "a/b c", params["path"])
+
+	_, err = parseURITemplate("file:///{date")
+	assert.Error(t, err)
+	_, err = parseURITemplate("file:///{?query}")
+	assert.Error(t, err)
+}
+
+func TestRetriever(t *testing.T) {
+	ctx := context.Background()
+	cli := &mockMCPClient{}
+
+	_, err := NewRetriever(ctx, nil)
+	assert.Error(t, err)
+	_, err = NewRetriever(ctx, &RetrieverConfig{Cli: cli, URITemplates: []string{"{"}})
+	assert.Error(t, err)
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Cli:          cli,
+		URITemplates: []string{"file:///logs/{date}/{service}.log"},
+	})
+	assert.NoError(t, err)
+
+	t.Run("query", func(t *testing.T) {
+		docs, err := r.Retrieve(ctx, "api logs")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"file:///logs/2025-01-01/api.log", "file:///logs/2025-01-02/api.log", "file:///logs/2025-01-01/web.log"}, ids(docs))
+		assert.Equal(t, 1.0, docs[0].Score())
+		assert.Equal(t, 0.5, docs[2].Score())
+		assert.Equal(t, "api log\nlogs of api", docs[0].Content)
+		assert.Equal(t, map[string]string{"date": "2025-01-01", "service": "api"}, docs[0].MetaData[MetaKeyParams])
+		assert.Equal(t, "file:///logs/{date}/{service}.log", docs[0].MetaData[MetaKeyURITemplate])
+
+		docs, err = r.Retrieve(ctx, "", retriever.WithTopK(2))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+	})
+
+	t.Run("params", func(t *testing.T) {
+		docs, err := r.Retrieve(ctx, "", WithParams(map[string]string{"date": "2025-01-01"}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"file:///logs/2025-01-01/api.log", "file:///logs/2025-01-01/web.log"}, ids(docs))
+
+		docs, err = r.Retrieve(ctx, "", WithParams(map[string]string{"city": "Paris"}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"weather://Paris/current"}, ids(docs))
+		assert.Equal(t, "weather://{city}/current", docs[0].MetaData[MetaKeyURITemplate])
+		assert.Equal(t, map[string]string{"city": "Paris"}, docs[0].MetaData[MetaKeyParams])
+	})
+
+	t.Run("read content", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{Cli: cli, ReadContent: true})
+		assert.NoError(t, err)
+		docs, err := r.Retrieve(ctx, "weather", WithParams(map[string]string{"city": "Paris"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "sunny in Paris", docs[0].Content)
+	})
+
+	t.Run("cache listing", func(t *testing.T) {
+		cli := &mockMCPClient{}
+		r, err := NewRetriever(ctx, &RetrieverConfig{Cli: cli, CacheListing: true})
+		assert.NoError(t, err)
+		_, err = r.Retrieve(ctx, "")
+		assert.NoError(t, err)
+		_, err = r.Retrieve(ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, cli.lists)
+
+		notification := mcp.JSONRPCNotification{}
+		notification.Method = methodResourceListChanged
+		cli.handler(notification)
+		_, err = r.Retrieve(ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, cli.lists)
+	})
+
+	t.Run("templates not supported", func(t *testing.T) {
+		cli := &mockMCPClient{templatesErr: errors.New("Method not found")}
+		r, err := NewRetriever(ctx, &RetrieverConfig{Cli: cli})
+		assert.NoError(t, err)
+		docs, err := r.Retrieve(ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(docs))
+
+		cli = &mockMCPClient{templatesErr: errors.New("internal error")}
+		r, err = NewRetriever(ctx, &RetrieverConfig{Cli: cli})
+		assert.NoError(t, err)
+		_, err = r.Retrieve(ctx, "")
+		assert.ErrorContains(t, err, "internal error")
+	})
+}
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.ID
+	}
+	return ret
+}
+
+type mockMCPClient struct {
+	mu           sync.Mutex
+	lists        int
+	templatesErr error
+	handler      func(notification mcp.JSONRPCNotification)
+}
+
+func (m *mockMCPClient) Initialize(ctx context.Context, request mcp.InitializeRequest) (*mcp.InitializeResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) Ping(ctx context.Context) error {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) ListResources(ctx context.Context, request mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
+	if request.Params.Cursor == "" {
+		m.mu.Lock()
+		m.lists++
+		m.mu.Unlock()
+
+		result := &mcp.ListResourcesResult{Resources: []mcp.Resource{
+			{URI: "file:///logs/2025-01-02/api.log", Name: "api log", Description: "logs of api"},
+			{URI: "file:///logs/2025-01-01/api.log", Name: "api log", Description: "logs of api"},
+		}}
+		result.NextCursor = "2"
+		return result, nil
+	}
+	return &mcp.ListResourcesResult{Resources: []mcp.Resource{
+		{URI: "file:///logs/2025-01-01/web.log", Name: "web log", MIMEType: "text/plain"},
+		{URI: "file:///readme.md", Name: "readme"},
+	}}, nil
+}
+
+func (m *mockMCPClient) ListResourceTemplates(ctx context.Context, request mcp.ListResourceTemplatesRequest) (*mcp.ListResourceTemplatesResult, error) {
+	if m.templatesErr != nil {
+		return nil, m.templatesErr
+	}
+	return &mcp.ListResourceTemplatesResult{ResourceTemplates: []mcp.ResourceTemplate{
+		{URITemplate: "weather://{city}/current", Name: "current weather", Description: "weather of a city"},
+		{URITemplate: "broken://{", Name: "broken"},
+	}}, nil
+}
+
+func (m *mockMCPClient) ReadResource(ctx context.Context, request mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	if request.Params.URI == "weather://Paris/current" {
+		return &mcp.ReadResourceResult{Contents: []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: request.Params.URI, Text: "sunny in Paris"},
+		}}, nil
+	}
+	return nil, errors.New("resource not found")
+}
+
+func (m *mockMCPClient) Subscribe(ctx context.Context, request mcp.SubscribeRequest) error {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) Unsubscribe(ctx context.Context, request mcp.UnsubscribeRequest) error {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) ListPrompts(ctx context.Context, request mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) GetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) ListTools(ctx context.Context, request mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) CallTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) SetLevel(ctx context.Context, request mcp.SetLevelRequest) error {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) Complete(ctx context.Context, request mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) Close() error {
+	panic("implement me")
+}
+
+func (m *mockMCPClient) OnNotification(handler func(notification mcp.JSONRPCNotification)) {
+	m.handler = handler
+}
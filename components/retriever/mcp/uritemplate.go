@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// uriTemplate supports simple string expansion "{var}" and reserved expansion "{+var}" of RFC 6570.
+type uriTemplate struct {
+	raw   string
+	parts []templatePart
+	vars  []string
+	re    *regexp.Regexp
+}
+
+type templatePart struct {
+	literal  string
+	name     string
+	reserved bool
+}
+
+func parseURITemplate(raw string) (*uriTemplate, error) {
+	t := &uriTemplate{raw: raw}
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+
+	rest := raw
+	for rest != "" {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			t.parts = append(t.parts, templatePart{literal: rest})
+			pattern.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed expression in uri template: %s", raw)
+		}
+		end += start
+
+		if start > 0 {
+			t.parts = append(t.parts, templatePart{literal: rest[:start]})
+			pattern.WriteString(regexp.QuoteMeta(rest[:start]))
+		}
+
+		name, reserved := rest[start+1:end], false
+		if strings.HasPrefix(name, "+") {
+			name, reserved = name[1:], true
+		}
+		if !isVarName(name) {
+			return nil, fmt.Errorf("unsupported expression {%s} in uri template: %s", rest[start+1:end], raw)
+		}
+		t.parts = append(t.parts, templatePart{name: name, reserved: reserved})
+		t.vars = append(t.vars, name)
+		if reserved {
+			pattern.WriteString("(.+?)")
+		} else {
+			pattern.WriteString("([^/?#]+?)")
+		}
+
+		rest = rest[end+1:]
+	}
+	pattern.WriteString("$")
+
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, fmt.Errorf("compile uri template fail: %w, template: %s", err, raw)
+	}
+	t.re = re
+	return t, nil
+}
+
+func isVarName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// expand builds the uri with params, ok is false if any variable is missing.
+func (t *uriTemplate) expand(params map[string]string) (string, bool) {
+	sb := strings.Builder{}
+	for _, p := range t.parts {
+		if p.name == "" {
+			sb.WriteString(p.literal)
+			continue
+		}
+		v, ok := params[p.name]
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(escape(v, p.reserved))
+	}
+	return sb.String(), true
+}
+
+// match extracts the values of variables from uri, ok is false if uri doesn't match the template.
+func (t *uriTemplate) match(uri string) (map[string]string, bool) {
+	sub := t.re.FindStringSubmatch(uri)
+	if sub == nil {
+		return nil, false
+	}
+	params := make(map[string]string, len(t.vars))
+	for i, name := range t.vars {
+		v, err := url.PathUnescape(sub[i+1])
+		if err != nil {
+			v = sub[i+1]
+		}
+		if prev, ok := params[name]; ok && prev != v {
+			return nil, false
+		}
+		params[name] = v
+	}
+	return params, true
+}
+
+const reservedChars = ":/?#[]@!$&'()*+,;=%"
+
+// escape percent-encodes s, keeping unreserved characters, and reserved characters if reserved is true.
+func escape(s string, reserved bool) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-._~", c) >= 0 ||
+			reserved && strings.IndexByte(reservedChars, c) >= 0 {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%%%02X", c))
+	}
+	return sb.String()
+}
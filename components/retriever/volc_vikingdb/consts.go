@@ -19,8 +19,9 @@ package volc_vikingdb
 const typ = "VikingDB"
 
 const (
-	ExtraKeyVikingDBFields = "_vikingdb_fields" // value: map[string]interface{}
-	ExtraKeyVikingDBTTL    = "_vikingdb_ttl"    // value: int64
+	ExtraKeyVikingDBFields        = "_vikingdb_fields"         // value: map[string]interface{}
+	ExtraKeyVikingDBTTL           = "_vikingdb_ttl"            // value: int64
+	ExtraKeyVikingDBOriginalScore = "_vikingdb_original_score" // value: float64, score before rerank
 )
 
 const (
@@ -33,3 +34,25 @@ const (
 	vikingEmbeddingRespSentenceDense  = "sentence_dense_embedding"
 	vikingEmbeddingRespSentenceSparse = "sentence_sparse_embedding"
 )
+
+const (
+	filterKeyOp    = "op"
+	filterKeyField = "field"
+	filterKeyConds = "conds"
+	filterKeyGt    = "gt"
+	filterKeyGte   = "gte"
+	filterKeyLt    = "lt"
+	filterKeyLte   = "lte"
+
+	filterOpMust    = "must"
+	filterOpMustNot = "must_not"
+	filterOpRange   = "range"
+	filterOpAnd     = "and"
+	filterOpOr      = "or"
+)
+
+const (
+	rerankKeyQuery   = "query"
+	rerankKeyContent = "content"
+	rerankKeyTitle   = "title"
+)
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+// Filter is a VikingDB scalar filter expression, which can be used as RetrieverConfig.FilterDSL
+// or passed by retriever.WithDSLInfo.
+// see: https://www.volcengine.com/docs/84313/1254609
+type Filter map[string]any
+
+// RangeBounds bounds of a range filter, nil bound is ignored.
+type RangeBounds struct {
+	Gt  any
+	Gte any
+	Lt  any
+	Lte any
+}
+
+// Must matches records whose field value is in conds.
+func Must(field string, conds ...any) Filter {
+	return Filter{
+		filterKeyOp:    filterOpMust,
+		filterKeyField: field,
+		filterKeyConds: conds,
+	}
+}
+
+// MustNot matches records whose field value is not in conds.
+func MustNot(field string, conds ...any) Filter {
+	return Filter{
+		filterKeyOp:    filterOpMustNot,
+		filterKeyField: field,
+		filterKeyConds: conds,
+	}
+}
+
+// Range matches records whose field value is within bounds.
+func Range(field string, bounds RangeBounds) Filter {
+	f := Filter{
+		filterKeyOp:    filterOpRange,
+		filterKeyField: field,
+	}
+
+	if bounds.Gt != nil {
+		f[filterKeyGt] = bounds.Gt
+	}
+	if bounds.Gte != nil {
+		f[filterKeyGte] = bounds.Gte
+	}
+	if bounds.Lt != nil {
+		f[filterKeyLt] = bounds.Lt
+	}
+	if bounds.Lte != nil {
+		f[filterKeyLte] = bounds.Lte
+	}
+
+	return f
+}
+
+// And matches records matched by all filters.
+func And(filters ...Filter) Filter {
+	return logicFilter(filterOpAnd, filters)
+}
+
+// Or matches records matched by any of filters.
+func Or(filters ...Filter) Filter {
+	return logicFilter(filterOpOr, filters)
+}
+
+func logicFilter(op string, filters []Filter) Filter {
+	conds := make([]any, 0, len(filters))
+	for _, f := range filters {
+		if len(f) == 0 {
+			continue
+		}
+		conds = append(conds, map[string]any(f))
+	}
+
+	return Filter{
+		filterKeyOp:    op,
+		filterKeyConds: conds,
+	}
+}
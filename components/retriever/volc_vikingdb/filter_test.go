@@ -0,0 +1,67 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilter(t *testing.T) {
+	PatchConvey("test filter builder", t, func() {
+		PatchConvey("test must and must_not", func() {
+			convey.So(Must("region", "cn", "us"), convey.ShouldResemble, Filter{
+				"op":    "must",
+				"field": "region",
+				"conds": []any{"cn", "us"},
+			})
+			convey.So(MustNot("doc_id", 1), convey.ShouldResemble, Filter{
+				"op":    "must_not",
+				"field": "doc_id",
+				"conds": []any{1},
+			})
+		})
+
+		PatchConvey("test range", func() {
+			convey.So(Range("price", RangeBounds{Gte: 10, Lt: 100}), convey.ShouldResemble, Filter{
+				"op":    "range",
+				"field": "price",
+				"gte":   10,
+				"lt":    100,
+			})
+		})
+
+		PatchConvey("test logic", func() {
+			f := And(Must("region", "cn"), Or(Range("price", RangeBounds{Gt: 1}), MustNot("tag", "x")), nil)
+			convey.So(f, convey.ShouldResemble, Filter{
+				"op": "and",
+				"conds": []any{
+					map[string]any{"op": "must", "field": "region", "conds": []any{"cn"}},
+					map[string]any{"op": "or", "conds": []any{
+						map[string]any{"op": "range", "field": "price", "gt": 1},
+						map[string]any{"op": "must_not", "field": "tag", "conds": []any{"x"}},
+					}},
+				},
+			})
+
+			var dsl map[string]any = f
+			convey.So(dsl["op"], convey.ShouldEqual, "and")
+		})
+	})
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// rerank rescores docs by VikingDB built-in rerank, docs are sorted by rerank score desc.
+// Original score is kept in metadata with key ExtraKeyVikingDBOriginalScore.
+func (r *Retriever) rerank(query string, docs []*schema.Document) ([]*schema.Document, error) {
+	datas := make([]map[string]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		data := map[string]interface{}{
+			rerankKeyQuery:   query,
+			rerankKeyContent: doc.Content,
+		}
+
+		if title := r.config.Rerank.TitleField; title != "" {
+			if fields, ok := doc.MetaData[ExtraKeyVikingDBFields].(map[string]interface{}); ok {
+				if val, ok := fields[title].(string); ok {
+					data[rerankKeyTitle] = val
+				}
+			}
+		}
+
+		datas = append(datas, data)
+	}
+
+	scores, err := r.service.BatchRerank(datas)
+	if err != nil {
+		return nil, fmt.Errorf("[rerank] batch rerank failed, %w", err)
+	}
+
+	return applyRerankScores(docs, scores, r.config.Rerank.TopN)
+}
+
+func applyRerankScores(docs []*schema.Document, scores []float64, topN int) ([]*schema.Document, error) {
+	if len(scores) != len(docs) {
+		return nil, fmt.Errorf("[rerank] invalid length of scores, got=%d, expected=%d", len(scores), len(docs))
+	}
+
+	for i, doc := range docs {
+		if doc.MetaData == nil {
+			doc.MetaData = map[string]any{}
+		}
+		doc.MetaData[ExtraKeyVikingDBOriginalScore] = doc.Score()
+		doc.WithScore(scores[i])
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		return docs[i].Score() > docs[j].Score()
+	})
+
+	if topN > 0 && len(docs) > topN {
+		docs = docs[:topN]
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volc_vikingdb
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/volcengine/volc-sdk-golang/service/vikingdb"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestRerank(t *testing.T) {
+	PatchConvey("test rerank", t, func() {
+		svc := &vikingdb.VikingDBService{}
+		r := &Retriever{
+			config:  &RetrieverConfig{Rerank: &RerankConfig{TitleField: "title", TopN: 2}},
+			service: svc,
+		}
+
+		newDocs := func() []*schema.Document {
+			return []*schema.Document{
+				(&schema.Document{ID: "1", Content: "a", MetaData: map[string]any{
+					ExtraKeyVikingDBFields: map[string]interface{}{"title": "t1"},
+				}}).WithScore(0.9),
+				(&schema.Document{ID: "2", Content: "b"}).WithScore(0.8),
+				(&schema.Document{ID: "3", Content: "c"}).WithScore(0.7),
+			}
+		}
+
+		PatchConvey("test BatchRerank error", func() {
+			Mock(GetMethod(svc, "BatchRerank")).Return(nil, fmt.Errorf("mock err")).Build()
+
+			docs, err := r.rerank("q", newDocs())
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(docs, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test scores length mismatch", func() {
+			Mock(GetMethod(svc, "BatchRerank")).Return([]float64{0.1}, nil).Build()
+
+			docs, err := r.rerank("q", newDocs())
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(docs, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test success", func() {
+			var got []map[string]interface{}
+			Mock(GetMethod(svc, "BatchRerank")).To(func(_ *vikingdb.VikingDBService, datas []map[string]interface{}) ([]float64, error) {
+				got = datas
+				return []float64{0.1, 0.5, 0.3}, nil
+			}).Build()
+
+			docs, err := r.rerank("q", newDocs())
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(got[0], convey.ShouldResemble, map[string]interface{}{"query": "q", "content": "a", "title": "t1"})
+			convey.So(got[1], convey.ShouldResemble, map[string]interface{}{"query": "q", "content": "b"})
+			convey.So(len(docs), convey.ShouldEqual, 2)
+			convey.So(docs[0].ID, convey.ShouldEqual, "2")
+			convey.So(docs[0].Score(), convey.ShouldEqual, 0.5)
+			convey.So(docs[0].MetaData[ExtraKeyVikingDBOriginalScore], convey.ShouldEqual, 0.8)
+			convey.So(docs[1].ID, convey.ShouldEqual, "3")
+		})
+	})
+}
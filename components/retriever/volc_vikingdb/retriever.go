@@ -19,7 +19,9 @@ package volc_vikingdb
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"sync"
 
 	"github.com/volcengine/volc-sdk-golang/service/vikingdb"
 
@@ -34,6 +36,8 @@ const (
 	defaultTopK        = 100
 	defaultPartition   = "default"
 	defaultDenseWeight = 0.5
+
+	defaultVectorFieldWeight = 1.0
 )
 
 type RetrieverConfig struct {
@@ -55,7 +59,31 @@ type RetrieverConfig struct {
 	TopK           *int     `json:"top_k,omitempty"`
 	ScoreThreshold *float64 `json:"score_threshold,omitempty"`
 	// FilterDSL 标量过滤 filter 表达式 https://www.volcengine.com/docs/84313/1254609
+	// 可使用 Must / MustNot / Range / And / Or 构建
 	FilterDSL map[string]any `json:"filter_dsl,omitempty"`
+
+	// VectorFields 多向量字段检索, 每个向量字段对应 Collection 下的一个索引, 未设置时仅检索 Index
+	// 设置后会并发检索 Index 及 VectorFields 中的所有索引, 按主键合并, 分数为各索引分数的加权和
+	VectorFields []*VectorField `json:"vector_fields,omitempty"`
+	// Rerank 使用 VikingDB 内置 rerank 对检索结果重排, 未设置时不重排
+	// see: https://www.volcengine.com/docs/84313/1254474
+	Rerank *RerankConfig `json:"rerank,omitempty"`
+}
+
+type VectorField struct {
+	// Index 构建在该向量字段上的索引名称
+	Index string `json:"index"`
+	// Weight 该索引分数在合并时的权重, 默认值为 1
+	Weight float64 `json:"weight"`
+	// Embedding 该向量字段使用的 embedding, 未设置时使用 EmbeddingConfig 中的向量化方法
+	Embedding embedding.Embedder `json:"-"`
+}
+
+type RerankConfig struct {
+	// TitleField 作为 rerank title 的字段名称, 未设置时不传 title
+	TitleField string `json:"title_field"`
+	// TopN rerank 后返回的文档数量, 为 0 时返回全部
+	TopN int `json:"top_n"`
 }
 
 type EmbeddingConfig struct {
@@ -79,10 +107,11 @@ type EmbeddingConfig struct {
 }
 
 type Retriever struct {
-	config   *RetrieverConfig
-	service  *vikingdb.VikingDBService
-	index    *vikingdb.Index
-	embModel *vikingdb.EmbModel
+	config       *RetrieverConfig
+	service      *vikingdb.VikingDBService
+	index        *vikingdb.Index
+	fieldIndexes []*vikingdb.Index
+	embModel     *vikingdb.EmbModel
 }
 
 func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, error) {
@@ -102,6 +131,24 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 		return nil, err
 	}
 
+	fieldIndexes := make([]*vikingdb.Index, 0, len(config.VectorFields))
+	for _, field := range config.VectorFields {
+		if field == nil || field.Index == "" {
+			return nil, fmt.Errorf("[VikingDBRetriever] index of vector field not provided")
+		}
+
+		if field.Weight == 0 {
+			field.Weight = defaultVectorFieldWeight
+		}
+
+		fieldIndex, err := service.GetIndex(config.Collection, field.Index)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldIndexes = append(fieldIndexes, fieldIndex)
+	}
+
 	if len(config.Partition) == 0 {
 		config.Partition = defaultPartition
 	}
@@ -111,10 +158,11 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 	}
 
 	r := &Retriever{
-		config:   config,
-		service:  service,
-		index:    index,
-		embModel: nil,
+		config:       config,
+		service:      service,
+		index:        index,
+		fieldIndexes: fieldIndexes,
+		embModel:     nil,
 	}
 
 	if config.EmbeddingConfig.UseBuiltin {
@@ -150,11 +198,6 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		DSLInfo:        r.config.FilterDSL,
 	}, opts...)
 
-	var (
-		dense  []float64
-		sparse map[string]interface{}
-	)
-
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
 		Query:          query,
 		TopK:           dereferenceOrZero(options.TopK),
@@ -162,22 +205,174 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		ScoreThreshold: options.ScoreThreshold,
 	})
 
-	if r.config.EmbeddingConfig.UseBuiltin && options.Embedding == nil {
-		dense, sparse, err = r.builtinEmbedding(ctx, query, options)
+	dense, sparse, err := r.embedding(ctx, query, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*vikingdb.Data
+	if len(r.fieldIndexes) == 0 {
+		result, err = r.index.SearchByVector(dense, r.makeSearchOption(sparse, options))
 	} else {
-		dense, err = r.customEmbedding(ctx, query, options)
+		result, err = r.multiFieldSearch(ctx, query, dense, sparse, options)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err = r.convertResult(result, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.config.Rerank != nil && len(docs) > 0 {
+		docs, err = r.rerank(query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+// SearchByID retrieves records nearest to the record with given primary key, e.g. neighbouring chunks of a retrieved chunk.
+// Partition, TopK, ScoreThreshold and DSLInfo in options take effect.
+func (r *Retriever) SearchByID(ctx context.Context, id any, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	defer func() {
+		if err != nil {
+			ctx = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := retriever.GetCommonOptions(&retriever.Options{
+		Index:          &r.config.Index,
+		SubIndex:       &r.config.Partition,
+		TopK:           r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		DSLInfo:        r.config.FilterDSL,
+	}, opts...)
+
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          fmt.Sprint(id),
+		TopK:           dereferenceOrZero(options.TopK),
+		Filter:         tryMarshalJsonString(options.DSLInfo),
+		ScoreThreshold: options.ScoreThreshold,
+	})
 
+	result, err := r.index.SearchById(id, r.makeSearchOption(nil, options))
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := r.index.SearchByVector(dense, r.makeSearchOption(sparse, options))
+	docs, err = r.convertResult(result, options)
 	if err != nil {
 		return nil, err
 	}
 
-	docs = make([]*schema.Document, 0, len(result))
+	ctx = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) embedding(ctx context.Context, query string, options *retriever.Options) (dense []float64, sparse map[string]interface{}, err error) {
+	if r.config.EmbeddingConfig.UseBuiltin && options.Embedding == nil {
+		return r.builtinEmbedding(ctx, query, options)
+	}
+
+	dense, err = r.customEmbedding(ctx, query, options)
+	return dense, nil, err
+}
+
+// multiFieldSearch searches Index and all VectorFields concurrently, then merges results by primary key.
+// Weight of Index is 1.
+func (r *Retriever) multiFieldSearch(ctx context.Context, query string, dense []float64, sparse map[string]interface{},
+	options *retriever.Options) ([]*vikingdb.Data, error) {
+
+	fields := make([]*VectorField, 0, len(r.config.VectorFields)+1)
+	fields = append(fields, &VectorField{Index: r.config.Index, Weight: defaultVectorFieldWeight})
+	fields = append(fields, r.config.VectorFields...)
+	indexes := append([]*vikingdb.Index{r.index}, r.fieldIndexes...)
+
+	results := make([][]*vikingdb.Data, len(fields))
+	weights := make([]float64, len(fields))
+	errs := make([]error, len(fields))
+
+	wg := sync.WaitGroup{}
+	for i := range fields {
+		weights[i] = fields[i].Weight
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				if e := recover(); e != nil {
+					errs[i] = fmt.Errorf("panic: %v", e)
+				}
+			}()
+
+			fieldDense, fieldSparse := dense, sparse
+			if emb := fields[i].Embedding; emb != nil {
+				var err error
+				fieldDense, err = r.customEmbedding(ctx, query, &retriever.Options{Embedding: emb})
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				fieldSparse = nil
+			}
+
+			results[i], errs[i] = indexes[i].SearchByVector(fieldDense, r.makeSearchOption(fieldSparse, options))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("[multiFieldSearch] search index %s failed, %w", fields[i].Index, err)
+		}
+	}
+
+	return mergeByWeightedScore(results, weights, dereferenceOrZero(options.TopK)), nil
+}
+
+// mergeByWeightedScore merges results of multiple indexes by primary key, score of a record is
+// the weighted sum of its scores in all results, records are sorted by score desc and truncated by topK.
+func mergeByWeightedScore(results [][]*vikingdb.Data, weights []float64, topK int) []*vikingdb.Data {
+	merged := make([]*vikingdb.Data, 0)
+	byID := make(map[string]*vikingdb.Data)
+	for i, result := range results {
+		for _, data := range result {
+			if data == nil {
+				continue
+			}
+
+			key := fmt.Sprint(data.Id)
+			if exist, ok := byID[key]; ok {
+				exist.Score += weights[i] * data.Score
+				continue
+			}
+
+			cp := *data
+			cp.Score = weights[i] * data.Score
+			byID[key] = &cp
+			merged = append(merged, &cp)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Score > merged[j].Score
+	})
+
+	if topK > 0 && len(merged) > topK {
+		merged = merged[:topK]
+	}
+
+	return merged
+}
+
+func (r *Retriever) convertResult(result []*vikingdb.Data, options *retriever.Options) ([]*schema.Document, error) {
+	docs := make([]*schema.Document, 0, len(result))
 	for _, data := range result {
 		if options.ScoreThreshold != nil && data.Score < *options.ScoreThreshold {
 			continue
@@ -191,8 +386,6 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		docs = append(docs, doc.WithDSLInfo(options.DSLInfo))
 	}
 
-	ctx = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
-
 	return docs, nil
 }
 
@@ -334,6 +334,63 @@ func TestData2Document(t *testing.T) {
 	})
 }
 
+func TestMergeByWeightedScore(t *testing.T) {
+	PatchConvey("test mergeByWeightedScore", t, func() {
+		results := [][]*vikingdb.Data{
+			{{Id: "a", Score: 0.9}, {Id: "b", Score: 0.5}},
+			{{Id: "b", Score: 0.8}, {Id: int64(1), Score: 0.4}},
+		}
+
+		merged := mergeByWeightedScore(results, []float64{1, 0.5}, 0)
+		convey.So(len(merged), convey.ShouldEqual, 3)
+		convey.So(merged[0].Id, convey.ShouldEqual, "a")
+		convey.So(merged[0].Score, convey.ShouldAlmostEqual, 0.9)
+		convey.So(merged[1].Id, convey.ShouldEqual, "b")
+		convey.So(merged[1].Score, convey.ShouldAlmostEqual, 0.9)
+		convey.So(merged[2].Id, convey.ShouldEqual, int64(1))
+		convey.So(merged[2].Score, convey.ShouldAlmostEqual, 0.2)
+
+		// inputs are not modified
+		convey.So(results[0][1].Score, convey.ShouldEqual, 0.5)
+
+		merged = mergeByWeightedScore(results, []float64{1, 0.5}, 1)
+		convey.So(len(merged), convey.ShouldEqual, 1)
+	})
+}
+
+func TestSearchByID(t *testing.T) {
+	PatchConvey("test SearchByID", t, func() {
+		ctx := context.Background()
+		idx := &vikingdb.Index{}
+		r := &Retriever{
+			config: &RetrieverConfig{Partition: defaultPartition, TopK: of(10), ScoreThreshold: of(0.3)},
+			index:  idx,
+		}
+
+		PatchConvey("test SearchById error", func() {
+			Mock(GetMethod(idx, "SearchById")).Return(nil, fmt.Errorf("mock err")).Build()
+
+			docs, err := r.SearchByID(ctx, "asd")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(docs, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test success", func() {
+			Mock(GetMethod(idx, "SearchById")).Return([]*vikingdb.Data{
+				{Id: "asd", Fields: map[string]interface{}{"content": "c1"}, Score: 1},
+				{Id: "qwe", Fields: map[string]interface{}{"content": "c2"}, Score: 0.8},
+				{Id: "zxc", Fields: map[string]interface{}{"content": "c3"}, Score: 0.1},
+			}, nil).Build()
+
+			docs, err := r.SearchByID(ctx, "asd")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(docs), convey.ShouldEqual, 2)
+			convey.So(docs[1].ID, convey.ShouldEqual, "qwe")
+			convey.So(docs[1].Content, convey.ShouldEqual, "c2")
+		})
+	})
+}
+
 type mockEmbedding struct {
 	fn func() ([][]float64, error)
 }
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// The helpers of this file are shared with components/prompt/mcp, which copies this file by go generate.
+
+// blobPart converts a blob resource into a message part of its mime type, the blob is carried by a data URL.
+func blobPart(r mcp.BlobResourceContents) schema.ChatMessagePart {
+	url := dataURL(r.MIMEType, r.Blob)
+	switch {
+	case strings.HasPrefix(r.MIMEType, "image/"):
+		return schema.ChatMessagePart{
+			Type:     schema.ChatMessagePartTypeImageURL,
+			ImageURL: &schema.ChatMessageImageURL{URL: url, URI: r.URI, MIMEType: r.MIMEType},
+		}
+	case strings.HasPrefix(r.MIMEType, "audio/"):
+		return schema.ChatMessagePart{
+			Type:     schema.ChatMessagePartTypeAudioURL,
+			AudioURL: &schema.ChatMessageAudioURL{URL: url, URI: r.URI, MIMEType: r.MIMEType},
+		}
+	case strings.HasPrefix(r.MIMEType, "video/"):
+		return schema.ChatMessagePart{
+			Type:     schema.ChatMessagePartTypeVideoURL,
+			VideoURL: &schema.ChatMessageVideoURL{URL: url, URI: r.URI, MIMEType: r.MIMEType},
+		}
+	default:
+		return schema.ChatMessagePart{
+			Type:    schema.ChatMessagePartTypeFileURL,
+			FileURL: &schema.ChatMessageFileURL{URL: url, URI: r.URI, MIMEType: r.MIMEType},
+		}
+	}
+}
+
+// dataURL builds a data URL of base64 encoded data.
+func dataURL(mimeType, data string) string {
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	return "data:" + mimeType + ";base64," + data
+}
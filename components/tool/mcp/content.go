@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ToolError is returned by InvokableRun when the MCP tool reports an error result and Config.FailOnToolError is true.
+// Otherwise, the error result is returned as the tool output, so that the model can react to it.
+type ToolError struct {
+	// Name is the name of the tool.
+	Name string
+	// Result is the raw result of the tool call.
+	Result *mcp.CallToolResult
+}
+
+func (e *ToolError) Error() string {
+	return fmt.Sprintf("mcp tool %s returns error: %s", e.Name, ContentsToText(e.Result.Content))
+}
+
+// ContentsToText converts contents of MCP tool result into text.
+// Texts and text resources are concatenated by new lines, images and blob resources are represented by placeholders.
+func ContentsToText(contents []mcp.Content) string {
+	texts := make([]string, 0, len(contents))
+	for _, content := range contents {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			texts = append(texts, c.Text)
+		case mcp.ImageContent:
+			texts = append(texts, fmt.Sprintf("[image: %s]", c.MIMEType))
+		case mcp.EmbeddedResource:
+			switch r := c.Resource.(type) {
+			case mcp.TextResourceContents:
+				texts = append(texts, r.Text)
+			case mcp.BlobResourceContents:
+				texts = append(texts, fmt.Sprintf("[resource: %s, %s]", r.URI, r.MIMEType))
+			}
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// ContentsToParts converts contents of MCP tool result into multimodal message parts.
+// Images and blob resources are represented by data URLs (RFC-2397), text resources are text parts.
+// The output of an eino tool is a string, so the parts can't be returned as the tool output,
+// call it in Config.ToolCallResultHandler and pass them to the model by other means, e.g. a following user message.
+func ContentsToParts(contents []mcp.Content) []schema.ChatMessagePart {
+	parts := make([]schema.ChatMessagePart, 0, len(contents))
+	for _, content := range contents {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			parts = append(parts, schema.ChatMessagePart{
+				Type: schema.ChatMessagePartTypeText,
+				Text: c.Text,
+			})
+		case mcp.ImageContent:
+			parts = append(parts, schema.ChatMessagePart{
+				Type: schema.ChatMessagePartTypeImageURL,
+				ImageURL: &schema.ChatMessageImageURL{
+					URL:      dataURL(c.MIMEType, c.Data),
+					MIMEType: c.MIMEType,
+				},
+			})
+		case mcp.EmbeddedResource:
+			switch r := c.Resource.(type) {
+			case mcp.TextResourceContents:
+				parts = append(parts, schema.ChatMessagePart{
+					Type: schema.ChatMessagePartTypeText,
+					Text: r.Text,
+				})
+			case mcp.BlobResourceContents:
+				parts = append(parts, blobPart(r))
+			}
+		}
+	}
+	return parts
+}
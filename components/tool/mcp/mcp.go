@@ -19,6 +19,7 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/cloudwego/eino/components/tool"
@@ -35,12 +36,37 @@ type Config struct {
 	// ToolNameList specifies which tools to fetch from MCP server
 	// If empty, all available tools will be fetched
 	ToolNameList []string
+
+	// Timeout limits the duration of each tool call. Zero means no timeout.
+	Timeout time.Duration
+	// ToolTimeouts overrides Timeout for the tools by name.
+	ToolTimeouts map[string]time.Duration
+	// FailOnToolError if true, a result with IsError set is returned as *ToolError.
+	// By default, it is returned as the tool output, so that the model can react to the error.
+	FailOnToolError bool
+	// ToolCallResultHandler converts the result of a tool call into the tool output.
+	// The tool output is text only, multimodal parts of the result, see ContentsToParts, are only available here.
+	// Default: texts of the result joined by new lines, see ContentsToText.
+	ToolCallResultHandler func(ctx context.Context, name string, result *mcp.CallToolResult) (string, error)
 }
 
+// GetTools fetches the tools from MCP server, following the pagination cursor until all pages are fetched.
 func GetTools(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
-	listResults, err := conf.Cli.ListTools(ctx, mcp.ListToolsRequest{})
-	if err != nil {
-		return nil, fmt.Errorf("list mcp tools fail: %w", err)
+	var mcpTools []mcp.Tool
+	var cursor mcp.Cursor
+	for {
+		req := mcp.ListToolsRequest{}
+		req.Params.Cursor = cursor
+		listResults, err := conf.Cli.ListTools(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("list mcp tools fail: %w", err)
+		}
+		mcpTools = append(mcpTools, listResults.Tools...)
+
+		if listResults.NextCursor == "" || listResults.NextCursor == cursor {
+			break
+		}
+		cursor = listResults.NextCursor
 	}
 
 	nameSet := make(map[string]struct{})
@@ -48,8 +74,8 @@ func GetTools(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 		nameSet[name] = struct{}{}
 	}
 
-	ret := make([]tool.BaseTool, 0, len(listResults.Tools))
-	for _, t := range listResults.Tools {
+	ret := make([]tool.BaseTool, 0, len(mcpTools))
+	for _, t := range mcpTools {
 		if len(conf.ToolNameList) > 0 {
 			if _, ok := nameSet[t.Name]; !ok {
 				continue
@@ -66,6 +92,13 @@ func GetTools(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 			return nil, fmt.Errorf("conv mcp tool input schema fail(unmarshal): %w, tool name: %s", err, t.Name)
 		}
 
+		timeout := conf.Timeout
+		if d, ok := conf.ToolTimeouts[t.Name]; ok {
+			timeout = d
+		}
+
+		// annotations and output schemas of tools are not available in this version of mcp-go,
+		// and schema.ToolInfo has no fields for them, so only the name, description and input schema are kept.
 		ret = append(ret, &toolHelper{
 			cli: conf.Cli,
 			info: &schema.ToolInfo{
@@ -73,6 +106,9 @@ func GetTools(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 				Desc:        t.Description,
 				ParamsOneOf: schema.NewParamsOneOfByOpenAPIV3(inputSchema),
 			},
+			timeout:         timeout,
+			failOnToolError: conf.FailOnToolError,
+			resultHandler:   conf.ToolCallResultHandler,
 		})
 	}
 
@@ -82,6 +118,10 @@ func GetTools(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 type toolHelper struct {
 	cli  client.MCPClient
 	info *schema.ToolInfo
+
+	timeout         time.Duration
+	failOnToolError bool
+	resultHandler   func(ctx context.Context, name string, result *mcp.CallToolResult) (string, error)
 }
 
 func (m *toolHelper) Info(ctx context.Context) (*schema.ToolInfo, error) {
@@ -94,6 +134,11 @@ func (m *toolHelper) InvokableRun(ctx context.Context, argumentsInJSON string, o
 	if err != nil {
 		return "", fmt.Errorf("unmarshal input fail: %w", err)
 	}
+	if m.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+	}
 	result, err := m.cli.CallTool(ctx, mcp.CallToolRequest{
 		Request: mcp.Request{
 			Method: "tools/call",
@@ -113,12 +158,11 @@ func (m *toolHelper) InvokableRun(ctx context.Context, argumentsInJSON string, o
 		return "", fmt.Errorf("call mcp tool fail: %w", err)
 	}
 
-	marshaledResult, err := sonic.MarshalString(result)
-	if err != nil {
-		return "", fmt.Errorf("marshal mcp tool result fail: %w", err)
+	if result.IsError && m.failOnToolError {
+		return "", &ToolError{Name: m.info.Name, Result: result}
 	}
-	if result.IsError {
-		return "", fmt.Errorf("call mcp tool fail: %s", marshaledResult)
+	if m.resultHandler != nil {
+		return m.resultHandler(ctx, m.info.Name, result)
 	}
-	return marshaledResult, nil
+	return ContentsToText(result.Content), nil
 }
@@ -18,9 +18,13 @@ package mcp
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 )
@@ -39,10 +43,166 @@ func TestTool(t *testing.T) {
 
 	result, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, "{\"input\": \"123\"}")
 	assert.NoError(t, err)
-	assert.Equal(t, "{\"content\":[{\"type\":\"text\",\"text\":\"hello\"}]}", result)
+	assert.Equal(t, "hello", result)
 }
 
-type mockMCPClient struct{}
+func TestWatchTools(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cli := &mockMCPClient{}
+
+	updates := make(chan []tool.BaseTool, 1)
+	tools, err := WatchTools(ctx, &Config{Cli: cli}, func(tools []tool.BaseTool, err error) {
+		assert.NoError(t, err)
+		updates <- tools
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tools))
+
+	cli.mu.Lock()
+	cli.added = true
+	cli.mu.Unlock()
+	notification := mcp.JSONRPCNotification{}
+	notification.Method = "notifications/resources/list_changed"
+	cli.handler(notification)
+	notification.Method = methodToolListChanged
+	cli.handler(notification)
+
+	select {
+	case tools = <-updates:
+	case <-time.After(time.Second):
+		t.Fatal("tools are not updated")
+	}
+	infos, err := ToolInfos(ctx, tools)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(infos))
+	assert.Equal(t, "name3", infos[2].Name)
+
+	_, err = WatchTools(ctx, &Config{Cli: cli}, nil)
+	assert.Error(t, err)
+}
+
+func TestWatchToolsRecover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cli := &mockMCPClient{}
+
+	errs := make(chan error, 3)
+	calls := 0
+	_, err := WatchTools(ctx, &Config{Cli: cli}, func(tools []tool.BaseTool, err error) {
+		calls++
+		if calls == 1 {
+			panic("on change")
+		}
+		errs <- err
+		if calls == 2 {
+			// the panic of reporting the panic is recovered too
+			panic("on change again")
+		}
+	})
+	assert.NoError(t, err)
+
+	notification := mcp.JSONRPCNotification{}
+	notification.Method = methodToolListChanged
+	cli.handler(notification)
+	select {
+	case err = <-errs:
+		assert.ErrorContains(t, err, "panic in watching mcp tools: on change")
+	case <-time.After(time.Second):
+		t.Fatal("panic is not reported")
+	}
+
+	// watching goes on after the panic
+	cli.handler(notification)
+	select {
+	case err = <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("tools are not updated after the panic")
+	}
+
+	// notifications are ignored after ctx is done
+	cancel()
+	cli.handler(notification)
+	select {
+	case <-errs:
+		t.Fatal("tools are updated after ctx is done")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestToolError(t *testing.T) {
+	ctx := context.Background()
+	cli := &mockMCPClient{}
+
+	tools, err := GetTools(ctx, &Config{Cli: cli, ToolNameList: []string{"name2"}})
+	assert.NoError(t, err)
+	result, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, "{}")
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid argument", result)
+
+	tools, err = GetTools(ctx, &Config{Cli: cli, ToolNameList: []string{"name2"}, FailOnToolError: true})
+	assert.NoError(t, err)
+	_, err = tools[0].(tool.InvokableTool).InvokableRun(ctx, "{}")
+	var toolErr *ToolError
+	assert.True(t, errors.As(err, &toolErr))
+	assert.Equal(t, "name2", toolErr.Name)
+	assert.Equal(t, "mcp tool name2 returns error: invalid argument", err.Error())
+}
+
+func TestToolTimeout(t *testing.T) {
+	ctx := context.Background()
+	cli := &mockMCPClient{}
+
+	tools, err := GetTools(ctx, &Config{
+		Cli:          cli,
+		Timeout:      time.Hour,
+		ToolTimeouts: map[string]time.Duration{"name": time.Millisecond},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Millisecond, tools[0].(*toolHelper).timeout)
+	assert.Equal(t, time.Hour, tools[1].(*toolHelper).timeout)
+
+	_, err = tools[0].(tool.InvokableTool).InvokableRun(ctx, "{\"input\": \"slow\"}")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestToolCallResultHandler(t *testing.T) {
+	ctx := context.Background()
+	tools, err := GetTools(ctx, &Config{
+		Cli:          &mockMCPClient{},
+		ToolNameList: []string{"name"},
+		ToolCallResultHandler: func(ctx context.Context, name string, result *mcp.CallToolResult) (string, error) {
+			return name + ": " + ContentsToText(result.Content), nil
+		},
+	})
+	assert.NoError(t, err)
+	result, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, "{}")
+	assert.NoError(t, err)
+	assert.Equal(t, "name: hello", result)
+}
+
+func TestContents(t *testing.T) {
+	contents := []mcp.Content{
+		mcp.TextContent{Type: "text", Text: "hello"},
+		mcp.ImageContent{Type: "image", Data: "aW1n", MIMEType: "image/png"},
+		mcp.EmbeddedResource{Type: "resource", Resource: mcp.TextResourceContents{URI: "file:///a.txt", MIMEType: "text/plain", Text: "text resource"}},
+		mcp.EmbeddedResource{Type: "resource", Resource: mcp.BlobResourceContents{URI: "file:///a.pdf", MIMEType: "application/pdf", Blob: "cGRm"}},
+	}
+
+	assert.Equal(t, "hello\n[image: image/png]\ntext resource\n[resource: file:///a.pdf, application/pdf]", ContentsToText(contents))
+	assert.Equal(t, []schema.ChatMessagePart{
+		{Type: schema.ChatMessagePartTypeText, Text: "hello"},
+		{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,aW1n", MIMEType: "image/png"}},
+		{Type: schema.ChatMessagePartTypeText, Text: "text resource"},
+		{Type: schema.ChatMessagePartTypeFileURL, FileURL: &schema.ChatMessageFileURL{URL: "data:application/pdf;base64,cGRm", URI: "file:///a.pdf", MIMEType: "application/pdf"}},
+	}, ContentsToParts(contents))
+}
+
+type mockMCPClient struct {
+	mu      sync.Mutex
+	added   bool
+	handler func(notification mcp.JSONRPCNotification)
+}
 
 func (m *mockMCPClient) Initialize(ctx context.Context, request mcp.InitializeRequest) (*mcp.InitializeResult, error) {
 	panic("implement me")
@@ -81,28 +241,58 @@ func (m *mockMCPClient) GetPrompt(ctx context.Context, request mcp.GetPromptRequ
 }
 
 func (m *mockMCPClient) ListTools(ctx context.Context, request mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
-	return &mcp.ListToolsResult{
-		Tools: []mcp.Tool{
-			{
-				Name:        "name",
-				Description: "description",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: map[string]interface{}{
-						"input": map[string]interface{}{"type": "string"},
+	if request.Params.Cursor == "" {
+		result := &mcp.ListToolsResult{
+			Tools: []mcp.Tool{
+				{
+					Name:        "name",
+					Description: "description",
+					InputSchema: mcp.ToolInputSchema{
+						Type: "object",
+						Properties: map[string]interface{}{
+							"input": map[string]interface{}{"type": "string"},
+						},
+						Required: []string{"input"},
 					},
-					Required: []string{"input"},
 				},
 			},
+		}
+		result.NextCursor = "page2"
+		return result, nil
+	}
+
+	result := &mcp.ListToolsResult{
+		Tools: []mcp.Tool{
 			{
 				Name:        "name2",
 				Description: "description",
 			},
 		},
-	}, nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.added {
+		result.Tools = append(result.Tools, mcp.Tool{Name: "name3"})
+	}
+	return result, nil
 }
 
 func (m *mockMCPClient) CallTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if request.Params.Name == "name2" {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: "invalid argument",
+				},
+			},
+			IsError: true,
+		}, nil
+	}
+	if request.Params.Arguments["input"] == "slow" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			mcp.TextContent{
@@ -127,5 +317,5 @@ func (m *mockMCPClient) Close() error {
 }
 
 func (m *mockMCPClient) OnNotification(handler func(notification mcp.JSONRPCNotification)) {
-	panic("implement me")
+	m.handler = handler
 }
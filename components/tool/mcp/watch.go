@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// methodToolListChanged is the notification sent by MCP server when its tool list changes.
+const methodToolListChanged = "notifications/tools/list_changed"
+
+// WatchTools fetches the tools like GetTools, and re-fetches them every time the server sends
+// notifications/tools/list_changed, calling onChange with the updated tools or the error of fetching.
+// Notifications arriving while fetching are coalesced into one more fetch. onChange is called sequentially.
+//
+// Watching stops when ctx is done. WatchTools must be called only once per client: a notification handler can't be
+// removed from client.MCPClient, so the handler registered here stays on the client after ctx is done, ignoring
+// notifications, and handlers registered by later calls accumulate.
+//
+// A panic in fetching the tools or in onChange is recovered and reported to onChange as an error,
+// and watching goes on.
+//
+// A ChatModel can be re-bound with the updated tools in onChange:
+//
+//	tools, err := mcp.WatchTools(ctx, conf, func(tools []tool.BaseTool, err error) {
+//		if err != nil {
+//			return
+//		}
+//		infos, err := mcp.ToolInfos(ctx, tools)
+//		if err != nil {
+//			return
+//		}
+//		_ = chatModel.BindTools(infos)
+//	})
+func WatchTools(ctx context.Context, conf *Config, onChange func(tools []tool.BaseTool, err error)) ([]tool.BaseTool, error) {
+	if onChange == nil {
+		return nil, fmt.Errorf("on change callback not provided")
+	}
+
+	changed := make(chan struct{}, 1)
+	conf.Cli.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != methodToolListChanged || ctx.Err() != nil {
+			return
+		}
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+
+	tools, err := GetTools(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				refreshTools(ctx, conf, onChange)
+			}
+		}
+	}()
+
+	return tools, nil
+}
+
+// refreshTools re-fetches the tools and calls onChange, recovering a panic so that the watching loop goes on.
+func refreshTools(ctx context.Context, conf *Config, onChange func(tools []tool.BaseTool, err error)) {
+	defer func() {
+		if e := recover(); e != nil {
+			// onChange may panic again when reporting the panic.
+			defer func() { _ = recover() }()
+			onChange(nil, fmt.Errorf("panic in watching mcp tools: %v", e))
+		}
+	}()
+
+	onChange(GetTools(ctx, conf))
+}
+
+// ToolInfos gets the infos of tools, e.g. for ChatModel.BindTools.
+func ToolInfos(ctx context.Context, tools []tool.BaseTool) ([]*schema.ToolInfo, error) {
+	infos := make([]*schema.ToolInfo, 0, len(tools))
+	for _, t := range tools {
+		info, err := t.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get tool info fail: %w", err)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
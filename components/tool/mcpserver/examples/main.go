@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/cloudwego/eino-ext/components/tool/mcpserver"
+)
+
+func main() {
+	ctx := context.Background()
+
+	svr, err := mcpserver.NewServer(ctx, &mcpserver.Config{
+		Name:    "eino-demo",
+		Tools:   []tool.BaseTool{&upperTool{}, wordCountGraph(ctx)},
+		Prompts: []*mcpserver.Prompt{translatePrompt()},
+		// notifications are only sent to a single session, unset SingleSession and Logging to serve over SSE.
+		SingleSession: true,
+		Logging:       true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// serve over SSE instead, without SingleSession: server.NewSSEServer(svr, "http://localhost:12345").Start("localhost:12345")
+	if err = server.ServeStdio(svr); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type upperTool struct{}
+
+func (u *upperTool) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{
+		Name: "upper",
+		Desc: "convert the text to upper case",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"text": {Type: schema.String, Desc: "the text to convert", Required: true},
+		}),
+	}, nil
+}
+
+func (u *upperTool) InvokableRun(_ context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	var args struct {
+		Text string `json:"text"`
+	}
+	if err := sonic.UnmarshalString(argumentsInJSON, &args); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(args.Text), nil
+}
+
+type wordCountInput struct {
+	Text string `json:"text"`
+}
+
+func wordCountGraph(ctx context.Context) tool.BaseTool {
+	r, err := compose.NewChain[*wordCountInput, map[string]int]().
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, in *wordCountInput) ([]string, error) {
+			return strings.Fields(in.Text), nil
+		})).
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, words []string) (map[string]int, error) {
+			counts := make(map[string]int, len(words))
+			for _, w := range words {
+				counts[strings.ToLower(w)]++
+			}
+			return counts, nil
+		})).
+		Compile(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return mcpserver.NewGraphTool[*wordCountInput, map[string]int](&schema.ToolInfo{
+		Name: "word_count",
+		Desc: "count the words in the text",
+		ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+			"text": {Type: schema.String, Desc: "the text to count", Required: true},
+		}),
+	}, r)
+}
+
+func translatePrompt() *mcpserver.Prompt {
+	return &mcpserver.Prompt{
+		Name:        "translate",
+		Description: "translate the text into the language",
+		Arguments: []mcp.PromptArgument{
+			{Name: "language", Description: "the target language", Required: true},
+			{Name: "text", Description: "the text to translate", Required: true},
+		},
+		Template: prompt.FromMessages(schema.FString,
+			schema.SystemMessage("You are a translator, translate the text of the user into {language}."),
+			schema.UserMessage("{text}"),
+		),
+	}
+}
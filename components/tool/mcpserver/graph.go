@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+)
+
+// NewGraphTool wraps a compiled graph as an InvokableTool, so that it can be served as a MCP tool.
+// The arguments of a call are unmarshaled into I, or passed as is if I is string.
+// The output is returned as is if O is string, otherwise it is marshaled to JSON.
+func NewGraphTool[I, O any](info *schema.ToolInfo, r compose.Runnable[I, O], opts ...compose.Option) tool.InvokableTool {
+	return &graphTool[I, O]{info: info, r: r, opts: opts}
+}
+
+type graphTool[I, O any] struct {
+	info *schema.ToolInfo
+	r    compose.Runnable[I, O]
+	opts []compose.Option
+}
+
+func (g *graphTool[I, O]) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return g.info, nil
+}
+
+func (g *graphTool[I, O]) InvokableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	var input I
+	if s, ok := any(&input).(*string); ok {
+		*s = argumentsInJSON
+	} else if err := sonic.UnmarshalString(argumentsInJSON, &input); err != nil {
+		return "", fmt.Errorf("unmarshal arguments of %s fail: %w", g.info.Name, err)
+	}
+
+	output, err := g.r.Invoke(ctx, input, g.opts...)
+	if err != nil {
+		return "", err
+	}
+
+	if s, ok := any(output).(string); ok {
+		return s, nil
+	}
+	return sonic.MarshalString(output)
+}
+
+func (g *graphTool[I, O]) GetType() string {
+	return "Graph"
+}
+
+// IsCallbacksEnabled the graph triggers callbacks of itself and its nodes.
+func (g *graphTool[I, O]) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcpserver
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	methodProgress = "notifications/progress"
+	methodMessage  = "notifications/message"
+
+	loggerName = "eino"
+)
+
+// notificationSender is implemented by *server.MCPServer.
+type notificationSender interface {
+	SendNotificationToClient(method string, params map[string]any) error
+}
+
+// notifier sends notifications to the client of the server.
+//
+// The server of mcp-go v0.10.3 sends notifications to the client of its latest request, not of the request
+// being handled, so notifications are only sent when the server serves a single session, see Config.SingleSession.
+type notifier struct {
+	send func(method string, params map[string]any) error
+}
+
+func newNotifier(svr notificationSender) *notifier {
+	return &notifier{send: svr.SendNotificationToClient}
+}
+
+// handler maps the callbacks of a tool call to notifications:
+// each finished component advances the progress of the token, and if logging is enabled,
+// starts, ends and errors of components are sent as log messages.
+// Notifications are best effort, failures to send them don't affect the tool call.
+func (n *notifier) handler(token mcp.ProgressToken, logging bool) callbacks.Handler {
+	if token == nil && !logging {
+		return nil
+	}
+
+	var progress int64
+	onStart := func(ctx context.Context, info *callbacks.RunInfo) context.Context {
+		if logging {
+			n.log("info", info, "start", nil)
+		}
+		return ctx
+	}
+	onEnd := func(ctx context.Context, info *callbacks.RunInfo) context.Context {
+		if logging {
+			n.log("info", info, "end", nil)
+		}
+		if token != nil {
+			_ = n.send(methodProgress, map[string]any{
+				"progressToken": token,
+				"progress":      atomic.AddInt64(&progress, 1),
+			})
+		}
+		return ctx
+	}
+
+	return callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, _ callbacks.CallbackInput) context.Context {
+			return onStart(ctx, info)
+		}).
+		OnStartWithStreamInputFn(func(ctx context.Context, info *callbacks.RunInfo, input *schema.StreamReader[callbacks.CallbackInput]) context.Context {
+			input.Close()
+			return onStart(ctx, info)
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, _ callbacks.CallbackOutput) context.Context {
+			return onEnd(ctx, info)
+		}).
+		OnEndWithStreamOutputFn(func(ctx context.Context, info *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+			output.Close()
+			return onEnd(ctx, info)
+		}).
+		OnErrorFn(func(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+			if logging {
+				n.log("error", info, "error", err)
+			}
+			return ctx
+		}).
+		Build()
+}
+
+func (n *notifier) log(level string, info *callbacks.RunInfo, event string, err error) {
+	data := map[string]any{"event": event}
+	if info != nil {
+		data["name"] = info.Name
+		data["type"] = info.Type
+		data["component"] = string(info.Component)
+	}
+	if err != nil {
+		data["error"] = err.Error()
+	}
+	_ = n.send(methodMessage, map[string]any{
+		"level":  level,
+		"logger": loggerName,
+		"data":   data,
+	})
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func newPromptHandler(p *Prompt) server.PromptHandlerFunc {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		for _, arg := range p.Arguments {
+			if _, ok := request.Params.Arguments[arg.Name]; arg.Required && !ok {
+				return nil, fmt.Errorf("missing required argument %s of prompt %s", arg.Name, p.Name)
+			}
+		}
+
+		vs := make(map[string]any, len(request.Params.Arguments))
+		for k, v := range request.Params.Arguments {
+			vs[k] = v
+		}
+		msgs, err := p.Template.Format(ctx, vs)
+		if err != nil {
+			return nil, fmt.Errorf("format prompt %s fail: %w", p.Name, err)
+		}
+
+		ret := &mcp.GetPromptResult{Description: p.Description}
+		for _, msg := range msgs {
+			ret.Messages = append(ret.Messages, toPromptMessages(msg)...)
+		}
+		return ret, nil
+	}
+}
+
+// toPromptMessages converts a message to MCP prompt messages, one for each content.
+// MCP prompts only have user and assistant roles, messages of the other roles are sent as user messages.
+func toPromptMessages(msg *schema.Message) []mcp.PromptMessage {
+	if msg == nil {
+		return nil
+	}
+	role := mcp.RoleUser
+	if msg.Role == schema.Assistant {
+		role = mcp.RoleAssistant
+	}
+
+	var ret []mcp.PromptMessage
+	if msg.Content != "" {
+		ret = append(ret, mcp.NewPromptMessage(role, mcp.NewTextContent(msg.Content)))
+	}
+	for _, part := range msg.MultiContent {
+		switch part.Type {
+		case schema.ChatMessagePartTypeText:
+			ret = append(ret, mcp.NewPromptMessage(role, mcp.NewTextContent(part.Text)))
+		case schema.ChatMessagePartTypeImageURL:
+			if part.ImageURL == nil {
+				continue
+			}
+			if mimeType, data, ok := parseDataURL(part.ImageURL.URL); ok {
+				ret = append(ret, mcp.NewPromptMessage(role, mcp.NewImageContent(data, mimeType)))
+			} else {
+				// MCP image contents must be inline, so remote images are referenced by text
+				ret = append(ret, mcp.NewPromptMessage(role, mcp.NewTextContent(part.ImageURL.URL)))
+			}
+		}
+	}
+	return ret
+}
+
+// parseDataURL parses a base64 data URL, e.g. "data:image/png;base64,xxx".
+func parseDataURL(url string) (mimeType, data string, ok bool) {
+	rest, ok := strings.CutPrefix(url, "data:")
+	if !ok {
+		return "", "", false
+	}
+	meta, data, ok := strings.Cut(rest, ",")
+	if !ok {
+		return "", "", false
+	}
+	mimeType, ok = strings.CutSuffix(meta, ";base64")
+	if !ok {
+		return "", "", false
+	}
+	return mimeType, data, true
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type Config struct {
+	// Name is the server name reported to the clients on initialization.
+	Name string
+	// Version is the server version reported to the clients on initialization.
+	// Default: "1.0.0".
+	Version string
+
+	// Tools are served as MCP tools, tools must be invokable or streamable.
+	// Compiled graphs can be served by wrapping them with NewGraphTool.
+	Tools []tool.BaseTool
+	// Prompts are served as MCP prompts.
+	Prompts []*Prompt
+
+	// SingleSession declares that the server serves a single session, e.g. by server.ServeStdio.
+	// Progress and log notifications are only sent with a single session: mcp-go v0.10.3 sends notifications to the client
+	// of the latest request of the server, so with SSE serving several sessions they may be delivered to another session.
+	SingleSession bool
+	// Logging if true, the server declares the logging capability and the callbacks of eino components
+	// run by a tool call are sent to the client as notifications/message.
+	// It requires SingleSession, NewServer fails otherwise.
+	Logging bool
+	// Callbacks are the extra callback handlers injected into each tool call.
+	Callbacks []callbacks.Handler
+}
+
+// Prompt is a ChatTemplate served as a MCP prompt.
+type Prompt struct {
+	Name        string
+	Description string
+	// Arguments declares the variables of Template, required arguments are checked before formatting.
+	Arguments []mcp.PromptArgument
+	Template  prompt.ChatTemplate
+}
+
+// NewServer creates a MCP server serving the tools and prompts in conf.
+// The returned server can be served with server.ServeStdio or server.NewSSEServer of mcp-go.
+func NewServer(ctx context.Context, conf *Config) (*server.MCPServer, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	if conf.Name == "" {
+		return nil, fmt.Errorf("server name is required")
+	}
+	version := conf.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	if conf.Logging && !conf.SingleSession {
+		return nil, fmt.Errorf("logging requires a single session, see Config.SingleSession")
+	}
+
+	var opts []server.ServerOption
+	if conf.Logging {
+		opts = append(opts, server.WithLogging())
+	}
+	if len(conf.Prompts) > 0 {
+		opts = append(opts, server.WithPromptCapabilities(false))
+	}
+	svr := server.NewMCPServer(conf.Name, version, opts...)
+	n := newNotifier(svr)
+
+	names := make(map[string]struct{}, len(conf.Tools))
+	for _, t := range conf.Tools {
+		mt, handler, err := newToolHandler(ctx, t, conf, n)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := names[mt.Name]; ok {
+			return nil, fmt.Errorf("duplicate tool name: %s", mt.Name)
+		}
+		names[mt.Name] = struct{}{}
+		svr.AddTool(mt, handler)
+	}
+
+	names = make(map[string]struct{}, len(conf.Prompts))
+	for _, p := range conf.Prompts {
+		if p == nil || p.Template == nil {
+			return nil, fmt.Errorf("prompt template is required")
+		}
+		if p.Name == "" {
+			return nil, fmt.Errorf("prompt name is required")
+		}
+		if _, ok := names[p.Name]; ok {
+			return nil, fmt.Errorf("duplicate prompt name: %s", p.Name)
+		}
+		names[p.Name] = struct{}{}
+		svr.AddPrompt(mcp.Prompt{
+			Name:        p.Name,
+			Description: p.Description,
+			Arguments:   p.Arguments,
+		}, newPromptHandler(p))
+	}
+
+	return svr, nil
+}
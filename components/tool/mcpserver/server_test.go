@@ -0,0 +1,258 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServer(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewServer(ctx, &Config{Name: "demo", Tools: []tool.BaseTool{&mockTool{name: "echo"}}})
+	assert.NoError(t, err)
+
+	_, err = NewServer(ctx, &Config{Name: "demo", Tools: []tool.BaseTool{&mockTool{name: "echo"}, &mockTool{name: "echo"}}})
+	assert.EqualError(t, err, "duplicate tool name: echo")
+
+	_, err = NewServer(ctx, &Config{Name: "demo", Prompts: []*Prompt{{Name: "p"}}})
+	assert.EqualError(t, err, "prompt template is required")
+
+	_, err = NewServer(ctx, &Config{})
+	assert.EqualError(t, err, "server name is required")
+
+	_, err = NewServer(ctx, &Config{Name: "demo", Logging: true})
+	assert.EqualError(t, err, "logging requires a single session, see Config.SingleSession")
+
+	_, err = NewServer(ctx, &Config{Name: "demo", SingleSession: true, Logging: true})
+	assert.NoError(t, err)
+}
+
+func TestToInputSchema(t *testing.T) {
+	s, err := toInputSchema(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, mcp.ToolInputSchema{Type: "object"}, s)
+
+	s, err = toInputSchema(schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"query": {Type: schema.String, Desc: "search query", Required: true},
+		"limit": {Type: schema.Integer},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"query"}, s.Required)
+	assert.Equal(t, map[string]any{"type": "string", "description": "search query"}, s.Properties["query"])
+	assert.Equal(t, map[string]any{"type": "integer"}, s.Properties["limit"])
+}
+
+func TestToolHandler(t *testing.T) {
+	ctx := context.Background()
+	svr := &mockServer{}
+	conf := &Config{SingleSession: true, Logging: true}
+
+	mt, handler, err := newToolHandler(ctx, &mockTool{name: "echo"}, conf, newNotifier(svr))
+	assert.NoError(t, err)
+	assert.Equal(t, "echo", mt.Name)
+	assert.Equal(t, "echo tool", mt.Description)
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "echo"
+	req.Params.Arguments = map[string]any{"text": "hello"}
+	req.Params.Meta = &struct {
+		ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+	}{ProgressToken: "token"}
+	result, err := handler(ctx, req)
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent(`{"text":"hello"}`)}, result.Content)
+
+	assert.Equal(t, []string{methodMessage, methodMessage, methodProgress}, svr.methods())
+	assert.Equal(t, map[string]any{"event": "start", "name": "echo", "type": "Mock", "component": "Tool"}, svr.params[0]["data"])
+	assert.Equal(t, map[string]any{"progressToken": "token", "progress": int64(1)}, svr.params[2])
+
+	// errors are returned as error results
+	svr.reset()
+	_, handler, err = newToolHandler(ctx, &mockTool{name: "fail", err: errors.New("bad input")}, &Config{}, newNotifier(svr))
+	assert.NoError(t, err)
+	result, err = handler(ctx, mcp.CallToolRequest{})
+	assert.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("bad input")}, result.Content)
+	assert.Empty(t, svr.methods())
+
+	// streamable tools are collected
+	_, handler, err = newToolHandler(ctx, &mockStreamTool{baseTool{name: "stream"}}, &Config{}, newNotifier(svr))
+	assert.NoError(t, err)
+	result, err = handler(ctx, mcp.CallToolRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("a b c")}, result.Content)
+
+	_, _, err = newToolHandler(ctx, &baseTool{name: "base"}, &Config{}, newNotifier(svr))
+	assert.EqualError(t, err, "tool base is not invokable or streamable")
+}
+
+type addInput struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+func TestGraphTool(t *testing.T) {
+	ctx := context.Background()
+
+	r, err := compose.NewChain[*addInput, int]().
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, in *addInput) (int, error) {
+			return in.A + in.B, nil
+		})).
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, in int) (int, error) {
+			return in * 2, nil
+		})).
+		Compile(ctx)
+	assert.NoError(t, err)
+
+	gt := NewGraphTool[*addInput, int](&schema.ToolInfo{Name: "double_sum", Desc: "double the sum"}, r)
+	output, err := gt.InvokableRun(ctx, `{"a":1,"b":2}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "6", output)
+
+	svr := &mockServer{}
+	_, handler, err := newToolHandler(ctx, gt, &Config{SingleSession: true}, newNotifier(svr))
+	assert.NoError(t, err)
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"a": 2, "b": 3}
+	req.Params.Meta = &struct {
+		ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+	}{ProgressToken: 1}
+	result, err := handler(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []mcp.Content{mcp.NewTextContent("10")}, result.Content)
+	// the graph and its two nodes
+	assert.Equal(t, []string{methodProgress, methodProgress, methodProgress}, svr.methods())
+	assert.Equal(t, int64(3), svr.params[2]["progress"])
+
+	// no notifications if several sessions may be served
+	svr.reset()
+	_, handler, err = newToolHandler(ctx, gt, &Config{}, newNotifier(svr))
+	assert.NoError(t, err)
+	_, err = handler(ctx, req)
+	assert.NoError(t, err)
+	assert.Empty(t, svr.methods())
+}
+
+func TestPromptHandler(t *testing.T) {
+	ctx := context.Background()
+	handler := newPromptHandler(&Prompt{
+		Name:        "ask",
+		Description: "ask a question",
+		Arguments:   []mcp.PromptArgument{{Name: "question", Required: true}},
+		Template: prompt.FromMessages(schema.FString,
+			schema.SystemMessage("you are a helpful assistant"),
+			schema.UserMessage("{question}"),
+			&schema.Message{Role: schema.User, MultiContent: []schema.ChatMessagePart{
+				{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "data:image/png;base64,aGVsbG8="}},
+				{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "https://example.com/a.png"}},
+			}},
+			schema.AssistantMessage("sure", nil),
+		),
+	})
+
+	req := mcp.GetPromptRequest{}
+	_, err := handler(ctx, req)
+	assert.EqualError(t, err, "missing required argument question of prompt ask")
+
+	req.Params.Arguments = map[string]string{"question": "what is eino?"}
+	result, err := handler(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "ask a question", result.Description)
+	assert.Equal(t, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("you are a helpful assistant")),
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("what is eino?")),
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewImageContent("aGVsbG8=", "image/png")),
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("https://example.com/a.png")),
+		mcp.NewPromptMessage(mcp.RoleAssistant, mcp.NewTextContent("sure")),
+	}, result.Messages)
+}
+
+type mockServer struct {
+	mu     sync.Mutex
+	calls  []string
+	params []map[string]any
+}
+
+func (m *mockServer) SendNotificationToClient(method string, params map[string]any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, method)
+	m.params = append(m.params, params)
+	return nil
+}
+
+func (m *mockServer) methods() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+func (m *mockServer) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls, m.params = nil, nil
+}
+
+type baseTool struct {
+	name string
+}
+
+func (b *baseTool) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: b.name}, nil
+}
+
+type mockTool struct {
+	name string
+	err  error
+}
+
+func (m *mockTool) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: m.name, Desc: m.name + " tool"}, nil
+}
+
+func (m *mockTool) InvokableRun(_ context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	return argumentsInJSON, nil
+}
+
+func (m *mockTool) GetType() string {
+	return "Mock"
+}
+
+type mockStreamTool struct {
+	baseTool
+}
+
+func (m *mockStreamTool) StreamableRun(_ context.Context, _ string, _ ...tool.Option) (*schema.StreamReader[string], error) {
+	return schema.StreamReaderFromArray([]string{"a", " b", " c"}), nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func newToolHandler(ctx context.Context, t tool.BaseTool, conf *Config, n *notifier) (mcp.Tool, server.ToolHandlerFunc, error) {
+	info, err := t.Info(ctx)
+	if err != nil {
+		return mcp.Tool{}, nil, fmt.Errorf("get tool info fail: %w", err)
+	}
+	if info == nil || info.Name == "" {
+		return mcp.Tool{}, nil, fmt.Errorf("tool name is required")
+	}
+
+	it, _ := t.(tool.InvokableTool)
+	st, _ := t.(tool.StreamableTool)
+	if it == nil && st == nil {
+		return mcp.Tool{}, nil, fmt.Errorf("tool %s is not invokable or streamable", info.Name)
+	}
+
+	inputSchema, err := toInputSchema(info.ParamsOneOf)
+	if err != nil {
+		return mcp.Tool{}, nil, fmt.Errorf("conv input schema of tool %s fail: %w", info.Name, err)
+	}
+
+	runInfo := &callbacks.RunInfo{Name: info.Name, Component: components.ComponentOfTool}
+	if typ, ok := components.GetType(t); ok {
+		runInfo.Type = typ
+	}
+	// same as ToolsNode, callbacks are triggered here for the tools that don't trigger callbacks by themselves
+	withCallbacks := !components.IsCallbacksEnabled(t)
+
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := "{}"
+		if len(request.Params.Arguments) > 0 {
+			var err error
+			args, err = sonic.MarshalString(request.Params.Arguments)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("marshal arguments fail: %v", err)), nil
+			}
+		}
+
+		var token mcp.ProgressToken
+		if conf.SingleSession && request.Params.Meta != nil {
+			token = request.Params.Meta.ProgressToken
+		}
+		handlers := make([]callbacks.Handler, 0, len(conf.Callbacks)+1)
+		handlers = append(handlers, conf.Callbacks...)
+		if h := n.handler(token, conf.Logging); h != nil {
+			handlers = append(handlers, h)
+		}
+		ctx = callbacks.InitCallbacks(ctx, runInfo, handlers...)
+
+		output, err := runTool(ctx, it, st, args, withCallbacks)
+		if err != nil {
+			// errors are reported in the result, so that the client and its model can see them
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	return mcp.Tool{
+		Name:        info.Name,
+		Description: info.Desc,
+		InputSchema: inputSchema,
+	}, handler, nil
+}
+
+func runTool(ctx context.Context, it tool.InvokableTool, st tool.StreamableTool, args string, withCallbacks bool) (output string, err error) {
+	if withCallbacks {
+		ctx = callbacks.OnStart(ctx, &tool.CallbackInput{ArgumentsInJSON: args})
+		defer func() {
+			if err != nil {
+				callbacks.OnError(ctx, err)
+				return
+			}
+			callbacks.OnEnd(ctx, &tool.CallbackOutput{Response: output})
+		}()
+	}
+
+	if it != nil {
+		return it.InvokableRun(ctx, args)
+	}
+
+	sr, err := st.StreamableRun(ctx, args)
+	if err != nil {
+		return "", err
+	}
+	defer sr.Close()
+
+	var sb strings.Builder
+	for {
+		chunk, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(chunk)
+	}
+	return sb.String(), nil
+}
+
+// toInputSchema converts the params of a tool to the input schema of MCP tools, which must be an object.
+func toInputSchema(params *schema.ParamsOneOf) (mcp.ToolInputSchema, error) {
+	ret := mcp.ToolInputSchema{Type: "object"}
+
+	s, err := params.ToOpenAPIV3()
+	if err != nil {
+		return ret, err
+	}
+	if s == nil {
+		return ret, nil
+	}
+	if s.Type != "" && s.Type != "object" {
+		return ret, fmt.Errorf("input schema should be object, got %s", s.Type)
+	}
+
+	b, err := sonic.Marshal(s)
+	if err != nil {
+		return ret, fmt.Errorf("marshal schema fail: %w", err)
+	}
+	var obj struct {
+		Properties map[string]any `json:"properties"`
+		Required   []string       `json:"required"`
+	}
+	if err = sonic.Unmarshal(b, &obj); err != nil {
+		return ret, fmt.Errorf("unmarshal schema fail: %w", err)
+	}
+	ret.Properties = obj.Properties
+	ret.Required = obj.Required
+	return ret, nil
+}
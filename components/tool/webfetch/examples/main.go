@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino-ext/components/tool/webfetch"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tool, err := webfetch.NewTool(ctx, &webfetch.Config{
+		MaxLength:     3000,
+		DeniedDomains: []string{"localhost"},
+	})
+	if err != nil {
+		log.Fatal("Failed to create tool:", err)
+	}
+
+	req, err := json.Marshal(&webfetch.FetchRequest{URL: "https://go.dev/doc/gc-guide"})
+	if err != nil {
+		log.Fatal("Failed to marshal fetch request:", err)
+	}
+
+	// read the page by pages until the content ends
+	for {
+		resp, err := tool.InvokableRun(ctx, string(req))
+		if err != nil {
+			log.Fatal("Fetch failed:", err)
+		}
+
+		var page webfetch.FetchResponse
+		if err = json.Unmarshal([]byte(resp), &page); err != nil {
+			log.Fatal("Failed to unmarshal fetch response:", err)
+		}
+		fmt.Println(page.Content)
+
+		if page.NextIndex == 0 {
+			break
+		}
+		req, _ = json.Marshal(&webfetch.FetchRequest{URL: page.URL, StartIndex: page.NextIndex})
+	}
+}
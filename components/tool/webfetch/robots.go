@@ -0,0 +1,231 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webfetch
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// maxRobotsSize is the size of robots.txt to parse, ref: RFC 9309.
+const maxRobotsSize = 500 << 10
+
+// robotsRules is the rules of a robots.txt that apply to the user agent of the tool.
+// TODO: use the robots package of the url loader once it is in a published version.
+type robotsRules struct {
+	rules []*robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+type robotsGroup struct {
+	agents []string
+	rules  []*robotsRule
+}
+
+// robotsCache fetches robots.txt once per origin.
+type robotsCache struct {
+	client    *http.Client
+	userAgent string
+
+	mu    sync.Mutex
+	rules map[string]*robotsRules
+}
+
+// allowed reports whether u may be fetched according to the robots.txt of its origin.
+func (c *robotsCache) allowed(ctx context.Context, u *url.URL) (bool, error) {
+	origin := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	rules, ok := c.rules[origin]
+	c.mu.Unlock()
+	if !ok {
+		var err error
+		rules, err = c.fetch(ctx, origin)
+		if err != nil {
+			return false, err
+		}
+		c.mu.Lock()
+		c.rules[origin] = rules
+		c.mu.Unlock()
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return rules.allowed(path), nil
+}
+
+// fetch gets the robots.txt of origin, a missing robots.txt allows everything,
+// while an unreachable one is an error, which is not cached, ref: RFC 9309.
+func (c *robotsCache) fetch(ctx context.Context, origin string) (*robotsRules, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch robots.txt of %s fail: %w", origin, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return parseRobots(io.LimitReader(resp.Body, maxRobotsSize), c.userAgent), nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("fetch robots.txt of %s fail: status %d", origin, resp.StatusCode)
+	}
+}
+
+// parseRobots parses a robots.txt and keeps the group matching userAgent,
+// falling back to the "*" group.
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	var (
+		groups  []*robotsGroup
+		cur     *robotsGroup
+		inRules bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if cur == nil || inRules {
+				cur = &robotsGroup{}
+				groups = append(groups, cur)
+				inRules = false
+			}
+			cur.agents = append(cur.agents, strings.ToLower(value))
+		case "allow", "disallow":
+			if cur == nil {
+				continue
+			}
+			inRules = true
+			if value == "" {
+				continue
+			}
+			cur.rules = append(cur.rules, &robotsRule{
+				allow:   key == "allow",
+				pattern: value,
+				re:      compileRobotsPattern(value),
+			})
+		}
+	}
+
+	ret := &robotsRules{}
+	if g := selectRobotsGroup(groups, userAgent); g != nil {
+		ret.rules = g.rules
+	}
+	return ret
+}
+
+// selectRobotsGroup picks the group with the longest agent token contained in userAgent, or the "*" group.
+func selectRobotsGroup(groups []*robotsGroup, userAgent string) *robotsGroup {
+	ua := strings.ToLower(userAgent)
+
+	var (
+		best     *robotsGroup
+		bestLen  int
+		wildcard *robotsGroup
+	)
+	for _, g := range groups {
+		for _, agent := range g.agents {
+			if agent == "*" {
+				if wildcard == nil {
+					wildcard = g
+				}
+				continue
+			}
+			if agent != "" && strings.Contains(ua, agent) && len(agent) > bestLen {
+				best, bestLen = g, len(agent)
+			}
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	return wildcard
+}
+
+// compileRobotsPattern supports the "*" wildcard and the "$" end anchor.
+func compileRobotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+
+	return regexp.MustCompile(expr)
+}
+
+// allowed reports whether path (with query) may be fetched, the longest matching rule wins and allow wins ties.
+// A nil rules allows everything.
+func (r *robotsRules) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+
+	allow, matchLen := true, -1
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		l := len(rule.pattern)
+		if l > matchLen || (l == matchLen && rule.allow) {
+			allow, matchLen = rule.allow, l
+		}
+	}
+
+	return allow
+}
@@ -0,0 +1,367 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webfetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"golang.org/x/net/html/charset"
+)
+
+var (
+	// ErrDomainNotAllowed is returned when the domain of the url is denied or not in the allowed domains.
+	ErrDomainNotAllowed = errors.New("domain is not allowed")
+	// ErrDisallowedByRobots is returned when the robots.txt of the site disallows the url.
+	ErrDisallowedByRobots = errors.New("url is disallowed by robots.txt")
+	// ErrTooLarge is returned when the response body exceeds Config.MaxBodySize.
+	ErrTooLarge = errors.New("response body is too large")
+)
+
+// Config is the configuration for the web fetch tool.
+type Config struct {
+	ToolName string `json:"tool_name"` // Optional. Default: "web_fetch".
+	ToolDesc string `json:"tool_desc"` // Optional. Default: "fetch a web page by url and read its content, long contents are returned in pages ..."
+
+	// HTTPClient is the client to send requests, its CheckRedirect is replaced to apply the domain and robots rules to redirects.
+	// Optional. Default: a client with Timeout.
+	HTTPClient *http.Client `json:"-"`
+	// Timeout is the timeout of the default http client.
+	// Optional. Default: 30s.
+	Timeout time.Duration `json:"timeout"`
+	// UserAgent is sent in requests and matched against the groups of robots.txt.
+	// Optional. Default: "eino (https://github.com/cloudwego/eino)".
+	UserAgent string `json:"user_agent"`
+	// MaxRedirects is the maximum number of redirects to follow.
+	// Optional. Default: 5.
+	MaxRedirects int `json:"max_redirects"`
+
+	// MaxBodySize is the maximum size of the response body in bytes, larger pages fail with ErrTooLarge.
+	// Optional. Default: 5MB.
+	MaxBodySize int64 `json:"max_body_size"`
+	// MaxLength is the maximum number of characters of content returned by a call,
+	// longer contents are paginated by the start_index of the request.
+	// Optional. Default: 5000.
+	MaxLength int `json:"max_length"`
+
+	// AllowedDomains if not empty, only the urls of these domains and their subdomains can be fetched.
+	AllowedDomains []string `json:"allowed_domains"`
+	// DeniedDomains are the domains, including their subdomains, that cannot be fetched, prior to AllowedDomains.
+	DeniedDomains []string `json:"denied_domains"`
+	// IgnoreRobots if true, robots.txt is not checked.
+	IgnoreRobots bool `json:"ignore_robots"`
+
+	// Parser extracts the content of HTML pages, the title is read from the html.MetaKeyTitle metadata.
+	// Set it to an html parser with readability and Markdown conversion to read the main content as Markdown.
+	// Optional. Default: the html parser, which extracts the text of <body>.
+	Parser parser.Parser `json:"-"`
+}
+
+// NewTool creates a new web fetch tool.
+func NewTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
+	f, err := newFetcher(ctx, conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create web fetch tool: %w", err)
+	}
+	t, err := utils.InferTool(conf.ToolName, conf.ToolDesc, f.Fetch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return t, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.ToolName == "" {
+		conf.ToolName = "web_fetch"
+	}
+	if conf.ToolDesc == "" {
+		conf.ToolDesc = "fetch a web page by url and read its content, " +
+			"long contents are returned in pages, use next_index of the result as start_index to read the next page"
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.UserAgent == "" {
+		conf.UserAgent = "eino (https://github.com/cloudwego/eino)"
+	}
+	if conf.MaxRedirects <= 0 {
+		conf.MaxRedirects = 5
+	}
+	if conf.MaxBodySize <= 0 {
+		conf.MaxBodySize = 5 << 20
+	}
+	if conf.MaxLength <= 0 {
+		conf.MaxLength = 5000
+	}
+	if conf.Parser == nil {
+		p, err := html.NewParser(context.Background(), &html.Config{Selector: &html.BodySelector})
+		if err != nil {
+			return fmt.Errorf("failed to create html parser: %w", err)
+		}
+		conf.Parser = p
+	}
+	return nil
+}
+
+func newFetcher(ctx context.Context, conf *Config) (*fetcher, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	base := conf.HTTPClient
+	if base == nil {
+		base = &http.Client{Timeout: conf.Timeout}
+	}
+
+	f := &fetcher{
+		conf:    conf,
+		parser:  conf.Parser,
+		allowed: normalizeDomains(conf.AllowedDomains),
+		denied:  normalizeDomains(conf.DeniedDomains),
+	}
+	if !conf.IgnoreRobots {
+		f.robots = &robotsCache{
+			client:    base,
+			userAgent: conf.UserAgent,
+			rules:     make(map[string]*robotsRules),
+		}
+	}
+
+	client := *base
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= conf.MaxRedirects {
+			return fmt.Errorf("stopped after %d redirects", conf.MaxRedirects)
+		}
+		return f.check(req.Context(), req.URL)
+	}
+	f.client = &client
+
+	return f, nil
+}
+
+type fetcher struct {
+	conf   *Config
+	client *http.Client
+	parser parser.Parser
+	robots *robotsCache
+
+	allowed []string
+	denied  []string
+}
+
+// FetchRequest is the request of the web fetch tool.
+type FetchRequest struct {
+	URL        string `json:"url" jsonschema_description:"The http(s) url of the web page to fetch"`
+	StartIndex int    `json:"start_index,omitempty" jsonschema_description:"The character offset of the content to start reading from, use next_index of the previous result to read the next page, default: 0"`
+	MaxLength  int    `json:"max_length,omitempty" jsonschema_description:"The maximum number of characters to return, default and upper limit are set by the tool"`
+}
+
+// FetchResponse is the response of the web fetch tool.
+type FetchResponse struct {
+	URL         string `json:"url" jsonschema_description:"The final url of the page after redirects"`
+	Title       string `json:"title,omitempty" jsonschema_description:"The title of the page"`
+	Content     string `json:"content" jsonschema_description:"The content of the page, from start_index"`
+	TotalLength int    `json:"total_length" jsonschema_description:"The number of characters of the whole content"`
+	NextIndex   int    `json:"next_index,omitempty" jsonschema_description:"The start_index to read the next page, absent if the content ends"`
+}
+
+// Fetch downloads the url and returns a page of its content.
+func (f *fetcher) Fetch(ctx context.Context, request *FetchRequest) (*FetchResponse, error) {
+	u, err := url.Parse(request.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %s: %w", request.URL, err)
+	}
+	if err = f.check(ctx, u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.conf.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9,*/*;q=0.8")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s fail: %w", request.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("fetch %s fail: status %d", request.URL, resp.StatusCode)
+	}
+	if resp.ContentLength > f.conf.MaxBodySize {
+		return nil, fmt.Errorf("fetch %s fail: %w, %d bytes", request.URL, ErrTooLarge, resp.ContentLength)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.conf.MaxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s fail: %w", request.URL, err)
+	}
+	if int64(len(body)) > f.conf.MaxBodySize {
+		return nil, fmt.Errorf("fetch %s fail: %w, more than %d bytes", request.URL, ErrTooLarge, f.conf.MaxBodySize)
+	}
+
+	finalURL := resp.Request.URL.String()
+	title, content, err := f.extract(ctx, finalURL, resp.Header.Get("Content-Type"), body)
+	if err != nil {
+		return nil, fmt.Errorf("extract content of %s fail: %w", request.URL, err)
+	}
+
+	maxLength := f.conf.MaxLength
+	if request.MaxLength > 0 && request.MaxLength < maxLength {
+		maxLength = request.MaxLength
+	}
+	page, next, total, err := paginate(content, request.StartIndex, maxLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FetchResponse{
+		URL:         finalURL,
+		Title:       title,
+		Content:     page,
+		TotalLength: total,
+		NextIndex:   next,
+	}, nil
+}
+
+// check applies the scheme, domain and robots rules to u.
+func (f *fetcher) check(ctx context.Context, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme of url %s, only http and https are supported", u)
+	}
+	if !f.domainAllowed(u.Hostname()) {
+		return fmt.Errorf("%w: %s", ErrDomainNotAllowed, u.Hostname())
+	}
+	if f.robots == nil {
+		return nil
+	}
+	allowed, err := f.robots.allowed(ctx, u)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s", ErrDisallowedByRobots, u)
+	}
+	return nil
+}
+
+func (f *fetcher) domainAllowed(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, d := range f.denied {
+		if matchDomain(host, d) {
+			return false
+		}
+	}
+	if len(f.allowed) == 0 {
+		return true
+	}
+	for _, d := range f.allowed {
+		if matchDomain(host, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDomain reports whether host is domain or its subdomain.
+func matchDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// normalizeDomains lowercases the domains and strips the leading "*." or ".".
+func normalizeDomains(domains []string) []string {
+	ret := make([]string, 0, len(domains))
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		d = strings.TrimPrefix(strings.TrimPrefix(d, "*"), ".")
+		if d != "" {
+			ret = append(ret, d)
+		}
+	}
+	return ret
+}
+
+// extract returns the title and the content of the body, HTML is parsed by Config.Parser and text is returned as is.
+func (f *fetcher) extract(ctx context.Context, uri, contentType string, body []byte) (title, content string, err error) {
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid content type %s: %w", contentType, err)
+	}
+
+	reader, err := charset.NewReader(bytes.NewReader(body), contentType)
+	if err != nil {
+		return "", "", fmt.Errorf("decode charset fail: %w", err)
+	}
+
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		docs, err := f.parser.Parse(ctx, reader, parser.WithURI(uri))
+		if err != nil {
+			return "", "", err
+		}
+		if len(docs) == 0 {
+			return "", "", nil
+		}
+		title, _ = docs[0].MetaData[html.MetaKeyTitle].(string)
+		return title, docs[0].Content, nil
+	case strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || mediaType == "application/xml" ||
+		strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml"):
+		text, err := io.ReadAll(reader)
+		if err != nil {
+			return "", "", err
+		}
+		return "", string(text), nil
+	default:
+		return "", "", fmt.Errorf("unsupported content type %s", mediaType)
+	}
+}
+
+// paginate returns the page of content from start, and the start of the next page if any, offsets are in characters.
+func paginate(content string, start, maxLength int) (page string, next, total int, err error) {
+	runes := []rune(content)
+	total = len(runes)
+	if start < 0 || (start > 0 && start >= total) {
+		return "", 0, total, fmt.Errorf("start_index %d is out of the content length %d", start, total)
+	}
+
+	end := start + maxLength
+	if end >= total {
+		return string(runes[start:]), 0, total, nil
+	}
+	return string(runes[start:end]), end, total, nil
+}
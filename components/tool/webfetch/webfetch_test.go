@@ -0,0 +1,244 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webfetch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const article = `<html><head><title>Tuning GC</title></head><body>
+<nav><a href="/">Home</a> <a href="/archive">Archive</a></nav>
+<article>
+<h1>Tuning GC</h1>
+<p>The garbage collector trades memory for CPU time, and understanding that trade-off is the key to tuning it well in production services.</p>
+<p>There are two settings worth knowing, both can be set through the environment or at runtime, see the <a href="/guide">guide</a>.</p>
+</article>
+<footer>Copyright 2025</footer>
+</body></html>`
+
+func newTestServer(robotsStatus int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		if robotsStatus != http.StatusOK {
+			w.WriteHeader(robotsStatus)
+			return
+		}
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /private\n\nUser-agent: strict-bot\nDisallow: /\n"))
+	})
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(article))
+	})
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("零一二三四五六七八九"))
+	})
+	mux.HandleFunc("/private/page", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("secret"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/private/page", http.StatusFound)
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(strings.Repeat("a", 2048)))
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFetch(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(http.StatusOK)
+	defer ts.Close()
+
+	f, err := newFetcher(ctx, &Config{MaxBodySize: 1024})
+	assert.NoError(t, err)
+
+	t.Run("html as text", func(t *testing.T) {
+		resp, err := f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/article"})
+		assert.NoError(t, err)
+		assert.Equal(t, ts.URL+"/article", resp.URL)
+		assert.Equal(t, "Tuning GC", resp.Title)
+		assert.Contains(t, resp.Content, "The garbage collector trades memory for CPU time")
+		assert.NotContains(t, resp.Content, "<p>")
+		assert.Equal(t, len([]rune(resp.Content)), resp.TotalLength)
+		assert.Equal(t, 0, resp.NextIndex)
+	})
+
+	t.Run("custom parser", func(t *testing.T) {
+		md, err := newFetcher(ctx, &Config{Parser: &markdownParser{}})
+		assert.NoError(t, err)
+		resp, err := md.Fetch(ctx, &FetchRequest{URL: ts.URL + "/article"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Tuning GC", resp.Title)
+		assert.Equal(t, "# Tuning GC\n\n"+ts.URL+"/article", resp.Content)
+	})
+
+	t.Run("paginate by characters", func(t *testing.T) {
+		resp, err := f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/text", MaxLength: 4})
+		assert.NoError(t, err)
+		assert.Equal(t, &FetchResponse{URL: ts.URL + "/text", Content: "零一二三", TotalLength: 10, NextIndex: 4}, resp)
+
+		resp, err = f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/text", StartIndex: 8, MaxLength: 4})
+		assert.NoError(t, err)
+		assert.Equal(t, "八九", resp.Content)
+		assert.Equal(t, 0, resp.NextIndex)
+
+		_, err = f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/text", StartIndex: 10})
+		assert.EqualError(t, err, "start_index 10 is out of the content length 10")
+	})
+
+	t.Run("robots", func(t *testing.T) {
+		_, err := f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/private/page"})
+		assert.True(t, errors.Is(err, ErrDisallowedByRobots))
+
+		// redirects are checked too
+		_, err = f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/redirect"})
+		assert.True(t, errors.Is(err, ErrDisallowedByRobots))
+
+		strict, err := newFetcher(ctx, &Config{UserAgent: "strict-bot/1.0"})
+		assert.NoError(t, err)
+		_, err = strict.Fetch(ctx, &FetchRequest{URL: ts.URL + "/article"})
+		assert.True(t, errors.Is(err, ErrDisallowedByRobots))
+
+		ignore, err := newFetcher(ctx, &Config{UserAgent: "strict-bot/1.0", IgnoreRobots: true})
+		assert.NoError(t, err)
+		resp, err := ignore.Fetch(ctx, &FetchRequest{URL: ts.URL + "/private/page"})
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", resp.Content)
+	})
+
+	t.Run("size and content type", func(t *testing.T) {
+		_, err := f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/big"})
+		assert.True(t, errors.Is(err, ErrTooLarge))
+
+		_, err = f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/image"})
+		assert.ErrorContains(t, err, "unsupported content type image/png")
+
+		_, err = f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/missing"})
+		assert.ErrorContains(t, err, "status 404")
+
+		_, err = f.Fetch(ctx, &FetchRequest{URL: "file:///etc/passwd"})
+		assert.ErrorContains(t, err, "unsupported scheme")
+	})
+
+	t.Run("domains", func(t *testing.T) {
+		allowed, err := newFetcher(ctx, &Config{AllowedDomains: []string{"example.com"}})
+		assert.NoError(t, err)
+		_, err = allowed.Fetch(ctx, &FetchRequest{URL: ts.URL + "/article"})
+		assert.True(t, errors.Is(err, ErrDomainNotAllowed))
+
+		denied, err := newFetcher(ctx, &Config{DeniedDomains: []string{"127.0.0.1"}})
+		assert.NoError(t, err)
+		_, err = denied.Fetch(ctx, &FetchRequest{URL: ts.URL + "/article"})
+		assert.True(t, errors.Is(err, ErrDomainNotAllowed))
+
+		d, err := newFetcher(ctx, &Config{AllowedDomains: []string{"*.Example.com"}, DeniedDomains: []string{"ads.example.com"}})
+		assert.NoError(t, err)
+		assert.True(t, d.domainAllowed("example.com"))
+		assert.True(t, d.domainAllowed("docs.example.com"))
+		assert.False(t, d.domainAllowed("cdn.ads.example.com"))
+		assert.False(t, d.domainAllowed("badexample.com"))
+	})
+}
+
+func TestRobotsUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	ts := newTestServer(http.StatusNotFound)
+	f, err := newFetcher(ctx, &Config{})
+	assert.NoError(t, err)
+	resp, err := f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/private/page"})
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", resp.Content)
+	ts.Close()
+
+	ts = newTestServer(http.StatusServiceUnavailable)
+	defer ts.Close()
+	_, err = f.Fetch(ctx, &FetchRequest{URL: ts.URL + "/article"})
+	assert.ErrorContains(t, err, "status 503")
+}
+
+func TestParseRobots(t *testing.T) {
+	robots := `
+# comment
+User-agent: eino
+User-agent: other
+Disallow: /tmp
+Allow: /tmp/public$
+Disallow: /*.pdf$
+
+User-agent: *
+Disallow: /
+`
+	rules := parseRobots(strings.NewReader(robots), "eino (https://github.com/cloudwego/eino)")
+	assert.True(t, rules.allowed("/"))
+	assert.False(t, rules.allowed("/tmp/a"))
+	assert.True(t, rules.allowed("/tmp/public"))
+	assert.False(t, rules.allowed("/tmp/public/a"))
+	assert.False(t, rules.allowed("/docs/a.pdf"))
+	assert.True(t, rules.allowed("/docs/a.pdf?download=1"))
+
+	rules = parseRobots(strings.NewReader(robots), "crawler")
+	assert.False(t, rules.allowed("/"))
+}
+
+func TestNewTool(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(http.StatusOK)
+	defer ts.Close()
+
+	wf, err := NewTool(ctx, &Config{})
+	assert.NoError(t, err)
+	info, err := wf.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "web_fetch", info.Name)
+
+	output, err := wf.InvokableRun(ctx, `{"url":"`+ts.URL+`/text","max_length":2}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"url":"`+ts.URL+`/text","content":"零一","total_length":10,"next_index":2}`, output)
+
+	_, err = NewTool(ctx, nil)
+	assert.Error(t, err)
+
+}
+
+// markdownParser stands for an html parser converting pages to Markdown.
+type markdownParser struct{}
+
+func (p *markdownParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	uri := parser.GetCommonOptions(&parser.Options{}, opts...).URI
+	return []*schema.Document{{
+		Content:  "# Tuning GC\n\n" + uri,
+		MetaData: map[string]any{html.MetaKeyTitle: "Tuning GC"},
+	}}, nil
+}